@@ -0,0 +1,80 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithAbbreviations makes Import accept unambiguous abbreviations of
+// the property names, as per ResolveProperty, e.g. "fg" for
+// "foreground" or "pad-l" for "padding-left". This is convenient for
+// interactive use, where the full names are tedious to type.
+func WithAbbreviations() ImportOption {
+	return func(o *importOptions) {
+		o.abbrev = true
+	}
+}
+
+// segmentAliases are the abbreviations of name segments that are not
+// prefixes of the segment.
+var segmentAliases = map[string]string{
+	"fg": "foreground",
+	"bg": "background",
+}
+
+// ResolveProperty returns the full name of the property designated by
+// an abbreviation. Each segment of the abbreviation, separated by
+// hyphens, must be a prefix of the corresponding segment of the
+// property name; in addition, "fg" and "bg" stand for "foreground" and
+// "background". For example, "pad-l" resolves to "padding-left" and
+// "border-t-fg" to "border-top-foreground". A full property name
+// resolves to itself.
+//
+// An error is returned if the abbreviation matches no property, or
+// more than one.
+func ResolveProperty(abbrev string) (string, error) {
+	if _, err := getProp(abbrev); err == nil {
+		return abbrev, nil
+	}
+	segs := strings.Split(abbrev, "-")
+	var matches []string
+	for _, name := range Properties() {
+		if matchAbbreviation(segs, strings.Split(name, "-")) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("property not supported: %q", abbrev)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("ambiguous property abbreviation %q: could be %s", abbrev, strings.Join(matches, ", "))
+}
+
+func matchAbbreviation(abbrev, name []string) bool {
+	if len(abbrev) != len(name) {
+		return false
+	}
+	for i, seg := range abbrev {
+		if seg == "" || (!strings.HasPrefix(name[i], seg) && segmentAliases[seg] != name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupProp finds the property for a directive, resolving
+// abbreviations if enabled. The full name of the property is returned.
+func (opt *importOptions) lookupProp(name string) (string, prop, error) {
+	p, err := getProp(name)
+	if err == nil || !opt.abbrev || opt.strict {
+		return name, p, err
+	}
+	full, aerr := ResolveProperty(name)
+	if aerr != nil {
+		return name, p, aerr
+	}
+	p, err = getProp(full)
+	return full, p, err
+}
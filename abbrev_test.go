@@ -0,0 +1,62 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestResolveProperty(t *testing.T) {
+	td := []struct {
+		in, exp, expErr string
+	}{
+		{in: "foreground", exp: "foreground"},
+		{in: "fg", exp: "foreground"},
+		{in: "bg", exp: "background"},
+		{in: "pad-l", exp: "padding-left"},
+		{in: "pad", exp: "padding"},
+		{in: "border-t-fg", exp: "border-top-foreground"},
+		{in: "max-w", exp: "max-width"},
+		{in: "b", expErr: `ambiguous property abbreviation "b": could be background, blink, bold, border`},
+		{in: "border-b", expErr: `ambiguous property abbreviation "border-b": could be border-background, border-bottom`},
+		{in: "pad-", expErr: `property not supported: "pad-"`},
+		{in: "xyz", expErr: `property not supported: "xyz"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			res, err := ResolveProperty(tc.in)
+			if tc.expErr != "" {
+				if err == nil || err.Error() != tc.expErr {
+					t.Errorf("expected %q, got %v", tc.expErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != tc.exp {
+				t.Errorf("expected %q, got %q", tc.exp, res)
+			}
+		})
+	}
+}
+
+func TestImportAbbreviations(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `fg: red; bg: 12; pad-l: 2; bo: true`, WithAbbreviations())
+	if err == nil || err.Error() != `in "bo: true": ambiguous property abbreviation "bo": could be bold, border` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	s, err = Import(lipgloss.NewStyle(), `fg: red; bg: 12; pad-l: 2; bol: true`, WithAbbreviations())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `background: 12; bold: true; foreground: #ff0000; padding-left: 2;`, Export(s))
+
+	// Abbreviations are not accepted by default.
+	if _, err := Import(lipgloss.NewStyle(), `fg: red`); err == nil || err.Error() != `in "fg: red": property not supported: "fg"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate(`pad-l: 2`, WithAbbreviations()); err != nil {
+		t.Error(err)
+	}
+}
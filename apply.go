@@ -0,0 +1,66 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithStyles makes the given styles available to the apply directive,
+// which composes them into the style being imported at the point
+// where the directive appears:
+//
+//	apply: base emphasized; padding: 0 1;
+//
+// The properties of the applied styles are copied in order, as per
+// Compose, over the properties set by the previous directives. The
+// option can be given multiple times; later maps take precedence.
+// ImportSheet makes the styles defined earlier in the sheet available
+// this way too.
+func WithStyles(styles map[string]S) ImportOption {
+	return func(o *importOptions) {
+		if o.styles == nil {
+			o.styles = make(map[string]S, len(styles))
+		}
+		for name, s := range styles {
+			o.styles[name] = s
+		}
+	}
+}
+
+// lookupStyles returns the styles named in the value of an apply
+// directive.
+func (opt *importOptions) lookupStyles(args string) ([]S, error) {
+	if opt.strict {
+		return nil, fmt.Errorf("extension property %q not allowed in strict mode", "apply")
+	}
+	args, err := expandVariables(args, opt.vars)
+	if err != nil {
+		return nil, err
+	}
+	names := strings.Fields(args)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("missing style name")
+	}
+	res := make([]S, len(names))
+	for i, name := range names {
+		s, ok := opt.styles[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown style %q", name)
+		}
+		res[i] = s
+	}
+	return res, nil
+}
+
+// applyStyles composes the styles named in the value of an apply
+// directive into dst.
+func (opt *importOptions) applyStyles(dst S, args string) (S, error) {
+	styles, err := opt.lookupStyles(args)
+	if err != nil {
+		return dst, err
+	}
+	for _, s := range styles {
+		dst = overlay(dst, s, nil, nil, nil)
+	}
+	return dst, nil
+}
@@ -0,0 +1,79 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestApply(t *testing.T) {
+	styles := map[string]S{
+		"base":       lipgloss.NewStyle().Foreground(lipgloss.Color("#fafafa")).Padding(0, 1),
+		"emphasized": lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#f00")),
+	}
+	td := []struct {
+		in  string
+		exp string
+		err string
+	}{
+		{`apply: base`, `foreground: #fafafa; padding-left: 1; padding-right: 1;`, ``},
+		{`apply: base emphasized`, `bold: true; foreground: #f00; padding-left: 1; padding-right: 1;`, ``},
+		// The applied styles override the previous directives, and are
+		// overridden by the following ones.
+		{`foreground: 12; italic: true; apply: base; padding-left: 3`, `foreground: #fafafa; italic: true; padding-left: 3; padding-right: 1;`, ``},
+		{`$e: emphasized; apply: $e`, `bold: true; foreground: #f00;`, ``},
+		{`bold: false !important; apply: emphasized`, `foreground: #f00;`, ``},
+		{`apply: missing`, ``, `in "apply: missing": unknown style "missing"`},
+		{`apply:`, ``, `in "apply:": missing style name`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			s, err := Import(lipgloss.NewStyle(), tc.in, WithStyles(styles))
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				if verr := Validate(tc.in, WithStyles(styles)); verr == nil || verr.Error() != tc.err {
+					t.Errorf("expected validation error %q, got %v", tc.err, verr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkOutput(t, tc.exp, Export(s))
+			if err := Validate(tc.in, WithStyles(styles)); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+
+	if _, err := Import(lipgloss.NewStyle(), `apply: base`); err == nil || err.Error() != `in "apply: base": unknown style "base"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := Import(lipgloss.NewStyle(), `apply: base`, WithStyles(styles), WithStrict()); err == nil ||
+		err.Error() != `in "apply: base": extension property "apply" not allowed in strict mode` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestApplySheet(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+base { foreground: #fafafa; padding: 0 1; }
+emphasized { bold: true; foreground: #f00; }
+title { foreground: 12; apply: base emphasized; padding-left: 2; }
+footer { apply: title; bold: false; }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	title, _ := ss.Get("title")
+	checkOutput(t, `bold: true; foreground: #f00; padding-left: 2; padding-right: 1;`, Export(title))
+	footer, _ := ss.Get("footer")
+	checkOutput(t, `foreground: #f00; padding-left: 2; padding-right: 1;`, Export(footer))
+
+	if _, err := ImportSheet(StyleSheet{}, "title { apply: base; }"); err == nil ||
+		err.Error() != `line 1: style "title": in "apply: base": unknown style "base"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
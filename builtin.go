@@ -0,0 +1,82 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// builtinThemes are the documents of the built-in themes, without
+// their styles; see builtinStyles.
+var builtinThemes = map[string]string{
+	"dracula": `@theme { name: "Dracula"; author: "Zeno Rocha"; mode: dark; }
+@palette {
+  fg: #f8f8f2; bg: #282a36; surface: #44475a; comment: #6272a4;
+  primary: #bd93f9; secondary: #ff79c6;
+  red: #ff5555; yellow: #f1fa8c; green: #50fa7b;
+}
+`,
+	"nord": `@theme { name: "Nord"; author: "Arctic Ice Studio"; mode: dark; }
+@palette {
+  fg: #d8dee9; bg: #2e3440; surface: #3b4252; comment: #4c566a;
+  primary: #88c0d0; secondary: #81a1c1;
+  red: #bf616a; yellow: #ebcb8b; green: #a3be8c;
+}
+`,
+	"solarized-dark": `@theme { name: "Solarized Dark"; author: "Ethan Schoonover"; mode: dark; }
+@palette {
+  fg: #839496; bg: #002b36; surface: #073642; comment: #586e75;
+  primary: #268bd2; secondary: #2aa198;
+  red: #dc322f; yellow: #b58900; green: #859900;
+}
+`,
+	"solarized-light": `@theme { name: "Solarized Light"; author: "Ethan Schoonover"; mode: light; }
+@palette {
+  fg: #657b83; bg: #fdf6e3; surface: #eee8d5; comment: #93a1a1;
+  primary: #268bd2; secondary: #2aa198;
+  red: #dc322f; yellow: #b58900; green: #859900;
+}
+`,
+}
+
+// builtinStyles are the styles shared by the built-in themes, in
+// terms of their palette.
+const builtinStyles = `
+title { bold: true; foreground: primary; }
+subtitle { foreground: secondary; }
+text { foreground: fg; }
+muted { foreground: comment; }
+selected { bold: true; foreground: fg; background: surface; }
+error { bold: true; foreground: red; }
+warning { foreground: yellow; }
+success { foreground: green; }
+panel { border: rounded; border-foreground: comment; padding: 0 1; }
+status-bar { foreground: fg; background: surface; padding: 0 1; }
+`
+
+// BuiltinThemes returns the names of the themes that ship with the
+// library, in sorted order, e.g. "dracula" or "solarized-light".
+func BuiltinThemes() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadBuiltinTheme loads the named built-in theme, as per LoadTheme
+// with the given options, so that applications can offer sensible
+// presets before users customize anything.
+//
+// All the built-in themes define the same styles: title, subtitle,
+// text, muted, selected, error, warning, success, panel and
+// status-bar. Their palettes also have the same entries: fg, bg,
+// surface, comment, primary, secondary, red, yellow and green.
+func LoadBuiltinTheme(name string, opts ...ImportOption) (Theme, error) {
+	doc, ok := builtinThemes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown built-in theme: %q (available: %s)", name, strings.Join(BuiltinThemes(), ", "))
+	}
+	return LoadTheme(strings.NewReader(doc+builtinStyles), opts...)
+}
@@ -0,0 +1,41 @@
+package lipglossc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBuiltinThemes(t *testing.T) {
+	names := BuiltinThemes()
+	if fmt.Sprint(names) != "[dracula nord solarized-dark solarized-light]" {
+		t.Errorf("unexpected themes: %v", names)
+	}
+	for _, name := range names {
+		th, err := LoadBuiltinTheme(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if th.Name == "" || th.Author == "" || th.Mode == ThemeAnyMode {
+			t.Errorf("%s: incomplete metadata: %+v", name, th)
+		}
+		if res := fmt.Sprint(th.Sheet.Names()); res != "[error muted panel selected status-bar subtitle success text title warning]" {
+			t.Errorf("%s: unexpected styles: %s", name, res)
+		}
+		if len(th.Palette()) != 9 {
+			t.Errorf("%s: unexpected palette: %v", name, th.Palette())
+		}
+	}
+
+	th, err := LoadBuiltinTheme("dracula")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "Dracula dark", th.Name+" "+th.Mode.String())
+	s, _ := th.Sheet.Get("title")
+	checkOutput(t, `bold: true; foreground: #bd93f9;`, Export(s))
+
+	if _, err := LoadBuiltinTheme("monokai"); err == nil ||
+		err.Error() != `unknown built-in theme: "monokai" (available: dracula, nord, solarized-dark, solarized-light)` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Command lipglossc-wasm exposes the style conversion functions to
+// JavaScript, for a browser-based playground. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o lipglossc.wasm ./cmd/lipglossc-wasm
+//
+// and load it with wasm_exec.js from the Go distribution. It defines
+// a global "lipglossc" object with the following methods:
+//
+//	lipglossc.validate(spec)          // null, or the error message
+//	lipglossc.convert(spec, options)  // {output, error}
+//	lipglossc.properties()            // array of property names
+//	lipglossc.keywords(prop)          // array of keywords, or null
+//
+// convert imports the spec and returns its canonical export. The
+// options object may contain "separator" (string), "defaults",
+// "namedColors" and "positionKeywords" (booleans), and "hexCase"
+// ("lower" or "upper").
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/charmbracelet/lipgloss"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func main() {
+	js.Global().Set("lipglossc", js.ValueOf(map[string]interface{}{
+		"validate":   js.FuncOf(validate),
+		"convert":    js.FuncOf(convert),
+		"properties": js.FuncOf(properties),
+		"keywords":   js.FuncOf(keywords),
+	}))
+	// Keep the functions available.
+	select {}
+}
+
+func validate(_ js.Value, args []js.Value) interface{} {
+	if err := lipglossc.Validate(stringArg(args, 0)); err != nil {
+		return err.Error()
+	}
+	return nil
+}
+
+func convert(_ js.Value, args []js.Value) interface{} {
+	s, err := lipglossc.Import(lipgloss.NewStyle(), stringArg(args, 0))
+	if err != nil {
+		return map[string]interface{}{"output": "", "error": err.Error()}
+	}
+	var opts []lipglossc.ExportOption
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		o := args[1]
+		if v := o.Get("separator"); v.Type() == js.TypeString {
+			opts = append(opts, lipglossc.WithSeparator(v.String()))
+		}
+		if o.Get("defaults").Truthy() {
+			opts = append(opts, lipglossc.WithExportDefaults())
+		}
+		if o.Get("namedColors").Truthy() {
+			opts = append(opts, lipglossc.WithNamedColors())
+		}
+		if o.Get("positionKeywords").Truthy() {
+			opts = append(opts, lipglossc.WithPositionKeywords())
+		}
+		switch o.Get("hexCase").String() {
+		case "lower":
+			opts = append(opts, lipglossc.WithHexCase(lipglossc.HexLower))
+		case "upper":
+			opts = append(opts, lipglossc.WithHexCase(lipglossc.HexUpper))
+		}
+	}
+	return map[string]interface{}{"output": lipglossc.Export(s, opts...), "error": nil}
+}
+
+func properties(_ js.Value, _ []js.Value) interface{} {
+	return stringArray(lipglossc.Properties())
+}
+
+func keywords(_ js.Value, args []js.Value) interface{} {
+	kw, err := lipglossc.Keywords(stringArg(args, 0))
+	if err != nil {
+		return nil
+	}
+	return stringArray(kw)
+}
+
+func stringArg(args []js.Value, i int) string {
+	if i >= len(args) || args[i].Type() != js.TypeString {
+		return ""
+	}
+	return args[i].String()
+}
+
+func stringArray(s []string) []interface{} {
+	res := make([]interface{}, len(s))
+	for i, v := range s {
+		res[i] = v
+	}
+	return res
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands,
+		command{
+			name:  "completion",
+			usage: "completion bash|zsh|fish",
+			help:  "print a shell completion script",
+			run:   runCompletion,
+		},
+		command{
+			// __complete is called by the completion scripts with the
+			// name of the shell and the command line up to the cursor.
+			name:   "__complete",
+			hidden: true,
+			run:    runComplete,
+		},
+	)
+}
+
+var completionScripts = map[string]string{
+	"bash": `_lipglossc() {
+    local IFS=$'\n'
+    COMPREPLY=($(lipglossc __complete bash "${COMP_LINE:0:COMP_POINT}" "$COMP_WORDBREAKS" 2>/dev/null))
+}
+complete -o nospace -o default -F _lipglossc lipglossc
+`,
+	"zsh": `#compdef lipglossc
+_lipglossc() {
+    local -a candidates
+    candidates=("${(@f)$(lipglossc __complete zsh "${BUFFER[1,CURSOR]}" 2>/dev/null)}")
+    compadd -Q -S '' -U -- "${candidates[@]}"
+}
+compdef _lipglossc lipglossc
+`,
+	"fish": `complete -c lipglossc -f -a '(lipglossc __complete fish (commandline -cp) 2>/dev/null)'
+`,
+}
+
+func runCompletion(args []string, _ io.Reader, out io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected one argument: bash, zsh or fish")
+	}
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return fmt.Errorf("unsupported shell: %q", args[0])
+	}
+	_, err := io.WriteString(out, script)
+	return err
+}
+
+func runComplete(args []string, _ io.Reader, out io.Writer) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+	shell, line := args[0], args[1]
+	words, cur, quoted := splitCommandLine(line)
+	candidates := complete(words, cur)
+	if shell == "bash" && len(args) > 2 && !quoted {
+		// Outside of quotes, bash splits words at some punctuation,
+		// e.g. ':'; the candidates must only include the part after
+		// the last one.
+		if i := strings.LastIndexAny(cur, args[2]); i >= 0 {
+			for j, c := range candidates {
+				candidates[j] = c[i+1:]
+			}
+		}
+	}
+	for _, c := range candidates {
+		fmt.Fprintln(out, c)
+	}
+	return nil
+}
+
+// complete returns the completion candidates for the word cur, given
+// the preceding words on the command line (including the program
+// name).
+func complete(words []string, cur string) []string {
+	if len(words) <= 1 {
+		var names []string
+		for _, c := range visibleCommands() {
+			names = append(names, c.name)
+		}
+		return filterPrefix(names, cur)
+	}
+	c, ok := findCommand(words[1])
+	if !ok {
+		return nil
+	}
+	prev := words[len(words)-1]
+	switch {
+	case prev == "--style" || prev == "-style":
+		return completeSpec(cur)
+	case strings.HasPrefix(cur, "--style="):
+		return addPrefix("--style=", completeSpec(strings.TrimPrefix(cur, "--style=")))
+	case strings.HasPrefix(cur, "-"):
+		return filterPrefix(c.flags, cur)
+	}
+	return nil
+}
+
+// completeSpec returns the completion candidates for a partial style
+// specification: property names at the start of a directive, and the
+// keywords of the property after the colon.
+func completeSpec(spec string) []string {
+	i := strings.LastIndexByte(spec, ';') + 1
+	done, cur := spec[:i], spec[i:]
+
+	j := strings.IndexByte(cur, ':')
+	if j < 0 {
+		// Completing a property name.
+		lead := cur[:len(cur)-len(strings.TrimLeft(cur, " "))]
+		var res []string
+		for _, p := range filterPrefix(lipglossc.Properties(), strings.TrimLeft(cur, " ")) {
+			res = append(res, done+lead+p+":")
+		}
+		return res
+	}
+
+	// Completing a value.
+	kw, err := lipglossc.Keywords(strings.TrimSpace(cur[:j]))
+	if err != nil {
+		return nil
+	}
+	k := strings.LastIndexByte(cur, ' ') + 1
+	if k <= j {
+		k = j + 1
+	}
+	return addPrefix(done+cur[:k], filterPrefix(kw, cur[k:]))
+}
+
+func filterPrefix(words []string, prefix string) []string {
+	var res []string
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+func addPrefix(prefix string, words []string) []string {
+	res := make([]string, len(words))
+	for i, w := range words {
+		res[i] = prefix + w
+	}
+	return res
+}
+
+// splitCommandLine splits a partial shell command line into the
+// complete words and the word being completed, handling single and
+// double quotes. The quotes are removed; quoted is true if the last
+// quote is not closed.
+func splitCommandLine(line string) (words []string, cur string, quoted bool) {
+	var buf strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			buf.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, buf.String())
+				buf.Reset()
+				inWord = false
+			}
+		default:
+			buf.WriteRune(r)
+			inWord = true
+		}
+	}
+	return words, buf.String(), quote != 0
+}
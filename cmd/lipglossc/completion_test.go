@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		exp  string
+	}{
+		{`lipglossc pre`, `preview`},
+		{`lipglossc preview --s`, `--style`},
+		{`lipglossc preview --style "border-t`, `border-top: border-top-background: border-top-foreground:`},
+		{`lipglossc preview --style "bold: true; ita`, `bold: true; italic:`},
+		{`lipglossc preview --style 'bold: t`, `bold: true`},
+		{`lipglossc preview --style 'align: center b`, `align: center bottom`},
+		{`lipglossc preview --style=align:r`, `--style=align:right`},
+		{`lipglossc preview --style 'foo: `, ``},
+		{`lipglossc unknown --`, ``},
+	} {
+		words, cur, _ := splitCommandLine(tc.line)
+		res := strings.Join(complete(words, cur), " ")
+		if res != tc.exp {
+			t.Errorf("%s:\nexpected: %s\n     got: %s", tc.line, tc.exp, res)
+		}
+	}
+}
+
+func TestCompleteBash(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"__complete", "bash", `lipglossc preview --style bold:t`, " \t\n\"'><=;|&(:"}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "true\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := run([]string{"__complete", "bash", `lipglossc preview --style "bold: t`, " \t\n\"'><=;|&(:"}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "bold: true\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := run([]string{"completion", "fish"}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "__complete fish") {
+		t.Errorf("unexpected script: %s", buf.String())
+	}
+}
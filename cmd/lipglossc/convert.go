@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "convert",
+		usage: "convert [--sheet] [--o DIR | --w] FILE|GLOB...",
+		help:  "normalize style files in batch",
+		flags: []string{"--sheet", "--o", "--w"},
+		run:   runConvert,
+	})
+}
+
+// runConvert rewrites the given files in the canonical format of
+// Export, or StyleSheet.Export with --sheet. The arguments can be glob
+// patterns, expanded even if the shell did not. The results are
+// printed, written to the output directory with --o, or written back
+// to the files with --w.
+//
+// The errors are reported for each file, and do not prevent the
+// conversion of the other files. The exit status is 1 if some files
+// failed.
+func runConvert(args []string, _ io.Reader, out io.Writer) error {
+	fs := newFlagSet("convert")
+	sheet := fs.Bool("sheet", false, "read the files as stylesheets")
+	outDir := fs.String("o", "", "write the results to this directory")
+	inPlace := fs.Bool("w", false, "write the results to the files instead of the output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 || (*inPlace && *outDir != "") {
+		fs.Usage()
+		return errUsage
+	}
+	if *outDir != "" {
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	var files []string
+	failed, badPatterns := 0, 0
+	fail := func(file string, err error) {
+		fmt.Fprintf(stderr, "%s: %v\n", file, err)
+		failed++
+	}
+	for _, pattern := range fs.Args() {
+		matches, err := filepath.Glob(pattern)
+		switch {
+		case err != nil:
+			fail(pattern, err)
+			badPatterns++
+		case len(matches) == 0:
+			// Not a pattern, or a pattern matching nothing: let the
+			// conversion report the missing file.
+			files = append(files, pattern)
+		default:
+			files = append(files, matches...)
+		}
+	}
+
+	written := map[string]string{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fail(file, err)
+			continue
+		}
+		res, err := convertFile(string(data), *sheet, *outDir != "" || *inPlace)
+		if err != nil {
+			fail(file, err)
+			continue
+		}
+		switch {
+		case *outDir != "":
+			target := filepath.Join(*outDir, filepath.Base(file))
+			if other, ok := written[target]; ok {
+				fail(file, fmt.Errorf("same output file as %s", other))
+				continue
+			}
+			written[target] = file
+			if err := ioutil.WriteFile(target, []byte(res), 0644); err != nil {
+				fail(file, err)
+			}
+		case *inPlace:
+			if res != string(data) {
+				if err := ioutil.WriteFile(file, []byte(res), 0644); err != nil {
+					fail(file, err)
+				}
+			}
+		default:
+			if _, err := io.WriteString(out, res); err != nil {
+				return err
+			}
+		}
+	}
+	if failed > 0 {
+		return &exitError{code: 1, err: fmt.Errorf("%d of %d files failed", failed, len(files)+badPatterns)}
+	}
+	return nil
+}
+
+// convertFile returns the canonical form of a style specification, or
+// of a stylesheet if sheet is set. If the result is to be written, the
+// inputs that contain constructs that the canonical form would lose,
+// e.g. comments, variables or mixins, are refused.
+func convertFile(input string, sheet, write bool) (string, error) {
+	if sheet {
+		if p := lipglossc.CheckSheetLossless(input); write && len(p) > 0 {
+			return "", fmt.Errorf("not rewriting the stylesheet, which would lose its %s", strings.Join(p, ", "))
+		}
+		ss, err := lipglossc.ImportSheet(lipglossc.StyleSheet{}, input)
+		if err != nil {
+			return "", err
+		}
+		return ss.Export(lipglossc.WithSeparator("\n")), nil
+	}
+	if p := lipglossc.CheckSpecLossless(input); write && len(p) > 0 {
+		return "", fmt.Errorf("not rewriting the specification, which would lose its %s", strings.Join(p, ", "))
+	}
+	s, err := lipglossc.Import(lipgloss.NewStyle(), input)
+	if err != nil {
+		return "", err
+	}
+	res := lipglossc.Export(s, lipglossc.WithSeparator("\n"))
+	if res != "" && !strings.HasSuffix(res, "\n") {
+		res += "\n"
+	}
+	return res, nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "convert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	title := write("theme/title.gloss", "foreground: #f00; bold: on\n")
+	help := write("theme/help.gloss", "faint: true")
+	bad := write("theme/bad.gloss", "bold: maybe\n")
+	sheet := write("app.glossy", "$c: #fff;\nfooter { faint: true } title { bold: true; foreground: $c }\n")
+
+	var buf bytes.Buffer
+	if err := run([]string{"convert", title}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "bold: true;\nforeground: #f00;\n"; buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	buf.Reset()
+	if err := run([]string{"convert", "--sheet", sheet}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "$c: #fff;\n\nfooter {\n  faint: true;\n}\n\ntitle {\n  bold: true;\n  foreground: $c;\n}\n"; buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	// A whole directory, with per-file errors.
+	var errBuf bytes.Buffer
+	stderr = &errBuf
+	defer func() { stderr = os.Stderr }()
+	outDir := filepath.Join(dir, "out")
+	missing := filepath.Join(dir, "missing.gloss")
+	buf.Reset()
+	err = run([]string{"convert", "--o", outDir, filepath.Join(dir, "theme", "*.gloss"), missing}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 1 || e.err.Error() != "2 of 4 files failed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	lines := strings.Split(strings.TrimSpace(errBuf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], bad+": ") || !strings.HasPrefix(lines[1], missing+": ") {
+		t.Errorf("unexpected errors:\n%s", errBuf.String())
+	}
+	for name, exp := range map[string]string{"title.gloss": "bold: true;\nforeground: #f00;\n", "help.gloss": "faint: true;\n"} {
+		data, err := ioutil.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != exp {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", name, exp, data)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "bad.gloss")); !os.IsNotExist(err) {
+		t.Errorf("unexpected output for bad file: %v", err)
+	}
+
+	// In place.
+	if err := run([]string{"convert", "--w", help}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := ioutil.ReadFile(help); string(data) != "faint: true;\n" {
+		t.Errorf("unexpected result: %q", data)
+	}
+
+	if err := run([]string{"convert", "--w", "--o", outDir, help}, nil, &buf); err != errUsage {
+		t.Errorf("expected usage error, got %v", err)
+	}
+
+	// The stylesheets whose structure would be lost are not rewritten,
+	// but can be printed.
+	const theme = "// Base theme.\n@mixin loud { bold: true; }\ntitle { @include loud; }\n"
+	themeFile := write("theme.glossy", theme)
+	errBuf.Reset()
+	err = run([]string{"convert", "--sheet", "--w", themeFile}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 1 {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exp := themeFile + ": not rewriting the stylesheet, which would lose its comments, mixins\n"; errBuf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, errBuf.String())
+	}
+	if data, _ := ioutil.ReadFile(themeFile); string(data) != theme {
+		t.Errorf("unexpected rewrite: %q", data)
+	}
+	buf.Reset()
+	if err := run([]string{"convert", "--sheet", themeFile}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "title {\n  bold: true;\n}\n"; buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	// Likewise for the specifications, with --w or --o.
+	const spec = "// brand colors\n$accent: #7D56F4;\nforeground: $accent;\n@dark { bold: true; }\n@light { italic: true; }\n"
+	specFile := write("brand.gloss", spec)
+	for _, args := range [][]string{{"--w"}, {"--o", outDir}} {
+		errBuf.Reset()
+		err = run(append(append([]string{"convert"}, args...), specFile), nil, &buf)
+		if e, ok := err.(*exitError); !ok || e.code != 1 {
+			t.Errorf("%v: unexpected error: %v", args, err)
+		}
+		if exp := specFile + ": not rewriting the specification, which would lose its comments, variables, @dark, @light and @profile sections\n"; errBuf.String() != exp {
+			t.Errorf("%v: expected:\n%s\ngot:\n%s", args, exp, errBuf.String())
+		}
+	}
+	if data, _ := ioutil.ReadFile(specFile); string(data) != spec {
+		t.Errorf("unexpected rewrite: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "brand.gloss")); !os.IsNotExist(err) {
+		t.Errorf("unexpected output: %v", err)
+	}
+}
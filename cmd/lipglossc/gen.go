@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "gen",
+		usage: "gen [--pkg NAME] [--o FILE] [--sheet] FILE...",
+		help:  "generate a Go package from style files",
+		flags: []string{"--pkg", "--o", "--sheet"},
+		run:   runGen,
+	})
+}
+
+// runGen generates Go code for the styles in the given files. Each
+// file defines one style, named after the file without its extension,
+// or with --sheet, is a stylesheet defining multiple named styles.
+func runGen(args []string, _ io.Reader, out io.Writer) error {
+	fs := newFlagSet("gen")
+	pkg := fs.String("pkg", "theme", "name of the generated package")
+	output := fs.String("o", "", "write the code to this file instead of the standard output")
+	sheet := fs.Bool("sheet", false, "read the files as stylesheets")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	var ss lipglossc.StyleSheet
+	for _, file := range fs.Args() {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if *sheet {
+			ss, err = lipglossc.ImportSheet(ss, string(data))
+			if err != nil {
+				return fmt.Errorf("%s: %v", file, err)
+			}
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		if _, ok := ss.Get(name); ok {
+			return fmt.Errorf("%s: duplicate style %q", file, name)
+		}
+		s, err := lipglossc.Import(lipgloss.NewStyle(), string(data))
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		ss.Set(name, s)
+	}
+
+	src, err := ss.GenerateGo(*pkg)
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		return ioutil.WriteFile(*output, src, 0644)
+	}
+	_, err = out.Write(src)
+	return err
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	title := write("title.gloss", "bold: true; foreground: #f00\n")
+	help := write("help-text.gloss", "faint: true\n")
+	bad := write("bad.gloss", "bold: maybe\n")
+
+	var buf bytes.Buffer
+	if err := run([]string{"gen", "--pkg", "styles", title, help}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	exp := `// Code generated by lipglossc; DO NOT EDIT.
+
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+// HelpText returns the "help-text" style.
+func HelpText() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Faint(true)
+}
+
+// Title returns the "title" style.
+func Title() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#f00"))
+}
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	outFile := filepath.Join(dir, "theme.go")
+	buf.Reset()
+	if err := run([]string{"gen", "--o", outFile, help}, nil, &buf); err != nil || buf.Len() != 0 {
+		t.Fatalf("unexpected result: %v, %s", err, buf.String())
+	}
+	data, err := ioutil.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "package theme\n") {
+		t.Errorf("unexpected output:\n%s", data)
+	}
+
+	sheet := write("theme.gloss", "title { bold: true; }\nhelp-text { faint: true; }\n")
+	buf.Reset()
+	if err := run([]string{"gen", "--pkg", "styles", "--sheet", sheet}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	exp = strings.Replace(exp, "Bold(true).\n\t\tForeground(lipgloss.Color(\"#f00\"))", "Bold(true)", 1)
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+	if err := run([]string{"gen", "--sheet", bad}, nil, &buf); err == nil || !strings.HasPrefix(err.Error(), bad+": line 1: ") {
+		t.Errorf("expected error for %s, got %v", bad, err)
+	}
+
+	if err := run([]string{"gen", title, bad}, nil, &buf); err == nil || !strings.HasPrefix(err.Error(), bad+": ") {
+		t.Errorf("expected error for %s, got %v", bad, err)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "lint",
+		usage: "lint [--sheet] [--json] FILE...",
+		help:  "check style files for errors and poor contrast",
+		flags: []string{"--sheet", "--json"},
+		run:   runLint,
+	})
+}
+
+// diagnostic is a problem reported by lint.
+type diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (d diagnostic) String() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", d.File, d.Line, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", d.File, d.Severity, d.Message)
+}
+
+// runLint checks the given files. The exit status is 0 if no problem
+// was found, 1 if some problems were found and 2 if the files could
+// not be read.
+func runLint(args []string, _ io.Reader, out io.Writer) error {
+	fs := newFlagSet("lint")
+	sheet := fs.Bool("sheet", false, "check the files as stylesheets")
+	jsonOutput := fs.Bool("json", false, "report the problems as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	diags := []diagnostic{}
+	for _, file := range fs.Args() {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return &exitError{code: 2, err: err}
+		}
+		if *sheet {
+			diags = append(diags, lintSheet(file, string(data))...)
+		} else {
+			diags = append(diags, lintSpec(file, string(data))...)
+		}
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diags); err != nil {
+			return err
+		}
+	} else {
+		for _, d := range diags {
+			fmt.Fprintln(out, d)
+		}
+	}
+	if len(diags) > 0 {
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+// lintSpec checks the style specification in the given file.
+func lintSpec(file, spec string) []diagnostic {
+	var diags []diagnostic
+	s, _, err := lipglossc.ImportWithSourceMap(lipgloss.NewStyle(), spec, file,
+		lipglossc.WithWarnings(func(w lipglossc.Warning) {
+			d := diagnostic{File: file, Line: w.Pos.Line, Severity: "warning"}
+			// The diagnostic reports the position already.
+			w.Pos = lipglossc.SourcePos{}
+			d.Message = w.String()
+			diags = append(diags, d)
+		}))
+	if err != nil {
+		d := diagnostic{File: file, Severity: "error", Message: err.Error()}
+		var se *lipglossc.SourceError
+		if errors.As(err, &se) {
+			d.Line, d.Message = se.Pos.Line, se.Err.Error()
+		}
+		return append(diags, d)
+	}
+	for _, p := range lipglossc.CheckContrast(s) {
+		diags = append(diags, diagnostic{File: file, Severity: "warning", Message: p})
+	}
+	return diags
+}
+
+// lintSheet checks the stylesheet in the given file. Besides the
+// errors, the definitions that are never used are reported, and the
+// contrast of the styles is checked if the sheet is valid.
+func lintSheet(file, input string) []diagnostic {
+	var diags []diagnostic
+	errs := lipglossc.ValidateSheet(input)
+	for _, e := range errs {
+		d := diagnostic{File: file, Line: e.Pos.Line, Severity: "error", Message: e.Err.Error()}
+		if e.Style != "" {
+			d.Message = fmt.Sprintf("style %q: %v", e.Style, e.Err)
+		}
+		diags = append(diags, d)
+	}
+	for _, w := range lipglossc.UnusedDefinitions(input) {
+		line := w.Pos.Line
+		w.Pos = lipglossc.SourcePos{}
+		diags = append(diags, diagnostic{File: file, Line: line, Severity: "warning", Message: w.String()})
+	}
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Line < diags[j].Line })
+	if len(errs) > 0 {
+		return diags
+	}
+	ss, err := lipglossc.ImportSheet(lipglossc.StyleSheet{}, input)
+	if err != nil {
+		return append(diags, diagnostic{File: file, Severity: "error", Message: err.Error()})
+	}
+	for _, name := range ss.Names() {
+		s, _ := ss.Get(name)
+		for _, p := range lipglossc.CheckContrast(s) {
+			msg := fmt.Sprintf("style %q: %s", name, p)
+			diags = append(diags, diagnostic{File: file, Severity: "warning", Message: msg})
+		}
+	}
+	return diags
+}
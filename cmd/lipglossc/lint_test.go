@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	good := write("good.gloss", "bold: true;\nforeground: #fff; background: #000;\n")
+	bad := write("bad.gloss", "bold: true;\nbold: maybe;\n")
+	dim := write("dim.gloss", "foreground: #777; background: #888;\n")
+
+	var buf bytes.Buffer
+	if err := run([]string{"lint", good}, nil, &buf); err != nil || buf.Len() != 0 {
+		t.Errorf("unexpected result: %v, %s", err, buf.String())
+	}
+
+	buf.Reset()
+	err = run([]string{"lint", good, bad, dim}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 1 {
+		t.Errorf("expected exit status 1, got %v", err)
+	}
+	exp := bad + `:2: error: in "bold: maybe": no value found; bold expects true or false
+` + dim + `: warning: foreground #777 on background #888: contrast ratio 1.26:1 is below 4.5:1
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	buf.Reset()
+	_ = run([]string{"lint", "--json", bad}, nil, &buf)
+	exp = `[
+  {
+    "file": "` + bad + `",
+    "line": 2,
+    "severity": "error",
+    "message": "in \"bold: maybe\": no value found; bold expects true or false"
+  }
+]
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	buf.Reset()
+	old := write("old.gloss", "bold: true;\ncolor-whitespace: false;\n")
+	err = run([]string{"lint", old}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 1 {
+		t.Errorf("expected exit status 1, got %v", err)
+	}
+	exp = old + `:2: warning: color-whitespace: deprecated property (use margins and padding instead)
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	buf.Reset()
+	sheet := write("theme.gloss", `$accent: #7D56F4;
+$spare: 2;
+@palette { danger: #ff5555; muted: #777; }
+@mixin loud { bold: true; }
+title { foreground: $accent; bold: maybe; }
+footer { foreground: danger; }
+`)
+	err = run([]string{"lint", "--sheet", sheet}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 1 {
+		t.Errorf("expected exit status 1, got %v", err)
+	}
+	exp = sheet + `:2: warning: $spare: unused variable
+` + sheet + `:3: warning: muted: unused palette entry
+` + sheet + `:4: warning: loud: unused mixin
+` + sheet + `:5: error: style "title": in "bold: maybe": no value found; bold expects true or false
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	buf.Reset()
+	dimSheet := write("dim-theme.gloss", "title { foreground: #777; background: #888; }\n")
+	_ = run([]string{"lint", "--sheet", dimSheet}, nil, &buf)
+	exp = dimSheet + `: warning: style "title": foreground #777 on background #888: contrast ratio 1.26:1 is below 4.5:1
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	err = run([]string{"lint", filepath.Join(dir, "missing")}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 2 {
+		t.Errorf("expected exit status 2, got %v", err)
+	}
+}
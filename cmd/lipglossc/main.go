@@ -0,0 +1,138 @@
+// Command lipglossc manipulates lipgloss style specifications from
+// the command line.
+//
+// Usage:
+//
+//	lipglossc <command> [arguments]
+//
+// Run "lipglossc help" for the list of commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// command is a sub-command of the CLI.
+type command struct {
+	// name is the name of the command on the command line.
+	name string
+	// usage is a one-line synopsis, e.g. "preview --style SPEC [TEXT]".
+	usage string
+	// help is a one-line description.
+	help string
+	// flags lists the names of the flags, for shell completion.
+	flags []string
+	// hidden excludes the command from help and completion.
+	hidden bool
+	// run executes the command.
+	run func(args []string, in io.Reader, out io.Writer) error
+}
+
+// commands is populated by the init functions of the files
+// implementing each command.
+var commands []command
+
+// stderr receives the error messages and the usage of the commands.
+// It is replaced in tests.
+var stderr io.Writer = os.Stderr
+
+// errUsage is returned when the command line is invalid; the usage
+// has been printed already.
+var errUsage = errors.New("invalid usage")
+
+// exitError is returned by commands that need a specific exit status.
+// If err is nil, no message is printed.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	switch e := err.(type) {
+	case nil:
+	case *exitError:
+		if e.err != nil {
+			fmt.Fprintln(stderr, "lipglossc:", e.err)
+		}
+		os.Exit(e.code)
+	default:
+		if err == errUsage {
+			os.Exit(2)
+		}
+		fmt.Fprintln(stderr, "lipglossc:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, in io.Reader, out io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(out)
+		return nil
+	}
+	c, ok := findCommand(args[0])
+	if !ok {
+		usage(stderr)
+		return fmt.Errorf("unknown command: %q", args[0])
+	}
+	return c.run(args[1:], in, out)
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// visibleCommands returns the non-hidden commands, in sorted order.
+func visibleCommands() []command {
+	var res []command
+	for _, c := range commands {
+		if !c.hidden {
+			res = append(res, c)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+	return res
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: lipglossc <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range visibleCommands() {
+		fmt.Fprintf(w, "  %-40s %s\n", c.usage, c.help)
+	}
+}
+
+// newFlagSet creates a flag set for the given command, reporting
+// errors on stderr.
+func newFlagSet(c string) *flag.FlagSet {
+	fs := flag.NewFlagSet(c, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseFlags parses the flags of a command, translating the help and
+// flag errors to errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "migrate",
+		usage: "migrate [--style OLD=NEW] [--prop OLD=NEW] [--w] FILE...",
+		help:  "rename styles and properties in stylesheets",
+		flags: []string{"--style", "--prop", "--w"},
+		run:   runMigrate,
+	})
+}
+
+// renames is a repeatable OLD=NEW flag.
+type renames map[string]string
+
+func (r renames) String() string { return "" }
+
+func (r renames) Set(v string) error {
+	i := strings.IndexByte(v, '=')
+	if i <= 0 || i == len(v)-1 {
+		return fmt.Errorf("expected OLD=NEW, got %q", v)
+	}
+	r[v[:i]] = v[i+1:]
+	return nil
+}
+
+// runMigrate applies the renamings to the given stylesheets. The
+// result is printed, or written back to the files with --w.
+func runMigrate(args []string, _ io.Reader, out io.Writer) error {
+	fs := newFlagSet("migrate")
+	m := lipglossc.Migration{Styles: renames{}, Properties: renames{}}
+	fs.Var(renames(m.Styles), "style", "rename the style `OLD=NEW` (can be repeated)")
+	fs.Var(renames(m.Properties), "prop", "rename the property `OLD=NEW` (can be repeated)")
+	inPlace := fs.Bool("w", false, "write the result to the files instead of the output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	for _, file := range fs.Args() {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		res, err := lipglossc.MigrateSheet(string(data), m)
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		if *inPlace {
+			if res != string(data) {
+				if err := ioutil.WriteFile(file, []byte(res), 0644); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if _, err := io.WriteString(out, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	const sheet = "title {\n  bold: true;\n  colour-fg: red;\n}\nhelp { extends: title }\n"
+	file := write("app.gloss", sheet)
+
+	var buf bytes.Buffer
+	args := []string{"migrate", "--style", "title=heading", "--prop", "colour-fg=foreground", file}
+	if err := run(args, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	const exp = "heading {\n  bold: true;\n  foreground: red;\n}\nhelp { extends: heading }\n"
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	buf.Reset()
+	args = append(args[:len(args)-1:len(args)-1], "--w", file)
+	if err := run(args, nil, &buf); err != nil || buf.Len() != 0 {
+		t.Fatalf("unexpected result: %v, %s", err, buf.String())
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, data)
+	}
+
+	if err := run([]string{"migrate", "--style", "title", file}, nil, &buf); err == nil {
+		t.Error("expected error for invalid renaming")
+	}
+}
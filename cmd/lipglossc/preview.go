@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "preview",
+		usage: "preview --style SPEC [--png FILE] [TEXT...]",
+		help:  "display sample text with a style",
+		flags: []string{"--png", "--style"},
+		run:   runPreview,
+	})
+}
+
+const sampleText = "The quick brown fox jumps over the lazy dog."
+
+// renderPNG renders styled text to a PNG image for preview --png. It
+// is only available when the CLI is built with the "png" tag (see
+// preview_png.go), so that the default build does not embed the
+// bitmap font and image encoder of the pngpreview package.
+var renderPNG func(w io.Writer, style lipgloss.Style, text string) error
+
+func runPreview(args []string, _ io.Reader, out io.Writer) error {
+	fs := newFlagSet("preview")
+	spec := fs.String("style", "", "style specification")
+	pngFile := fs.String("png", "", "write the preview to a PNG image")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	style, err := lipglossc.Import(lipgloss.NewStyle(), *spec)
+	if err != nil {
+		return err
+	}
+	text := sampleText
+	if fs.NArg() > 0 {
+		text = strings.Join(fs.Args(), " ")
+	}
+	if *pngFile != "" {
+		if renderPNG == nil {
+			return fmt.Errorf("PNG previews are not supported by this build of lipglossc; rebuild it with -tags png")
+		}
+		f, err := os.Create(*pngFile)
+		if err != nil {
+			return err
+		}
+		if err := renderPNG(f, style, text); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	_, err = fmt.Fprintln(out, style.Render(text))
+	return err
+}
@@ -0,0 +1,17 @@
+//go:build png
+// +build png
+
+package main
+
+import (
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/knz/lipgloss-convert/pngpreview"
+)
+
+func init() {
+	renderPNG = func(w io.Writer, style lipgloss.Style, text string) error {
+		return pngpreview.Render(w, style, text)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build png
+// +build png
+
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreviewPNG(t *testing.T) {
+	var buf bytes.Buffer
+	dir, err := ioutil.TempDir("", "preview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "preview.png")
+	if err := run([]string{"preview", "--style", "bold: true", "--png", file}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := png.Decode(f); err != nil {
+		t.Error(err)
+	}
+}
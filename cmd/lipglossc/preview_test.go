@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"preview", "--style", "padding-left: 2", "hello"}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "  hello\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	if renderPNG == nil {
+		// The default build does not support PNG previews.
+		err := run([]string{"preview", "--style", "bold: true", "--png", "preview.png"}, nil, &buf)
+		if err == nil || !strings.Contains(err.Error(), "-tags png") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	err := run([]string{"preview", "--style", "bold: maybe"}, nil, &buf)
+	if err == nil || err.Error() != `in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := run([]string{"unknown"}, nil, &buf); err == nil || err.Error() != `unknown command: "unknown"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "render",
+		usage: "render --style SPEC < INPUT",
+		help:  "apply a style to the standard input",
+		flags: []string{"--style"},
+		run:   runRender,
+	})
+}
+
+func runRender(args []string, in io.Reader, out io.Writer) error {
+	fs := newFlagSet("render")
+	spec := fs.String("style", "", "style specification")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+	style, err := lipglossc.Import(lipgloss.NewStyle(), *spec)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	// The final newline is not part of the text to style.
+	text := strings.TrimSuffix(string(data), "\n")
+	_, err = fmt.Fprintln(out, style.Render(text))
+	return err
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	in := strings.NewReader("hello\nworld\n")
+	if err := run([]string{"render", "--style", "border: rounded; padding: 0 1"}, in, &buf); err != nil {
+		t.Fatal(err)
+	}
+	exp := `╭───────╮
+│ hello │
+│ world │
+╰───────╯
+`
+	if buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+
+	if err := run([]string{"render", "--style", "bold: maybe"}, strings.NewReader(""), &buf); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func init() {
+	commands = append(commands, command{
+		name:  "surface",
+		usage: "surface [--diff FILE]",
+		help:  "print or compare the properties supported with this lipgloss",
+		flags: []string{"--diff"},
+		run:   runSurface,
+	})
+}
+
+// runSurface prints the property surface of the version of lipgloss
+// the tool is built with, or with --diff, compares it with a surface
+// saved earlier, e.g. before upgrading lipgloss. The exit status is 1
+// if there are differences.
+func runSurface(args []string, _ io.Reader, out io.Writer) error {
+	fs := newFlagSet("surface")
+	diff := fs.String("diff", "", "compare with the surface saved in this file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cur := lipglossc.CurrentSurface()
+	if *diff == "" {
+		_, err := io.WriteString(out, cur.String())
+		return err
+	}
+	data, err := ioutil.ReadFile(*diff)
+	if err != nil {
+		return err
+	}
+	saved, err := lipglossc.ParseSurface(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %v", *diff, err)
+	}
+	d := lipglossc.DiffSurfaces(saved, cur)
+	if d.Empty() {
+		return nil
+	}
+	if _, err := io.WriteString(out, d.String()); err != nil {
+		return err
+	}
+	return &exitError{code: 1}
+}
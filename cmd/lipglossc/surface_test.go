@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSurface(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"surface"}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	surface := buf.String()
+	if !strings.Contains(surface, "\nbold: false\n") {
+		t.Errorf("unexpected surface:\n%s", surface)
+	}
+
+	dir, err := ioutil.TempDir("", "surface")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	saved := filepath.Join(dir, "surface.txt")
+	if err := ioutil.WriteFile(saved, []byte(surface), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := run([]string{"surface", "--diff", saved}, nil, &buf); err != nil || buf.Len() != 0 {
+		t.Errorf("unexpected result: %v, %q", err, buf.String())
+	}
+
+	old := strings.Replace(surface, "\nbold: false\n", "\nbold-text: false\n", 1) + "blinking: false\n"
+	if err := ioutil.WriteFile(saved, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	err = run([]string{"surface", "--diff", saved}, nil, &buf)
+	if e, ok := err.(*exitError); !ok || e.code != 1 {
+		t.Errorf("expected exit status 1, got %v", err)
+	}
+	if exp := "+ bold\n- blinking\n- bold-text\n"; buf.String() != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, buf.String())
+	}
+}
@@ -0,0 +1,146 @@
+package lipglossc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColorBlindness identifies a form of color vision deficiency, for
+// SimulateColorBlindness.
+type ColorBlindness int
+
+const (
+	// Protanopia is the absence of red cones.
+	Protanopia ColorBlindness = iota
+	// Deuteranopia is the absence of green cones.
+	Deuteranopia
+	// Tritanopia is the absence of blue cones.
+	Tritanopia
+)
+
+// String implements fmt.Stringer.
+func (c ColorBlindness) String() string {
+	switch c {
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	}
+	return fmt.Sprintf("ColorBlindness(%d)", int(c))
+}
+
+// colorBlindnessMatrices are the simulation matrices of Machado et
+// al. (2009) at full severity, applied to linear RGB components.
+var colorBlindnessMatrices = map[ColorBlindness][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// SimulateColorBlindness returns a copy of the style where every
+// color is replaced by its appearance for a viewer with the given
+// color vision deficiency. This helps theme authors check that the
+// colors of a theme remain distinguishable.
+//
+// The simulated colors are expressed as RGB hex values. All the
+// variants of adaptive and complete colors are transformed. Colors
+// that cannot be decoded, e.g. NoColor, are left unchanged.
+func SimulateColorBlindness(s S, kind ColorBlindness) S {
+	m, ok := colorBlindnessMatrices[kind]
+	if !ok {
+		return s.Copy()
+	}
+	result := s.Copy()
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		if val.Type().Name() != "TerminalColor" || isDefault(val) {
+			continue
+		}
+		tc := simulateColor(val.Interface().(lipgloss.TerminalColor), &m)
+		result = g.setFn.Call([]reflect.Value{reflect.ValueOf(result), reflect.ValueOf(tc)})[0].Interface().(S)
+	}
+	return result
+}
+
+// SimulateColorBlindness returns a copy of the sheet where
+// SimulateColorBlindness has been applied to every style.
+func (ss StyleSheet) SimulateColorBlindness(kind ColorBlindness) StyleSheet {
+	res := ss.Copy()
+	for name, s := range ss.styles {
+		res.styles[name] = SimulateColorBlindness(s, kind)
+	}
+	return res
+}
+
+func simulateColor(tc lipgloss.TerminalColor, m *[3][3]float64) lipgloss.TerminalColor {
+	switch tc := tc.(type) {
+	case lipgloss.Color:
+		return lipgloss.Color(simulateHex(string(tc), m))
+	case lipgloss.AdaptiveColor:
+		return lipgloss.AdaptiveColor{Light: simulateHex(tc.Light, m), Dark: simulateHex(tc.Dark, m)}
+	case lipgloss.CompleteColor:
+		return simulateComplete(tc, m)
+	case lipgloss.CompleteAdaptiveColor:
+		return lipgloss.CompleteAdaptiveColor{Light: simulateComplete(tc.Light, m), Dark: simulateComplete(tc.Dark, m)}
+	}
+	return tc
+}
+
+func simulateComplete(cc lipgloss.CompleteColor, m *[3][3]float64) lipgloss.CompleteColor {
+	return lipgloss.CompleteColor{
+		TrueColor: simulateHex(cc.TrueColor, m),
+		ANSI256:   simulateHex(cc.ANSI256, m),
+		ANSI:      simulateHex(cc.ANSI, m),
+	}
+}
+
+// simulateHex applies the simulation matrix to a hex or ANSI color.
+// Colors that cannot be decoded are returned unchanged.
+func simulateHex(c string, m *[3][3]float64) string {
+	r, g, b, ok := colorRGB(c)
+	if !ok {
+		return c
+	}
+	in := [3]float64{srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)}
+	var out [3]int
+	for i, row := range m {
+		out[i] = linearToSRGB(row[0]*in[0] + row[1]*in[1] + row[2]*in[2])
+	}
+	return fmt.Sprintf("#%02x%02x%02x", out[0], out[1], out[2])
+}
+
+func srgbToLinear(v int) float64 {
+	x := float64(v) / 255
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(x float64) int {
+	x = math.Max(0, math.Min(1, x))
+	if x <= 0.0031308 {
+		x *= 12.92
+	} else {
+		x = 1.055*math.Pow(x, 1/2.4) - 0.055
+	}
+	return int(math.Round(x * 255))
+}
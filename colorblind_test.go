@@ -0,0 +1,44 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSimulateColorBlindness(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `bold: true; foreground: #ff0000; background: adaptive(#00ff00, 15); border-top-foreground: #ffffff`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	td := []struct {
+		kind ColorBlindness
+		exp  string
+	}{
+		{Protanopia, `background: adaptive(#ffe500,#ffffff); bold: true; border-top-foreground: #ffffff; foreground: #6d5f00;`},
+		{Deuteranopia, `background: adaptive(#efd63a,#ffffff); bold: true; border-top-foreground: #ffffff; foreground: #a39000;`},
+		{Tritanopia, `background: adaptive(#00f7d9,#ffffff); bold: true; border-top-foreground: #ffffff; foreground: #ff000f;`},
+	}
+	for _, tc := range td {
+		t.Run(tc.kind.String(), func(t *testing.T) {
+			res := SimulateColorBlindness(s, tc.kind)
+			checkOutput(t, tc.exp, Export(res))
+		})
+	}
+	// The original style is unchanged.
+	checkOutput(t, `background: adaptive(#00ff00,15); bold: true; border-top-foreground: #ffffff; foreground: #ff0000;`, Export(s))
+}
+
+func TestSheetSimulateColorBlindness(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `title { foreground: #ff0000 } footer { faint: true }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := ss.SimulateColorBlindness(Deuteranopia)
+	s, _ := res.Get("title")
+	checkOutput(t, `foreground: #a39000;`, Export(s))
+	s, _ = res.Get("footer")
+	checkOutput(t, `faint: true;`, Export(s))
+	s, _ = ss.Get("title")
+	checkOutput(t, `foreground: #ff0000;`, Export(s))
+}
@@ -0,0 +1,27 @@
+package lipglossc
+
+import "testing"
+
+func TestNearestNamedColor(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+		ok  bool
+	}{
+		{"#ff0000", "red", true},
+		{"#FE0102", "red", true},
+		{"#0ff", "aqua", true},
+		{"#808080", "gray", true},
+		{"#123456", "", false},
+		{"12", "", false},
+		{"#12", "", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			res, ok := nearestNamedColor(tc.in)
+			if res != tc.out || ok != tc.ok {
+				t.Errorf("expected %q/%v, got %q/%v", tc.out, tc.ok, res, ok)
+			}
+		})
+	}
+}
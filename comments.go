@@ -0,0 +1,63 @@
+package lipglossc
+
+import (
+	"errors"
+	"strings"
+)
+
+var errUnterminatedComment = errors.New("invalid syntax: unterminated comment")
+
+// blankComments replaces the "// ..." line comments and "/* ... */"
+// block comments in the input by spaces, keeping the newlines so that
+// the positions and line numbers of the rest of the input do not
+// change. Comment markers within double-quoted strings do not count.
+//
+// If a block comment is not terminated, the offset of its start is
+// returned as unterminated; otherwise unterminated is -1.
+func blankComments(input string) (res string, unterminated int) {
+	if !strings.Contains(input, "/") {
+		return input, -1
+	}
+	buf := []byte(input)
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if buf[i] != '\n' {
+				buf[i] = ' '
+			}
+		}
+	}
+	inString := false
+	for i := 0; i < len(buf); i++ {
+		switch {
+		case inString && buf[i] == '\\':
+			i++
+		case buf[i] == '"':
+			inString = !inString
+		case inString:
+		case strings.HasPrefix(input[i:], "//"):
+			end := strings.IndexByte(input[i:], '\n')
+			if end < 0 {
+				end = len(input) - i
+			}
+			blank(i, i+end)
+			i += end
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i+2:], "*/")
+			if end < 0 {
+				return input, i
+			}
+			blank(i, i+2+end+2)
+			i += 2 + end + 1
+		}
+	}
+	return string(buf), -1
+}
+
+// stripComments is blankComments for the inputs of Import.
+func stripComments(input string) (string, error) {
+	res, unterminated := blankComments(input)
+	if unterminated >= 0 {
+		return input, errUnterminatedComment
+	}
+	return res, nil
+}
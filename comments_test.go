@@ -0,0 +1,63 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestImportComments(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `// Title style.
+bold: true; // Emphasized.
+/* The accent
+   color. */ foreground: #7D56F4 /* purple */;
+border-style: border("/*","//","|","|","+","+","+","+");
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; border-style: border("/*","//","|","|","+","+","+","+"); foreground: #7D56F4;`, Export(s))
+
+	// The provenance comments of Export can be imported back.
+	r := Resolve(Layer{Name: "base", Styles: map[string]S{"title": s}})
+	s, err = Import(lipgloss.NewStyle(), r.ExportWithOrigins("title"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; border-style: border("/*","//","|","|","+","+","+","+"); foreground: #7D56F4;`, Export(s))
+
+	if _, err := Import(lipgloss.NewStyle(), `bold: true; /* oops`); err == nil || err.Error() != `invalid syntax: unterminated comment` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate("bold: true // until the end of line"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCommentsSourceMap(t *testing.T) {
+	_, sm, err := ImportWithSourceMap(lipgloss.NewStyle(), "/* header;\n   comment; */\nbold: true; // note;\nfaint: true", "a.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sm["bold"].Line != 3 || sm["faint"].Line != 4 {
+		t.Errorf("unexpected source map: %v", sm)
+	}
+}
+
+func TestSheetComments(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+// The application title.
+title { bold: true; /* } */ }
+/* footer { faint: true } */
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := ss.Names(); len(names) != 1 || names[0] != "title" {
+		t.Errorf("unexpected styles: %v", names)
+	}
+	_, err = ImportSheet(StyleSheet{}, "title { bold: true }\n\n/* footer")
+	if err == nil || err.Error() != `line 3: unterminated comment` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Compose merges a stack of styles into a new style. Later layers
+// override earlier ones, but only for the properties they set; this
+// formalizes the base theme / user theme / override pattern.
+//
+// A layer sets a property when the property was assigned in it, even
+// to its default value: a layer with "bold: false" or "padding-left: 0"
+// resets the property over the earlier layers.
+func Compose(layers ...S) S {
+	result := lipgloss.NewStyle()
+	for _, l := range layers {
+		result = overlay(result, l, nil, nil, nil)
+	}
+	return result
+}
+
+// StyleLayer is a style with the names of its properties marked
+// !important, for ComposeLayers. The names are those reported by
+// Export, e.g. "padding-left"; see StyleSheet.Important.
+type StyleLayer struct {
+	Style     S
+	Important []string
+}
+
+// ComposeLayers is like Compose, but honors the !important markers: as
+// in CSS, a property marked important in a layer is not overridden by
+// the later layers, unless they also mark it important.
+func ComposeLayers(layers ...StyleLayer) S {
+	result := lipgloss.NewStyle()
+	important := map[string]bool{}
+	for _, l := range layers {
+		result = overlay(result, l.Style, important, importantSet(l.Important), nil)
+	}
+	return result
+}
+
+// importantSet converts a list of property names to a set.
+func importantSet(props []string) map[string]bool {
+	if len(props) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(props))
+	for _, prop := range props {
+		set[prop] = true
+	}
+	return set
+}
+
+// importantList converts a set of property names to a sorted list.
+func importantList(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	props := make([]string, 0, len(set))
+	for prop := range set {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	return props
+}
+
+// overlay copies the properties set in layer onto dst. The properties
+// in important, if any, are not overridden unless they are also in
+// layerImportant; those of layerImportant that are copied are added to
+// important. If record is non-nil, it is called with the name of each
+// property copied.
+func overlay(dst, layer S, important, layerImportant map[string]bool, record func(prop string)) S {
+	v := reflect.ValueOf(layer)
+	set := setProps(layer)
+	for _, g := range styleGetters {
+		if !set[g.name] || (important[g.name] && !layerImportant[g.name]) {
+			continue
+		}
+		if layerImportant[g.name] && important != nil {
+			important[g.name] = true
+		}
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		dst = g.setFn.Call([]reflect.Value{reflect.ValueOf(dst), val})[0].Interface().(S)
+		if record != nil {
+			record(g.name)
+		}
+	}
+	return dst
+}
+
+// Layer is a named source of styles, for use with Resolve.
+type Layer struct {
+	// Name identifies the layer in provenance reports,
+	// e.g. "defaults", "theme.gloss" or "env".
+	Name string
+	// Styles maps style names to styles.
+	Styles map[string]S
+	// Sources optionally maps style names to the source maps of the
+	// styles, as returned by ImportWithSourceMap, for Resolved.Why.
+	Sources map[string]SourceMap
+	// Important optionally maps style names to the properties marked
+	// !important in the styles, as returned by StyleSheet.Important.
+	// The later layers do not override them unless they also mark
+	// them important.
+	Important map[string][]string
+}
+
+// Resolved is the result of Resolve.
+type Resolved struct {
+	// Styles maps style names to the final styles.
+	Styles map[string]S
+
+	// origins maps style names, then property names to the name of
+	// the layer that determined the final value.
+	origins map[string]map[string]string
+	// important maps style names to the properties marked !important
+	// in the final styles.
+	important map[string]map[string]bool
+	// sources maps layer names, then style names to the source maps
+	// of the layers.
+	sources map[string]map[string]SourceMap
+}
+
+// Resolve merges style sources in precedence order, for example
+// built-in defaults, then a theme file, then the environment, then
+// explicit overrides. Styles with the same name are merged as per
+// Compose, so a layer can reset a property to its default value, and
+// the properties marked important in Layer.Important are kept as per
+// ComposeLayers. The result remembers which layer set each property.
+func Resolve(layers ...Layer) Resolved {
+	r := Resolved{
+		Styles:    map[string]S{},
+		origins:   map[string]map[string]string{},
+		important: map[string]map[string]bool{},
+		sources:   map[string]map[string]SourceMap{},
+	}
+	for _, l := range layers {
+		if l.Sources != nil {
+			r.sources[l.Name] = l.Sources
+		}
+		// Process the styles in a deterministic order.
+		names := make([]string, 0, len(l.Styles))
+		for name := range l.Styles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			origins := r.origins[name]
+			if origins == nil {
+				origins = map[string]string{}
+				r.origins[name] = origins
+			}
+			important := r.important[name]
+			if important == nil {
+				important = map[string]bool{}
+				r.important[name] = important
+			}
+			dst, ok := r.Styles[name]
+			if !ok {
+				dst = lipgloss.NewStyle()
+			}
+			r.Styles[name] = overlay(dst, l.Styles[name], important, importantSet(l.Important[name]), func(prop string) {
+				origins[prop] = l.Name
+			})
+		}
+	}
+	return r
+}
+
+// Origin returns the name of the layer that determined the value of
+// the given property in the given style. The property name is that
+// reported by Export, e.g. "padding-left" and not "padding".
+func (r Resolved) Origin(style, prop string) (layer string, ok bool) {
+	layer, ok = r.origins[style][prop]
+	return layer, ok
+}
+
+// Important returns the names of the properties marked !important in
+// the given style, in sorted order, e.g. to pass the result to another
+// call to Resolve in Layer.Important.
+func (r Resolved) Important(style string) []string {
+	return importantList(r.important[style])
+}
+
+// ExportWithOrigins is like Export for the given style, but annotates
+// each directive with a comment naming the layer that set it, e.g.
+// "bold: true; /* theme.gloss */". This makes merged themes auditable.
+func (r Resolved) ExportWithOrigins(style string, opts ...ExportOption) string {
+	opts = append(opts[:len(opts):len(opts)], func(e *options) {
+		e.origin = func(prop string) string {
+			layer, _ := r.Origin(style, prop)
+			return layer
+		}
+	})
+	return Export(r.Styles[style], opts...)
+}
+
+// CombineSpecs applies the given style specifications in order to an
+// empty style, and returns the canonical specification of the result.
+// Later fragments override earlier ones. Unlike Compose, a fragment
+// can reset a property with e.g. "bold: false" or "bold: unset".
+// This can be used to flatten override files for storage.
+//
+// As in CSS, a value marked "!important" in a fragment survives the
+// later fragments, unless they also mark it "!important".
+func CombineSpecs(specs ...string) (string, error) {
+	s := lipgloss.NewStyle()
+	important := map[string]bool{}
+	for i, spec := range specs {
+		var err error
+		s, err = Import(s, spec, withImportant(important))
+		if err != nil {
+			return "", fmt.Errorf("spec %d: %v", i+1, err)
+		}
+	}
+	return Export(s), nil
+}
@@ -0,0 +1,162 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCompose(t *testing.T) {
+	base := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("12")).
+		PaddingLeft(2)
+	user := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#fff")).
+		BorderStyle(lipgloss.RoundedBorder())
+	override := lipgloss.NewStyle().
+		PaddingLeft(4)
+
+	checkOutput(t, ``, Export(Compose()))
+	checkOutput(t, Export(base), Export(Compose(base)))
+	checkOutput(t,
+		`bold: true; border-style: border("─","─","│","│","╭","╮","╯","╰"); foreground: #fff; padding-left: 4;`,
+		Export(Compose(base, user, override)))
+
+	// The layers are not modified.
+	checkOutput(t, `bold: true; foreground: 12; padding-left: 2;`, Export(base))
+}
+
+func TestComposeResetToDefault(t *testing.T) {
+	base := lipgloss.NewStyle().Bold(true).PaddingLeft(2).Italic(true)
+	override := lipgloss.NewStyle().Bold(false).PaddingLeft(0)
+
+	checkOutput(t, `italic: true;`, Export(Compose(base, override)))
+	// The result sets the properties explicitly, for later layers.
+	checkOutput(t, `bold: false; italic: true; padding-left: 0;`,
+		Diff(lipgloss.NewStyle(), Compose(base, override)).String())
+}
+
+func TestComposeLayersImportant(t *testing.T) {
+	base := StyleLayer{
+		Style:     lipgloss.NewStyle().Foreground(lipgloss.Color("#f00")).Bold(false),
+		Important: []string{"foreground", "bold"},
+	}
+	user := StyleLayer{Style: lipgloss.NewStyle().Foreground(lipgloss.Color("#00f")).Bold(true).Italic(true)}
+	checkOutput(t, `foreground: #f00; italic: true;`, Export(ComposeLayers(base, user)))
+
+	// A later layer marking a property important overrides it.
+	user.Important = []string{"foreground"}
+	checkOutput(t, `foreground: #00f; italic: true;`, Export(ComposeLayers(base, user)))
+
+	// Without the markers, ComposeLayers is like Compose.
+	checkOutput(t, Export(Compose(base.Style, user.Style)),
+		Export(ComposeLayers(StyleLayer{Style: base.Style}, StyleLayer{Style: user.Style})))
+}
+
+func TestResolve(t *testing.T) {
+	defaults := Layer{Name: "defaults", Styles: map[string]S{
+		"title":  lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12")),
+		"footer": lipgloss.NewStyle().Faint(true),
+	}}
+	theme := Layer{Name: "theme.gloss", Styles: map[string]S{
+		"title": lipgloss.NewStyle().Foreground(lipgloss.Color("#fff")).PaddingLeft(1),
+	}}
+	env := Layer{Name: "env", Styles: map[string]S{
+		"title":  lipgloss.NewStyle().PaddingLeft(2),
+		"status": lipgloss.NewStyle().Reverse(true),
+	}}
+
+	r := Resolve(defaults, theme, env)
+	if len(r.Styles) != 3 {
+		t.Fatalf("expected 3 styles, got %v", r.Styles)
+	}
+	checkOutput(t, `bold: true; foreground: #fff; padding-left: 2;`, Export(r.Styles["title"]))
+	checkOutput(t, `faint: true;`, Export(r.Styles["footer"]))
+	checkOutput(t, `reverse: true;`, Export(r.Styles["status"]))
+
+	for _, tc := range []struct {
+		style, prop string
+		layer       string
+		ok          bool
+	}{
+		{"title", "bold", "defaults", true},
+		{"title", "foreground", "theme.gloss", true},
+		{"title", "padding-left", "env", true},
+		{"title", "italic", "", false},
+		{"footer", "faint", "defaults", true},
+		{"unknown", "bold", "", false},
+	} {
+		layer, ok := r.Origin(tc.style, tc.prop)
+		if layer != tc.layer || ok != tc.ok {
+			t.Errorf("%s.%s: expected %q/%v, got %q/%v", tc.style, tc.prop, tc.layer, tc.ok, layer, ok)
+		}
+	}
+
+	checkOutput(t, `bold: true; /* defaults */
+foreground: #fff; /* theme.gloss */
+padding-left: 2; /* env */`, r.ExportWithOrigins("title", WithSeparator("\n")))
+
+	// The layers are not modified.
+	checkOutput(t, `bold: true; foreground: 12;`, Export(defaults.Styles["title"]))
+}
+
+func TestResolveResetToDefault(t *testing.T) {
+	defaults := Layer{Name: "defaults", Styles: map[string]S{
+		"title": lipgloss.NewStyle().Bold(true).PaddingLeft(2),
+	}}
+	env := Layer{Name: "env", Styles: map[string]S{
+		"title": lipgloss.NewStyle().Bold(false),
+	}}
+
+	r := Resolve(defaults, env)
+	checkOutput(t, `padding-left: 2;`, Export(r.Styles["title"]))
+	if layer, _ := r.Origin("title", "bold"); layer != "env" {
+		t.Errorf("expected bold from env, got %q", layer)
+	}
+}
+
+func TestResolveImportant(t *testing.T) {
+	theme, err := ImportSheet(StyleSheet{}, `title { foreground: #f00 !important; bold: true; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Resolve(
+		Layer{Name: "theme", Styles: theme.Styles(), Important: map[string][]string{"title": theme.Important("title")}},
+		Layer{Name: "env", Styles: map[string]S{"title": lipgloss.NewStyle().Foreground(lipgloss.Color("#00f")).Bold(false)}},
+	)
+	checkOutput(t, `foreground: #f00;`, Export(r.Styles["title"]))
+	if layer, _ := r.Origin("title", "foreground"); layer != "theme" {
+		t.Errorf("expected foreground from theme, got %q", layer)
+	}
+	checkOutput(t, `foreground`, strings.Join(r.Important("title"), " "))
+}
+
+func TestCombineSpecs(t *testing.T) {
+	res, err := CombineSpecs(
+		`bold: true; foreground: 12; padding: 1`,
+		`foreground: #fff; italic: true`,
+		`bold: false; padding-left: 3`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `foreground: #fff; italic: true; padding-bottom: 1; padding-left: 3; padding-right: 1; padding-top: 1;`, res)
+
+	res, err = CombineSpecs(
+		`foreground: 12 !important; bold: true`,
+		`foreground: #fff; bold: false`,
+		`italic: true !important`,
+		`italic: false !important`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `foreground: 12;`, res)
+
+	_, err = CombineSpecs(`bold: true`, `bold: maybe`)
+	if err == nil || err.Error() != `spec 2: in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// WithBackgroundMode sets the background mode used by the @dark and
+// @light blocks in Import: with dark set to true, the directives in
+// @dark blocks are applied and those in @light blocks are ignored, and
+// conversely. Without this option, the mode is detected from the
+// terminal with lipgloss.HasDarkBackground when the input contains
+// such a block.
+func WithBackgroundMode(dark bool) ImportOption {
+	return func(o *importOptions) {
+		o.dark = &dark
+	}
+}
+
+// WithImportProfile sets the color profile used by the @profile
+// blocks in Import. Without this option, the profile is detected from
+// the terminal with lipgloss.ColorProfile when the input contains such
+// a block.
+func WithImportProfile(p termenv.Profile) ImportOption {
+	return func(o *importOptions) {
+		o.profile = &p
+	}
+}
+
+// profileNames are the color profiles accepted by @profile blocks.
+var profileNames = map[string]termenv.Profile{
+	"truecolor": termenv.TrueColor,
+	"256":       termenv.ANSI256,
+	"16":        termenv.ANSI,
+	"ascii":     termenv.Ascii,
+}
+
+// condition is the condition of a @dark, @light or @profile block.
+type condition struct {
+	// dark is set for @dark and @light blocks.
+	dark *bool
+	// profiles is set for @profile blocks.
+	profiles []termenv.Profile
+}
+
+// parseCondition parses the header of a conditional block, e.g.
+// "@dark" or "@profile 256 16".
+func parseCondition(header string) (condition, error) {
+	words := strings.Fields(header)
+	var c condition
+	switch {
+	case len(words) == 1 && (words[0] == "@dark" || words[0] == "@light"):
+		dark := words[0] == "@dark"
+		c.dark = &dark
+	case len(words) > 0 && words[0] == "@profile":
+		if len(words) == 1 {
+			return c, fmt.Errorf("missing color profile in %q", header)
+		}
+		for _, w := range words[1:] {
+			p, ok := profileNames[w]
+			if !ok {
+				return c, fmt.Errorf("unknown color profile: %q (expected truecolor, 256, 16 or ascii)", w)
+			}
+			c.profiles = append(c.profiles, p)
+		}
+	default:
+		return c, fmt.Errorf("unknown block: %q", header)
+	}
+	return c, nil
+}
+
+// holds reports whether the directives of a conditional block apply.
+func (opt *importOptions) holds(c condition) bool {
+	if opt.allConditions {
+		return true
+	}
+	if c.dark != nil {
+		return opt.isDark() == *c.dark
+	}
+	p := opt.colorProfile()
+	for _, other := range c.profiles {
+		if other == p {
+			return true
+		}
+	}
+	return false
+}
+
+// isDark reports the background mode for the @dark and @light blocks.
+func (opt *importOptions) isDark() bool {
+	if opt.dark != nil {
+		return *opt.dark
+	}
+	return lipgloss.HasDarkBackground()
+}
+
+// colorProfile reports the color profile for the @profile blocks.
+func (opt *importOptions) colorProfile() termenv.Profile {
+	if opt.profile != nil {
+		return *opt.profile
+	}
+	return lipgloss.ColorProfile()
+}
@@ -0,0 +1,120 @@
+package lipglossc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var constants = struct {
+	sync.RWMutex
+	values map[string]string
+}{values: map[string]string{}}
+
+// RegisterConstant defines a keyword that expands to the given value
+// in any spec subsequently imported, for example:
+//
+//	RegisterConstant("brand-accent", "#7D56F4")
+//	RegisterConstant("gutter", "2")
+//
+// makes "foreground: brand-accent; padding: 0 gutter;" valid.
+//
+// Constants take precedence over the built-in keywords and color
+// names. The name must start with a letter or underscore and
+// contain only letters, digits, dashes and underscores;
+// RegisterConstant panics otherwise.
+func RegisterConstant(name string, value string) {
+	if !reConstName.MatchString(name) {
+		panic(fmt.Sprintf("invalid constant name: %q", name))
+	}
+	constants.Lock()
+	defer constants.Unlock()
+	constants.values[name] = value
+}
+
+var reConstName = regexp.MustCompile(`^[a-zA-Z_][-a-zA-Z0-9_]*$`)
+
+// expandConstants replaces the registered constants in the given
+// property value. Quoted strings are left unchanged.
+func expandConstants(args string) string {
+	constants.RLock()
+	defer constants.RUnlock()
+	if len(constants.values) == 0 {
+		return args
+	}
+	return expandWords(args, constants.values)
+}
+
+// usedConstant returns the first registered constant used in the
+// given property value, if any.
+func usedConstant(args string) (string, bool) {
+	constants.RLock()
+	defer constants.RUnlock()
+	var found string
+	mapWords(args, func(word string) string {
+		if _, ok := constants.values[word]; ok && found == "" {
+			found = word
+		}
+		return word
+	})
+	return found, found != ""
+}
+
+// expandWords replaces the words of the given property value that
+// appear in the map by their value. Quoted strings, numbers and hex
+// colors are left unchanged.
+func expandWords(args string, words map[string]string) string {
+	return mapWords(args, func(word string) string {
+		if v, ok := words[word]; ok {
+			return v
+		}
+		return word
+	})
+}
+
+// mapWords replaces the words of the given property value by the
+// result of fn. Quoted strings, numbers and hex colors are left
+// unchanged.
+func mapWords(args string, fn func(word string) string) string {
+	var buf strings.Builder
+	inQuote := false
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case inQuote:
+			if c == '\\' && i+1 < len(args) {
+				buf.WriteByte(c)
+				i++
+				c = args[i]
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == '"':
+			inQuote = true
+		case c == '#' || (c >= '0' && c <= '9'):
+			// Numbers and hex colors are never constants.
+			j := i + 1
+			for j < len(args) && (isIdentStart(args[j]) || (args[j] >= '0' && args[j] <= '9') || args[j] == '.') {
+				j++
+			}
+			buf.WriteString(args[i:j])
+			i = j - 1
+			continue
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(args) && (isIdentStart(args[j]) || args[j] == '-' || (args[j] >= '0' && args[j] <= '9')) {
+				j++
+			}
+			buf.WriteString(fn(args[i:j]))
+			i = j - 1
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String()
+}
+
+func isIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
@@ -0,0 +1,48 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestConstants(t *testing.T) {
+	RegisterConstant("brand-accent", "#7D56F4")
+	RegisterConstant("gutter", "2")
+	RegisterConstant("D56F4", "invalid")
+	RegisterConstant("fancy", `border("a","b","c","d","e","f","g","h")`)
+	defer func() {
+		constants.Lock()
+		defer constants.Unlock()
+		constants.values = map[string]string{}
+	}()
+
+	td := []struct {
+		in  string
+		out string
+	}{
+		{`foreground: brand-accent`, `foreground: #7D56F4;`},
+		{`foreground: adaptive(brand-accent, #D56F4a)`, `foreground: adaptive(#7D56F4,#D56F4a);`},
+		{`padding: 0 gutter`, `padding-left: 2; padding-right: 2;`},
+		{`border-style: fancy`, `border-style: border("a","b","c","d","e","f","g","h");`},
+		{`border-style: border("gutter","b","c","d","e","f","g","h")`, `border-style: border("gutter","b","c","d","e","f","g","h");`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			s, err := Import(lipgloss.NewStyle(), tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkOutput(t, tc.out, Export(s))
+		})
+	}
+}
+
+func TestRegisterConstantInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	RegisterConstant("1abc", "x")
+}
@@ -0,0 +1,123 @@
+package lipglossc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MinContrast is the minimum contrast ratio between foreground and
+// background colors accepted by CheckContrast. This is the WCAG AA
+// level for normal text.
+const MinContrast = 4.5
+
+// contrastPairs lists the foreground/background properties that are
+// rendered together.
+var contrastPairs = [][2]string{
+	{"foreground", "background"},
+	{"border-top-foreground", "border-top-background"},
+	{"border-right-foreground", "border-right-background"},
+	{"border-bottom-foreground", "border-bottom-background"},
+	{"border-left-foreground", "border-left-background"},
+}
+
+// CheckContrast reports the foreground/background color pairs in the
+// style whose contrast ratio is lower than MinContrast. Adaptive colors
+// are checked separately for light and dark backgrounds. Pairs where
+// either color is not set are not checked, since the colors of the
+// terminal are not known.
+func CheckContrast(s S) []string {
+	colors := map[string]lipgloss.TerminalColor{}
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		if val.Type().Name() != "TerminalColor" || isDefault(val) {
+			continue
+		}
+		colors[g.name] = val.Interface().(lipgloss.TerminalColor)
+	}
+
+	var problems []string
+	for _, pair := range contrastPairs {
+		fg, bg := colors[pair[0]], colors[pair[1]]
+		if fg == nil || bg == nil {
+			continue
+		}
+		for _, branch := range []string{"light", "dark"} {
+			fgc, fgAdaptive := colorBranch(fg, branch == "dark")
+			bgc, bgAdaptive := colorBranch(bg, branch == "dark")
+			if branch == "dark" && !fgAdaptive && !bgAdaptive {
+				// Same colors as the light branch.
+				break
+			}
+			ratio, ok := contrastRatio(fgc, bgc)
+			if !ok || ratio >= MinContrast {
+				continue
+			}
+			what := ""
+			if fgAdaptive || bgAdaptive {
+				what = " (" + branch + ")"
+			}
+			problems = append(problems, fmt.Sprintf("%s %s on %s %s%s: contrast ratio %.2f:1 is below %.1f:1",
+				pair[0], fgc, pair[1], bgc, what, ratio, MinContrast))
+		}
+	}
+	return problems
+}
+
+// colorBranch returns the color used for the given background, and
+// whether the color is adaptive. For complete colors, the true color
+// variant is used.
+func colorBranch(tc lipgloss.TerminalColor, dark bool) (string, bool) {
+	switch tc := tc.(type) {
+	case lipgloss.Color:
+		return string(tc), false
+	case lipgloss.AdaptiveColor:
+		if dark {
+			return tc.Dark, true
+		}
+		return tc.Light, true
+	case lipgloss.CompleteColor:
+		return tc.TrueColor, false
+	case lipgloss.CompleteAdaptiveColor:
+		if dark {
+			return tc.Dark.TrueColor, true
+		}
+		return tc.Light.TrueColor, true
+	}
+	return "", false
+}
+
+// contrastRatio computes the WCAG contrast ratio between two colors.
+func contrastRatio(a, b string) (float64, bool) {
+	la, ok := luminance(a)
+	if !ok {
+		return 0, false
+	}
+	lb, ok := luminance(b)
+	if !ok {
+		return 0, false
+	}
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05), true
+}
+
+// luminance computes the WCAG relative luminance of a color.
+func luminance(c string) (float64, bool) {
+	r, g, b, ok := colorRGB(c)
+	if !ok {
+		return 0, false
+	}
+	lin := func(v int) float64 {
+		x := float64(v) / 255
+		if x <= 0.03928 {
+			return x / 12.92
+		}
+		return math.Pow((x+0.055)/1.055, 2.4)
+	}
+	return 0.2126*lin(r) + 0.7152*lin(g) + 0.0722*lin(b), true
+}
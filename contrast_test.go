@@ -0,0 +1,25 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCheckContrast(t *testing.T) {
+	if r, _ := contrastRatio("#000", "#fff"); r != 21 {
+		t.Errorf("expected 21, got %v", r)
+	}
+
+	s, err := Import(lipgloss.NewStyle(), `foreground: #777; background: #888;
+border-top-foreground: adaptive(#000,#eee); border-top-background: #fff;
+border-left-foreground: 15; border-left-background: 0;
+border-right-foreground: #777`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `foreground #777 on background #888: contrast ratio 1.26:1 is below 4.5:1
+border-top-foreground #eee on border-top-background #fff (dark): contrast ratio 1.16:1 is below 4.5:1`,
+		strings.Join(CheckContrast(s), "\n"))
+}
@@ -2,13 +2,18 @@ package lipglossc
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // S is a handy alias to simplify declarations in this library.
@@ -16,37 +21,373 @@ type S = lipgloss.Style
 
 // Import reads style specifications from the input string
 // and sets the corresponding properties in the dst style.
-func Import(dst S, input string) (S, error) {
-	// Syntax: semicolon-separated list of prop: values... pairs.
-	assignments := strings.Split(input, ";")
-	for _, a := range assignments {
-		a = strings.TrimSpace(a)
-		if a == "" {
-			continue
+//
+// A value followed by "!important", e.g. "bold: true !important", is
+// not overridden by later directives unless they are also important.
+//
+// The input can contain "// ..." line comments and "/* ... */" block
+// comments.
+//
+// Properties sharing a prefix can be grouped in a nested block:
+// "border { top: true; style: rounded; }" is equivalent to
+// "border-top: true; border-style: rounded;".
+//
+// The directives in a "@dark { ... }" or "@light { ... }" block are
+// applied only if the background mode is dark, respectively light; see
+// WithBackgroundMode. Likewise, the directives in a
+// "@profile truecolor|256|16|ascii { ... }" block are applied only
+// for one of the listed color profiles; see WithImportProfile.
+func Import(dst S, input string, opts ...ImportOption) (S, error) {
+	opt := makeImportOptions(opts)
+	if m := loadMetrics(); m != nil {
+		start := time.Now()
+		dst, err := importStyle(dst, input, &opt)
+		m.ImportDone(time.Since(start), len(splitAssignments(input, opt.sep)), err)
+		return dst, err
+	}
+	return importStyle(dst, input, &opt)
+}
+
+type importOptions struct {
+	sep string
+	// important is the set of properties set by !important
+	// directives; see protect.
+	important map[string]bool
+	// onDirective, if set, is called after each directive.
+	onDirective func(prop, value string, s S) error
+	// warn, if set, is called for each warning.
+	warn func(Warning)
+	// ranges applies the range policy.
+	ranges rangeCheck
+	// vars are the variables defined so far, without "$".
+	vars map[string]string
+	// dark, if set, is the background mode for @dark and @light
+	// blocks; see isDark.
+	dark *bool
+	// profile, if set, is the color profile for @profile blocks; see
+	// colorProfile.
+	profile *termenv.Profile
+	// abbrev enables the abbreviations of property names; see
+	// WithAbbreviations.
+	abbrev bool
+	// palette maps the names of the palette entries of a stylesheet to
+	// their color, for the color properties.
+	palette map[string]string
+	// strict rejects the extensions to the core syntax; see
+	// WithStrict.
+	strict bool
+	// borders maps the names of the borders defined in a stylesheet to
+	// their border(...) value, for the border properties.
+	borders map[string]string
+	// styles are the styles available to the apply directive; see
+	// WithStyles.
+	styles map[string]S
+	// lookupEnv, if set, resolves the references to environment
+	// variables; see WithEnvInterpolation.
+	lookupEnv func(string) (string, bool)
+	// version is the version selected for the @version sections of
+	// stylesheets; see WithThemeVersion.
+	version string
+	// allConditions keeps the directives of all the conditional
+	// blocks, for Validate.
+	allConditions bool
+}
+
+// ImportOption configures Import.
+type ImportOption func(*importOptions)
+
+func makeImportOptions(opts []ImportOption) importOptions {
+	opt := importOptions{sep: ";", important: map[string]bool{}, vars: map[string]string{}}
+	for _, o := range opts {
+		o(&opt)
+	}
+	return opt
+}
+
+// WithDirectiveCallback calls fn after each directive is applied,
+// with the property name and value as written in the input (without
+// any !important marker), and a copy of the resulting style. For the
+// clear and clear-xxx keywords, the property is the keyword and the
+// value is empty. If fn returns an error, Import stops and returns it,
+// with the style as it was before the directive that fn rejected.
+//
+// This can be used to report progress on large inputs, or to trigger
+// side effects as properties are set.
+func WithDirectiveCallback(fn func(prop, value string, s S) error) ImportOption {
+	return func(o *importOptions) {
+		o.onDirective = fn
+	}
+}
+
+// withImportant shares the set of important properties across
+// multiple calls to Import, so that a value marked !important in an
+// earlier input survives the later ones.
+func withImportant(important map[string]bool) ImportOption {
+	return func(o *importOptions) {
+		o.important = important
+	}
+}
+
+// WithImportSeparator sets the separator between directives, for
+// example "\n" for inputs with one directive per line. The default is
+// ";". An empty separator is ignored.
+func WithImportSeparator(sep string) ImportOption {
+	return func(o *importOptions) {
+		if sep != "" {
+			o.sep = sep
 		}
+	}
+}
 
+func importStyle(dst S, input string, opt *importOptions) (S, error) {
+	input, err := preprocess(input, opt.sep, opt)
+	if err != nil {
+		return dst, err
+	}
+	// Syntax: semicolon-separated list of prop: values... pairs.
+	for _, a := range splitAssignments(input, opt.sep) {
+		// lipgloss setters modify the style in-place, so protect
+		// needs a copy to compare with, and the directive callback a
+		// copy to return if it fails.
+		before := dst
+		if len(opt.important) > 0 || strings.HasSuffix(a, "!important") || opt.onDirective != nil {
+			before = dst.Copy()
+		}
 		if a == "clear" {
 			// Special keyword: reset style.
-			dst = lipgloss.NewStyle()
+			dst = opt.protect(before, lipgloss.NewStyle(), "", false)
+			if err := opt.directiveDone(a, a, "", dst); err != nil {
+				return before, err
+			}
+			continue
+		}
+		if props, ok := clearCategory(a); ok {
+			// Special keyword: reset only one category of properties.
+			var err error
+			dst, err = unsetProps(dst, props)
+			if err != nil {
+				return dst, fmt.Errorf("in %q: %v", a, err)
+			}
+			dst = opt.protect(before, dst, "", false)
+			if err := opt.directiveDone(a, a, "", dst); err != nil {
+				return before, err
+			}
 			continue
 		}
 
-		pair := strings.SplitN(a, ":", 2)
-		if len(pair) != 2 {
+		propName, args, ok := splitAssignment(a)
+		if !ok {
 			return dst, fmt.Errorf("invalid syntax: %q", a)
 		}
-		propName, args := pair[0], pair[1]
-		propName = strings.TrimSpace(propName)
-		args = strings.TrimSpace(args)
-		p, err := getProp(propName)
+		if strings.HasPrefix(propName, "$") {
+			if err := opt.defineVariable(propName, args); err != nil {
+				return dst, fmt.Errorf("in %q: %v", a, err)
+			}
+			if err := opt.directiveDone(a, propName, args, dst); err != nil {
+				return before, err
+			}
+			continue
+		}
+		if propName == "apply" {
+			dst, err = opt.applyStyles(dst, args)
+			if err != nil {
+				return dst, fmt.Errorf("in %q: %v", a, err)
+			}
+			dst = opt.protect(before, dst, "", false)
+			if err := opt.directiveDone(a, propName, args, dst); err != nil {
+				return before, err
+			}
+			continue
+		}
+		propName, p, err := opt.lookupProp(propName)
 		if err != nil {
 			return dst, fmt.Errorf("in %q: %v", a, err)
 		}
+		opt.checkDeprecated(propName)
 
-		dst, err = p.assign(dst, args)
+		args, important := splitImportant(args)
+		args, err = expandVariables(args, opt.vars)
+		if err != nil {
+			return dst, fmt.Errorf("in %q: %v", a, err)
+		}
+		args, err = opt.expandMacros(unquoteValue(args))
 		if err != nil {
 			return dst, fmt.Errorf("in %q: %v", a, err)
 		}
+		if len(opt.palette) > 0 && p.hasColor() {
+			args = expandWords(args, opt.palette)
+		}
+		if len(opt.borders) > 0 && p.hasBorder() {
+			args = expandWords(args, opt.borders)
+		}
+		if err := opt.checkStrict(args); err != nil {
+			return dst, fmt.Errorf("in %q: %v", a, err)
+		}
+		opt.ranges.clamped = opt.ranges.clamped[:0]
+		dst, err = p.assign(dst, args, &opt.ranges)
+		if err != nil {
+			return dst, fmt.Errorf("in %q: %v", a, p.withSyntax(propName, err))
+		}
+		opt.reportClamped(propName)
+		dst = opt.protect(before, dst, propName, important)
+		if err := opt.directiveDone(a, propName, args, dst); err != nil {
+			return before, err
+		}
+	}
+	return dst, nil
+}
+
+// preprocess removes the comments from the input, replaces the
+// references to environment variables and expands the nested blocks,
+// as per expandNested.
+func preprocess(input, sep string, opt *importOptions) (string, error) {
+	input, err := stripComments(input)
+	if err != nil {
+		return input, err
+	}
+	if opt != nil {
+		input, _, err = opt.interpolateEnv(input)
+		if err != nil {
+			return input, err
+		}
+	}
+	return expandNested(input, sep, opt)
+}
+
+// directiveDone calls the WithDirectiveCallback function, if any,
+// after the directive a.
+func (opt *importOptions) directiveDone(a, prop, value string, s S) error {
+	if opt.onDirective == nil {
+		return nil
+	}
+	if err := opt.onDirective(prop, value, s.Copy()); err != nil {
+		return fmt.Errorf("in %q: %v", a, err)
+	}
+	return nil
+}
+
+// splitImportant removes the !important marker at the end of the
+// value of a directive, if any.
+func splitImportant(args string) (string, bool) {
+	if !strings.HasSuffix(args, "!important") {
+		return args, false
+	}
+	return strings.TrimSpace(strings.TrimSuffix(args, "!important")), true
+}
+
+// protect implements the !important marker. After an important
+// directive, it records the properties that the directive set. After
+// other directives, it restores the important properties that they
+// modified to their previous value.
+func (opt *importOptions) protect(before, after S, propName string, important bool) S {
+	if !important && len(opt.important) == 0 {
+		return after
+	}
+	bv, av := propValues(before), propValues(after)
+	for i, g := range styleGetters {
+		changed := !reflect.DeepEqual(bv[i], av[i])
+		switch {
+		case important:
+			if changed || g.name == propName {
+				opt.important[g.name] = true
+			}
+		case changed && opt.important[g.name]:
+			after = g.setFn.Call([]reflect.Value{reflect.ValueOf(after), reflect.ValueOf(bv[i])})[0].Interface().(S)
+		}
+	}
+	return after
+}
+
+// splitAssignments splits the input into individual directives
+// separated by sep, omitting empty ones. Separators within
+// double-quoted strings do not count.
+func splitAssignments(input, sep string) []string {
+	var res []string
+	for _, a := range splitOutsideStrings(input, sep) {
+		if a = strings.TrimSpace(a); a != "" {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// splitOutsideStrings splits the input at the separators that are not
+// within double-quoted strings. Unlike splitAssignments, the parts are
+// returned as-is, including the empty ones.
+func splitOutsideStrings(input, sep string) []string {
+	var res []string
+	start, inString := 0, false
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && strings.HasPrefix(input[i:], sep):
+			res = append(res, input[start:i])
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+	return append(res, input[start:])
+}
+
+// splitAssignment splits a "prop: value" directive.
+func splitAssignment(a string) (propName, args string, ok bool) {
+	pair := strings.SplitN(a, ":", 2)
+	if len(pair) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(pair[0]), strings.TrimSpace(pair[1]), true
+}
+
+// propCategories groups related properties together. This is used
+// by the clear-xxx keywords in Import.
+var propCategories = map[string][]string{
+	"text": {
+		"blink", "bold", "color-whitespace", "faint", "italic", "reverse",
+		"strikethrough", "strikethrough-spaces", "underline", "underline-spaces",
+	},
+	"colors": {
+		"background", "foreground", "margin-background",
+		"border-bottom-background", "border-bottom-foreground",
+		"border-left-background", "border-left-foreground",
+		"border-right-background", "border-right-foreground",
+		"border-top-background", "border-top-foreground",
+	},
+	"border": {
+		"border-bottom", "border-left", "border-right", "border-style", "border-top",
+	},
+	"layout": {
+		"align-horizontal", "align-vertical", "height", "inline",
+		"margin-bottom", "margin-left", "margin-right", "margin-top",
+		"max-height", "max-width",
+		"padding-bottom", "padding-left", "padding-right", "padding-top",
+		"width",
+	},
+}
+
+// clearCategory recognizes the clear-xxx keywords and returns the
+// corresponding properties.
+func clearCategory(a string) ([]string, bool) {
+	if !strings.HasPrefix(a, "clear-") {
+		return nil, false
+	}
+	props, ok := propCategories[strings.TrimPrefix(a, "clear-")]
+	return props, ok
+}
+
+// unsetProps removes the given properties from the style.
+func unsetProps(dst S, props []string) (S, error) {
+	for _, name := range props {
+		p, err := getProp(name)
+		if err != nil {
+			return dst, err
+		}
+		dst, err = p.assign(dst, "unset", nil)
+		if err != nil {
+			return dst, fmt.Errorf("%s: %v", name, err)
+		}
 	}
 	return dst, nil
 }
@@ -54,6 +395,32 @@ func Import(dst S, input string) (S, error) {
 type options struct {
 	includeDefaults bool
 	sep             string
+	hexCase         HexCase
+	namedColors     bool
+	shellQuote      bool
+	// resolveAdaptive, when set, emits only the branch of adaptive
+	// colors selected by dark.
+	resolveAdaptive bool
+	dark            bool
+	boolWords       BoolWords
+	posKeywords     bool
+	// origin, if set, names the source of a property. The name is
+	// emitted as a comment after the directive.
+	origin func(prop string) string
+	// important, if set, is the set of properties marked !important
+	// in the exported style of a sheet.
+	important map[string]bool
+	// vars are the variables to preserve, see WithVariables.
+	vars map[string]string
+	// colorRefs, if set, maps colors to the variable references that
+	// replace them, e.g. "#7D56F4" to "$slateblue".
+	colorRefs map[string]string
+	// paletteRefs, if set, maps whole color values to the names of the
+	// palette entries that replace them, e.g. "#7D56F4" to "accent".
+	paletteRefs map[string]string
+	// borderRefs, if set, maps border(...) values to the names of the
+	// borders of a stylesheet; see StyleSheet.Export.
+	borderRefs map[string]string
 }
 
 type ExportOption func(*options)
@@ -72,24 +439,215 @@ func WithExportDefaults() ExportOption {
 	}
 }
 
+// HexCase selects the letter case of hexadecimal colors in Export.
+type HexCase int
+
+const (
+	hexAsIs HexCase = iota
+	// HexLower emits hex colors in lowercase, e.g. #fafafa.
+	HexLower
+	// HexUpper emits hex colors in uppercase, e.g. #FAFAFA.
+	HexUpper
+)
+
+// WithHexCase normalizes the letter case of exported hex colors.
+// By default, colors are emitted with the case they were defined with.
+func WithHexCase(c HexCase) ExportOption {
+	return func(e *options) {
+		e.hexCase = c
+	}
+}
+
+// BoolWords selects the keywords used for boolean values in Export.
+type BoolWords int
+
+const (
+	// BoolTrueFalse emits booleans as true/false. This is the default.
+	BoolTrueFalse BoolWords = iota
+	// BoolOnOff emits booleans as on/off.
+	BoolOnOff
+	// BoolYesNo emits booleans as yes/no.
+	BoolYesNo
+)
+
+// WithBoolWords selects the keywords used for exported booleans.
+func WithBoolWords(w BoolWords) ExportOption {
+	return func(e *options) {
+		e.boolWords = w
+	}
+}
+
+// WithPositionKeywords emits the positions that have a keyword,
+// e.g. 0.5 for alignments, as the keyword (left, center, right, top
+// or bottom) instead of a number.
+func WithPositionKeywords() ExportOption {
+	return func(e *options) {
+		e.posKeywords = true
+	}
+}
+
+// WithNamedColors replaces hex colors by the nearest X11 color name,
+// e.g. #ff0000 becomes red, when one exists within a small tolerance.
+// Note that the substitution is approximate: re-importing the
+// result yields the exact value of the named color.
+func WithNamedColors() ExportOption {
+	return func(e *options) {
+		e.namedColors = true
+	}
+}
+
+// WithShellQuoting produces a single line quoted for POSIX shells,
+// suitable for passing a style through environment variables or
+// command-line arguments. It overrides WithSeparator.
+func WithShellQuoting() ExportOption {
+	return func(e *options) {
+		e.shellQuote = true
+	}
+}
+
+// WithResolvedBackground emits the Dark (if dark is true) or Light
+// branch of adaptive colors instead of the adaptive color itself.
+// This produces a flat snapshot of a theme for a given terminal
+// background.
+func WithResolvedBackground(dark bool) ExportOption {
+	return func(e *options) {
+		e.resolveAdaptive = true
+		e.dark = dark
+	}
+}
+
 // Export emits style specifications that represent
 // the given style.
 // If includeDefaults is set, all the fields set to
 // default values are also included in the output.
+// The properties are emitted in a fixed canonical order, so that
+// the output is byte-stable.
+//
+// Export only reads the style and the options, and never modifies
+// shared state, so it is safe to call concurrently from multiple
+// goroutines, including on the same style.
 func Export(s S, opts ...ExportOption) string {
+	opt := makeExportOptions(opts)
+
+	if opt.shellQuote {
+		opt.sep = " "
+	}
+
+	defs := exportVariables(&opt)
+	res := printDirectives(defs, append(defs, exportProps(s, &opt)...), &opt)
+	if opt.shellQuote {
+		return shellQuote(res)
+	}
+	return res
+}
+
+// printDirectives prints the directives, replacing the values
+// identical to those of the variable definitions in defs by
+// references.
+func printDirectives(defs, props []propValue, opt *options) string {
+	var buf strings.Builder
+	for _, pv := range props {
+		if buf.Len() > 0 {
+			buf.WriteString(opt.sep)
+		}
+		if ref, ok := variableRef(defs, pv.value); ok && !strings.HasPrefix(pv.name, "$") {
+			pv.value = ref
+		}
+		buf.WriteString(pv.name)
+		buf.WriteString(": ")
+		buf.WriteString(quoteValue(pv.value))
+		if opt.important[pv.name] {
+			buf.WriteString(" !important")
+		}
+		buf.WriteByte(';')
+		if opt.origin != nil {
+			if o := opt.origin(pv.name); o != "" {
+				fmt.Fprintf(&buf, " /* %s */", o)
+			}
+		}
+	}
+	return buf.String()
+}
+
+func makeExportOptions(opts []ExportOption) options {
 	opt := options{
 		sep: " ",
 	}
 	for _, o := range opts {
 		o(&opt)
 	}
+	return opt
+}
 
-	var buf strings.Builder
+// ExportMap returns the properties of the style with their textual
+// values, as printed by Export with the same options, e.g.
+// {"bold": "true", "padding-left": "2"}. This is useful to feed style
+// data into templates or key/value stores without parsing Export's
+// output. The separator and quoting options do not apply.
+func ExportMap(s S, opts ...ExportOption) map[string]string {
+	opt := makeExportOptions(opts)
+	m := map[string]string{}
+	for _, pv := range exportProps(s, &opt) {
+		m[pv.name] = pv.value
+	}
+	return m
+}
+
+// shellQuote quotes a string for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
 
+// propValue is a property name with its textual value.
+type propValue struct {
+	name  string
+	value string
+}
+
+// exportProps computes the textual representation of the properties
+// of the given style, in a stable order.
+func exportProps(s S, opt *options) []propValue {
+	var props []propValue
 	v := reflect.ValueOf(s)
-	t := v.Type()
-	for i := 0; i < t.NumMethod(); i++ {
-		m := t.Method(i)
+	for _, g := range styleGetters {
+		res := g.getFn.Call([]reflect.Value{v})
+
+		if !opt.includeDefaults && len(res) == 1 && isDefault(res[0]) {
+			// Default value. Don't report anything for this getter.
+			continue
+		}
+
+		var buf strings.Builder
+		for j, v := range res {
+			if j > 0 {
+				buf.WriteByte(' ')
+			}
+			printValue(&buf, g.name, v, opt)
+		}
+		props = append(props, propValue{name: g.name, value: buf.String()})
+	}
+	return props
+}
+
+// getter is a lipgloss.Style getter reported by Export.
+type getter struct {
+	// name is the property name, e.g. "padding-left".
+	name string
+	// getFn is the getter method, e.g. GetPaddingLeft.
+	getFn reflect.Value
+	// setFn is the corresponding setter method, e.g. PaddingLeft.
+	setFn reflect.Value
+	// unsetFn is the corresponding unset method, e.g. UnsetPaddingLeft.
+	unsetFn reflect.Value
+}
+
+// styleGetters lists the getters of lipgloss.Style, in a stable order.
+var styleGetters = findGetters()
+
+func findGetters() []getter {
+	var getters []getter
+	for i := 0; i < styleType.NumMethod(); i++ {
+		m := styleType.Method(i)
 		if !strings.HasPrefix(m.Name, "Get") {
 			continue
 		}
@@ -100,78 +658,225 @@ func Export(s S, opts ...ExportOption) string {
 			// Method with parameters; not truly a Getter. Ignore.
 			continue
 		}
-
-		res := m.Func.Call([]reflect.Value{v})
-
-		if !opt.includeDefaults && len(res) == 1 && isDefault(res[0]) {
-			// Default value. Don't report anything for this getter.
-			continue
+		g := getter{
+			name:  snakeCase(strings.TrimPrefix(m.Name, "Get")),
+			getFn: m.Func,
 		}
-
-		if buf.Len() > 0 {
-			buf.WriteString(opt.sep)
+		if sm, ok := styleType.MethodByName(strings.TrimPrefix(m.Name, "Get")); ok {
+			g.setFn = sm.Func
 		}
-		buf.WriteString(snakeCase(strings.TrimPrefix(m.Name, "Get")))
-		buf.WriteString(": ")
-		for j, v := range res {
-			if j > 0 {
-				buf.WriteByte(' ')
-			}
-			printValue(&buf, v)
+		unsetName := "Unset" + strings.TrimPrefix(m.Name, "Get")
+		if alias, ok := unsetAliases[unsetName]; ok {
+			unsetName = alias
 		}
-		buf.WriteByte(';')
+		if um, ok := styleType.MethodByName(unsetName); ok {
+			g.unsetFn = um.Func
+		}
+		getters = append(getters, g)
 	}
-	return buf.String()
+	sortGetters(getters)
+	return getters
 }
 
-func printValue(buf *strings.Builder, v reflect.Value) {
+// canonicalProps is the order in which Export emits the properties.
+// It does not depend on the order of the methods reported by reflect,
+// so that the output of Export is stable across Go and lipgloss
+// versions.
+var canonicalProps = []string{
+	"align-horizontal", "align-vertical",
+	"background", "blink", "bold",
+	"border-bottom", "border-bottom-background", "border-bottom-foreground",
+	"border-left", "border-left-background", "border-left-foreground",
+	"border-right", "border-right-background", "border-right-foreground",
+	"border-style",
+	"border-top", "border-top-background", "border-top-foreground",
+	"color-whitespace", "faint", "foreground", "height", "inline", "italic",
+	"margin-bottom", "margin-left", "margin-right", "margin-top",
+	"max-height", "max-width",
+	"padding-bottom", "padding-left", "padding-right", "padding-top",
+	"reverse", "strikethrough", "strikethrough-spaces",
+	"underline", "underline-spaces", "width",
+}
+
+// sortGetters sorts the getters in the order of canonicalProps.
+// Properties missing from the list, e.g. added in a later version of
+// lipgloss, come last in alphabetical order.
+func sortGetters(getters []getter) {
+	rank := make(map[string]int, len(canonicalProps))
+	for i, name := range canonicalProps {
+		rank[name] = i
+	}
+	sort.SliceStable(getters, func(i, j int) bool {
+		ri, iok := rank[getters[i].name]
+		rj, jok := rank[getters[j].name]
+		switch {
+		case iok && jok:
+			return ri < rj
+		case iok != jok:
+			return iok
+		}
+		return getters[i].name < getters[j].name
+	})
+}
+
+// printValue formats the value of the given property.
+func printValue(buf *strings.Builder, name string, v reflect.Value, opt *options) {
 	switch v.Type().Name() {
 	case "TerminalColor":
 		tc := v.Interface().(lipgloss.TerminalColor)
-		switch c := tc.(type) {
-		case lipgloss.NoColor:
-			buf.WriteString("none")
-		case lipgloss.Color:
-			buf.WriteString(string(c))
-		case lipgloss.AdaptiveColor:
-			fmt.Fprintf(buf, "adaptive(%s,%s)", c.Light, c.Dark)
-		case lipgloss.CompleteColor:
-			fmt.Fprintf(buf, "complete(%s,%s,%s)", c.TrueColor, c.ANSI256, c.ANSI)
-		case lipgloss.CompleteAdaptiveColor:
-			fmt.Fprintf(buf, "adaptive(complete(%s,%s,%s),complete(%s,%s,%s))",
-				c.Light.TrueColor, c.Light.ANSI256, c.Light.ANSI,
-				c.Dark.TrueColor, c.Dark.ANSI256, c.Dark.ANSI,
-			)
-		default:
-			r, g, b, _ := tc.RGBA()
-			fmt.Fprintf(buf, "#%02x%02x%02x", r, g, b)
+		if opt.resolveAdaptive {
+			tc = opt.resolveBackground(tc)
+		}
+		if len(opt.paletteRefs) == 0 {
+			printColor(buf, tc, opt)
+			break
+		}
+		var cbuf strings.Builder
+		printColor(&cbuf, tc, opt)
+		if ref, ok := opt.paletteRefs[cbuf.String()]; ok {
+			buf.WriteString(ref)
+		} else {
+			buf.WriteString(cbuf.String())
+		}
+	case "bool":
+		buf.WriteString(opt.bool(v.Bool()))
+	case "Position":
+		if k, ok := positionKeyword(name, v.Interface().(lipgloss.Position)); ok && opt.posKeywords {
+			buf.WriteString(k)
+		} else {
+			fmt.Fprintf(buf, "%v", v.Interface())
 		}
 	case "Border":
-		b := v.Interface().(lipgloss.Border)
-		fmt.Fprintf(buf, "border(%q,%q,%q,%q,%q,%q,%q,%q)",
-			b.Top, b.Bottom, b.Left, b.Right,
-			b.TopLeft, b.TopRight, b.BottomRight, b.BottomLeft,
-		)
+		literal := borderLiteral(v.Interface().(lipgloss.Border))
+		if ref, ok := opt.borderRefs[literal]; ok {
+			literal = ref
+		}
+		buf.WriteString(literal)
 	default:
 		fmt.Fprintf(buf, "%v", v.Interface())
 	}
 }
 
+// printColor formats a color value.
+func printColor(buf *strings.Builder, tc lipgloss.TerminalColor, opt *options) {
+	c := opt.color
+	switch tc := tc.(type) {
+	case lipgloss.NoColor:
+		buf.WriteString("none")
+	case lipgloss.Color:
+		buf.WriteString(c(string(tc)))
+	case lipgloss.AdaptiveColor:
+		fmt.Fprintf(buf, "adaptive(%s,%s)", c(tc.Light), c(tc.Dark))
+	case lipgloss.CompleteColor:
+		fmt.Fprintf(buf, "complete(%s,%s,%s)", c(tc.TrueColor), c(tc.ANSI256), c(tc.ANSI))
+	case lipgloss.CompleteAdaptiveColor:
+		fmt.Fprintf(buf, "adaptive(complete(%s,%s,%s),complete(%s,%s,%s))",
+			c(tc.Light.TrueColor), c(tc.Light.ANSI256), c(tc.Light.ANSI),
+			c(tc.Dark.TrueColor), c(tc.Dark.ANSI256), c(tc.Dark.ANSI),
+		)
+	default:
+		r, g, b, _ := tc.RGBA()
+		buf.WriteString(c(fmt.Sprintf("#%02x%02x%02x", r, g, b)))
+	}
+}
+
+// resolveBackground selects the branch of an adaptive color for the
+// background chosen with WithResolvedBackground.
+func (opt *options) resolveBackground(tc lipgloss.TerminalColor) lipgloss.TerminalColor {
+	switch tc := tc.(type) {
+	case lipgloss.AdaptiveColor:
+		if opt.dark {
+			return lipgloss.Color(tc.Dark)
+		}
+		return lipgloss.Color(tc.Light)
+	case lipgloss.CompleteAdaptiveColor:
+		if opt.dark {
+			return tc.Dark
+		}
+		return tc.Light
+	}
+	return tc
+}
+
+// bool formats a boolean value according to the export options.
+func (opt *options) bool(b bool) string {
+	words := [2]string{"false", "true"}
+	switch opt.boolWords {
+	case BoolOnOff:
+		words = [2]string{"off", "on"}
+	case BoolYesNo:
+		words = [2]string{"no", "yes"}
+	}
+	if b {
+		return words[1]
+	}
+	return words[0]
+}
+
+// color formats a single color value according to the export options.
+func (opt *options) color(s string) string {
+	if ref, ok := opt.colorRefs[s]; ok {
+		return ref
+	}
+	if !strings.HasPrefix(s, "#") {
+		return s
+	}
+	if opt.namedColors {
+		if name, ok := nearestNamedColor(s); ok {
+			return name
+		}
+	}
+	switch opt.hexCase {
+	case HexLower:
+		return strings.ToLower(s)
+	case HexUpper:
+		return strings.ToUpper(s)
+	}
+	return s
+}
+
 func isDefault(v reflect.Value) bool {
 	if v.IsZero() {
 		return true
 	}
 	switch v.Type().Name() {
 	case "TerminalColor":
-		color := v.Interface().(lipgloss.TerminalColor)
-		_, isNoColor := color.(lipgloss.NoColor)
-		return isNoColor
+		return isNoColor(v.Interface().(lipgloss.TerminalColor))
 	default:
 		// Unknown type. Always include in output.
 		return false
 	}
 }
 
+// setProps returns the names of the properties set in s, including
+// those set to their default value, e.g. with "bold: false". Unlike
+// isDefault, this distinguishes a property reset by a style from a
+// property that the style leaves alone.
+func setProps(s S) map[string]bool {
+	set := map[string]bool{}
+	n := numRules(s)
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		if !g.unsetFn.IsValid() {
+			if !isDefault(g.getFn.Call([]reflect.Value{v})[0]) {
+				set[g.name] = true
+			}
+			continue
+		}
+		// The unset methods modify the rules of the style in place.
+		u := g.unsetFn.Call([]reflect.Value{reflect.ValueOf(s.Copy())})[0].Interface().(S)
+		if numRules(u) < n {
+			set[g.name] = true
+		}
+	}
+	return set
+}
+
+// numRules returns the number of rules set in s.
+func numRules(s S) int {
+	return reflect.ValueOf(s).FieldByName("rules").Len()
+}
+
 var ignoredMethods = map[string]bool{
 	"GetAlign":                true,
 	"GetBorder":               true,
@@ -195,13 +900,21 @@ var ignoredMethods = map[string]bool{
 }
 
 func getProp(name string) (prop, error) {
-	p, ok := propRegistry[name]
+	propRegistry.RLock()
+	p, ok := propRegistry.props[name]
+	propRegistry.RUnlock()
+	if m := loadMetrics(); m != nil {
+		m.PropertyLookup(ok)
+	}
 	if !ok {
 		var err error
 		p, err = discoverProp(name)
 		if err != nil {
 			return prop{}, err
 		}
+		propRegistry.Lock()
+		propRegistry.props[name] = p
+		propRegistry.Unlock()
 	}
 	return p, nil
 }
@@ -258,7 +971,11 @@ func discoverProp(name string) (prop, error) {
 		args:       args,
 	}
 
-	if um, hasUnsetMethod := t.MethodByName("Unset" + name); hasUnsetMethod &&
+	unsetName := "Unset" + name
+	if alias, ok := unsetAliases[unsetName]; ok {
+		unsetName = alias
+	}
+	if um, hasUnsetMethod := t.MethodByName(unsetName); hasUnsetMethod &&
 		m.Type.NumOut() == 1 && m.Type.Out(0) == styleType {
 		p.unsetFn = um.Func
 	}
@@ -266,15 +983,26 @@ func discoverProp(name string) (prop, error) {
 	return p, nil
 }
 
+// unsetAliases maps the expected name of unset methods to their
+// actual name, for those methods that do not follow the convention.
+var unsetAliases = map[string]string{
+	"UnsetBorderTopBackground": "UnsetBorderTopBackgroundColor",
+}
+
 var styleType = reflect.TypeOf(lipgloss.NewStyle())
 
 type argtype interface {
-	parse([]byte, int) (int, reflect.Value, error)
+	parse([]byte, int, *rangeCheck) (int, reflect.Value, error)
+	// keywords lists the keywords recognized by parse.
+	keywords() []string
+	// syntax describes the values recognized by parse, for error
+	// messages and documentation.
+	syntax() string
 }
 
 type inttype struct{}
 
-func (inttype) parse(input []byte, first int) (pos int, val reflect.Value, err error) {
+func (inttype) parse(input []byte, first int, rc *rangeCheck) (pos int, val reflect.Value, err error) {
 	pos = first
 	r := reInt.FindSubmatch(input[pos:])
 	if r == nil {
@@ -285,32 +1013,63 @@ func (inttype) parse(input []byte, first int) (pos int, val reflect.Value, err e
 	if err != nil {
 		return pos, val, err
 	}
+	if i < 0 {
+		clamp, err := rc.outOfRange(fmt.Sprintf("negative value not allowed: %d", i), "0")
+		if err != nil {
+			return pos, val, err
+		}
+		if clamp {
+			i = 0
+		}
+	}
 	return pos, reflect.ValueOf(i), nil
 }
 
-var reInt = regexp.MustCompile(`^\s*([0-9]+)(?:\s+|$)`)
+func (inttype) keywords() []string { return nil }
+
+func (inttype) syntax() string { return "an integer" }
+
+var reInt = regexp.MustCompile(`^\s*(-?[0-9]+)(?:\s+|$)`)
 
 type booltype struct{}
 
-func (booltype) parse(input []byte, first int) (pos int, val reflect.Value, err error) {
+func (booltype) parse(input []byte, first int, _ *rangeCheck) (pos int, val reflect.Value, err error) {
 	pos = first
 	r := reBool.FindSubmatch(input[pos:])
 	if r == nil {
 		return pos, val, fmt.Errorf("no value found")
 	}
 	pos += len(r[0])
-	b, err := strconv.ParseBool(string(r[1]))
+	b, err := parseBool(string(r[1]))
 	if err != nil {
 		return pos, val, err
 	}
 	return pos, reflect.ValueOf(b), nil
 }
 
-var reBool = regexp.MustCompile(`^\s*(1|[tT]|TRUE|[tT]rue|0|[fF]|FALSE|[fF]alse)(?:\s+|$)`)
+func (booltype) keywords() []string {
+	return []string{"true", "false", "on", "off", "yes", "no"}
+}
+
+func (booltype) syntax() string { return "true or false" }
+
+var reBool = regexp.MustCompile(`^\s*(1|[tT]|TRUE|[tT]rue|0|[fF]|FALSE|[fF]alse|(?i:on|off|yes|no))(?:\s+|$)`)
+
+// parseBool is like strconv.ParseBool, but also accepts on/off and
+// yes/no in any letter case.
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "on", "yes":
+		return true, nil
+	case "off", "no":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
 
 type postype struct{}
 
-func (postype) parse(input []byte, first int) (pos int, val reflect.Value, err error) {
+func (postype) parse(input []byte, first int, rc *rangeCheck) (pos int, val reflect.Value, err error) {
 	pos = first
 	r := rePos.FindSubmatch(input[pos:])
 	if r == nil {
@@ -318,44 +1077,94 @@ func (postype) parse(input []byte, first int) (pos int, val reflect.Value, err e
 	}
 	pos += len(r[0])
 	word := string(r[1])
-	switch word {
-	case "top":
-		val = reflect.ValueOf(lipgloss.Top)
-	case "bottom":
-		val = reflect.ValueOf(lipgloss.Bottom)
-	case "center":
-		val = reflect.ValueOf(lipgloss.Center)
-	case "left":
-		val = reflect.ValueOf(lipgloss.Left)
-	case "right":
-		val = reflect.ValueOf(lipgloss.Right)
-	default:
-		p, err := strconv.ParseFloat(word, 64)
+	for _, k := range posKeywords {
+		if k.name == word {
+			return pos, reflect.ValueOf(k.pos), nil
+		}
+	}
+	p, err := strconv.ParseFloat(word, 64)
+	if err != nil {
+		return pos, val, err
+	}
+	if p < 0 || p > 1 {
+		bound := math.Max(0, math.Min(1, p))
+		clamp, err := rc.outOfRange(fmt.Sprintf("position out of range [0,1]: %s", word), fmt.Sprint(bound))
 		if err != nil {
 			return pos, val, err
 		}
-		position := lipgloss.Position(p)
-		val = reflect.ValueOf(position)
+		if clamp {
+			p = bound
+		}
 	}
+	position := lipgloss.Position(p)
+	val = reflect.ValueOf(position)
 	return pos, val, nil
 }
 
-var rePos = regexp.MustCompile(`^\s*(top|bottom|center|left|right|1|1\.0|0\.5|\.5|0|0\.0|\.0)(?:\s+|$)`)
+func (postype) keywords() []string {
+	names := make([]string, len(posKeywords))
+	for i, k := range posKeywords {
+		names[i] = k.name
+	}
+	return names
+}
+
+func (postype) syntax() string {
+	return "a number between 0 and 1, or top, bottom, center, middle, left or right"
+}
+
+// positionKeyword returns the keyword for the position, if any, for
+// the axis of the given property.
+func positionKeyword(prop string, p lipgloss.Position) (string, bool) {
+	switch p {
+	case lipgloss.Center:
+		return "center", true
+	case lipgloss.Top:
+		if prop == "align-vertical" {
+			return "top", true
+		}
+		return "left", true
+	case lipgloss.Bottom:
+		if prop == "align-vertical" {
+			return "bottom", true
+		}
+		return "right", true
+	}
+	return "", false
+}
+
+// posKeywords lists the keywords recognized for positions.
+var posKeywords = []struct {
+	name string
+	pos  lipgloss.Position
+}{
+	{"top", lipgloss.Top},
+	{"bottom", lipgloss.Bottom},
+	{"center", lipgloss.Center},
+	// middle is a common name for the vertical center.
+	{"middle", lipgloss.Center},
+	{"left", lipgloss.Left},
+	{"right", lipgloss.Right},
+}
+
+var rePos = regexp.MustCompile(`^\s*(` + strings.Join(postype{}.keywords(), "|") +
+	`|-?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+))(?:\s+|$)`)
 
 type colortype struct{}
 
-func getColors(rematch [][]byte, cvals []string) error {
+func getColors(rematch [][]byte, cvals []string, rc *rangeCheck) error {
 	for i := 0; i < len(cvals); i++ {
 		val := strings.TrimSpace(string(rematch[i+1]))
-		if !reColor.MatchString(val) {
-			return fmt.Errorf("color not recognized: %q", val)
+		c, err := colorValue(val, rc)
+		if err != nil {
+			return err
 		}
-		cvals[i] = val
+		cvals[i] = c
 	}
 	return nil
 }
 
-func (colortype) parse(input []byte, first int) (pos int, val reflect.Value, err error) {
+func (colortype) parse(input []byte, first int, rc *rangeCheck) (pos int, val reflect.Value, err error) {
 	pos = first
 	// possible syntaxes:
 	// - adaptive(X, Y)
@@ -365,7 +1174,7 @@ func (colortype) parse(input []byte, first int) (pos int, val reflect.Value, err
 	if r := reAdaptive.FindSubmatch(input[pos:]); r != nil {
 		pos += len(r[0])
 		var cvals [2]string
-		if err := getColors(r, cvals[:]); err != nil {
+		if err := getColors(r, cvals[:], rc); err != nil {
 			return pos, val, err
 		}
 		c := lipgloss.AdaptiveColor{Light: cvals[0], Dark: cvals[1]}
@@ -375,7 +1184,7 @@ func (colortype) parse(input []byte, first int) (pos int, val reflect.Value, err
 	if r := reComplete.FindSubmatch(input[pos:]); r != nil {
 		pos += len(r[0])
 		var cvals [3]string
-		if err := getColors(r, cvals[:]); err != nil {
+		if err := getColors(r, cvals[:], rc); err != nil {
 			return pos, val, err
 		}
 		c := lipgloss.CompleteColor{TrueColor: cvals[0], ANSI256: cvals[1], ANSI: cvals[2]}
@@ -385,7 +1194,7 @@ func (colortype) parse(input []byte, first int) (pos int, val reflect.Value, err
 	if r := reCompleteAdaptive.FindSubmatch(input[pos:]); r != nil {
 		pos += len(r[0])
 		var cvals [6]string
-		if err := getColors(r, cvals[:]); err != nil {
+		if err := getColors(r, cvals[:], rc); err != nil {
 			return pos, val, err
 		}
 		c := lipgloss.CompleteAdaptiveColor{
@@ -406,16 +1215,54 @@ func (colortype) parse(input []byte, first int) (pos int, val reflect.Value, err
 	case "none":
 		val = reflect.ValueOf(lipgloss.NoColor{})
 	default:
-		if !reColor.MatchString(word) {
-			return pos, val, fmt.Errorf("color not recognized: %q", word)
+		c, err := colorValue(word, rc)
+		if err != nil {
+			return pos, val, err
 		}
-		val = reflect.ValueOf(lipgloss.Color(word))
+		val = reflect.ValueOf(lipgloss.Color(c))
 	}
 	return pos, val, nil
 }
 
-var reColor = regexp.MustCompile(`^\s*(\d+|#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6})(?:\s+|$)`)
-var reColorOrNone = regexp.MustCompile(`^\s*(none|\d+|#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6})(?:\s+|$)`)
+// colorValue checks the syntax of a single color and translates
+// color names to their hex value.
+func colorValue(word string, rc *rangeCheck) (string, error) {
+	if !reColor.MatchString(word) {
+		return "", fmt.Errorf("color not recognized: %q", word)
+	}
+	if reColorName.MatchString(word) {
+		if c, ok := lookupNamedColor(word); ok {
+			return c, nil
+		}
+		return "", fmt.Errorf("color not recognized: %q", word)
+	}
+	if n, err := strconv.Atoi(word); err == nil && n > 255 {
+		clamp, err := rc.outOfRange(fmt.Sprintf("color index out of range [0,255]: %d", n), "255")
+		if err != nil {
+			return "", err
+		}
+		if clamp {
+			word = "255"
+		}
+	}
+	return word, nil
+}
+
+func (colortype) syntax() string {
+	return "a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))"
+}
+
+func (colortype) keywords() []string {
+	names := []string{"none"}
+	for _, c := range namedColors {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+var reColor = regexp.MustCompile(`^\s*(\d+|#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]+)(?:\s+|$)`)
+var reColorOrNone = regexp.MustCompile(`^\s*(none|\d+|#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]+)(?:\s+|$)`)
+var reColorName = regexp.MustCompile(`^[a-zA-Z]+$`)
 var reAdaptive = regexp.MustCompile(`^\s*(?:adaptive\s*\(([^,]*),([^,]*)\))(?:\s+|$)`)
 
 var reComplete = regexp.MustCompile(`^\s*(?:complete\s*\(([^,]*),([^,]*),([^,]*)\))(?:\s+|$)`)
@@ -429,27 +1276,17 @@ var reCompleteAdaptive = regexp.MustCompile(`^\s*(?:` +
 
 type bordertype struct{}
 
-func (bordertype) parse(input []byte, first int) (pos int, val reflect.Value, err error) {
+func (bordertype) parse(input []byte, first int, _ *rangeCheck) (pos int, val reflect.Value, err error) {
 	pos = first
 	if r := reSpecialBorder.FindSubmatch(input[pos:]); r != nil {
 		pos += len(r[0])
 		word := string(r[1])
-		var b lipgloss.Border
-		switch word {
-		case "rounded":
-			b = lipgloss.RoundedBorder()
-		case "normal":
-			b = lipgloss.NormalBorder()
-		case "thick":
-			b = lipgloss.ThickBorder()
-		case "hidden":
-			b = lipgloss.HiddenBorder()
-		case "double":
-			b = lipgloss.DoubleBorder()
-		default:
-			return pos, val, fmt.Errorf("unrecognized border name: %q", word)
+		for _, b := range borderPresets {
+			if b.name == word {
+				return pos, reflect.ValueOf(b.fn()), nil
+			}
 		}
-		return pos, reflect.ValueOf(b), nil
+		return pos, val, fmt.Errorf("unrecognized border name: %q", word)
 	}
 	r := reBorder.FindSubmatch(input[pos:])
 	if r == nil {
@@ -492,7 +1329,31 @@ var reBorder = regexp.MustCompile(`^\s*(?:border\s*\(\s*(` +
 	reBorderStr + `)\s*,\s*(` +
 	reBorderStr + `)\s*\))(?:\s+|$)`)
 
-var reSpecialBorder = regexp.MustCompile(`^\s*(rounded|normal|thick|hidden|double)(?:\s+|$)`)
+func (bordertype) syntax() string {
+	return `a preset name or border("t","b","l","r","tl","tr","br","bl")`
+}
+
+func (bordertype) keywords() []string {
+	names := make([]string, len(borderPresets))
+	for i, b := range borderPresets {
+		names[i] = b.name
+	}
+	return names
+}
+
+// borderPresets lists the predefined lipgloss borders.
+var borderPresets = []struct {
+	name string
+	fn   func() lipgloss.Border
+}{
+	{"rounded", lipgloss.RoundedBorder},
+	{"normal", lipgloss.NormalBorder},
+	{"thick", lipgloss.ThickBorder},
+	{"hidden", lipgloss.HiddenBorder},
+	{"double", lipgloss.DoubleBorder},
+}
+
+var reSpecialBorder = regexp.MustCompile(`^\s*(` + strings.Join(bordertype{}.keywords(), "|") + `)(?:\s+|$)`)
 
 // camelCase converts hello-world to HelloWorld.
 func camelCase(s string) string {
@@ -527,7 +1388,11 @@ func snakeCase(s string) string {
 	return buf.String()
 }
 
-var propRegistry = map[string]prop{}
+// propRegistry caches the result of discoverProp.
+var propRegistry = struct {
+	sync.RWMutex
+	props map[string]prop
+}{props: map[string]prop{}}
 
 type prop struct {
 	setFn      reflect.Value
@@ -536,20 +1401,31 @@ type prop struct {
 	args       []argtype
 }
 
-func (p prop) assign(dst S, args string) (S, error) {
+func (p prop) assign(dst S, args string, rc *rangeCheck) (S, error) {
 	if args == "unset" {
 		// Special keyword.
-		var noValue reflect.Value
-		if p.unsetFn == noValue {
+		if !p.unsetFn.IsValid() {
 			return dst, fmt.Errorf("no unset method defined")
 		}
 		out := p.unsetFn.Call([]reflect.Value{reflect.ValueOf(dst)})
 		return out[0].Interface().(lipgloss.Style), nil
 	}
 
-	// Read the arguments from the input string.
-	vals := make([]reflect.Value, 0, 1+len(p.args))
-	vals = append(vals, reflect.ValueOf(dst))
+	vals, err := p.parseArgs(args, rc)
+	if err != nil {
+		return dst, err
+	}
+
+	// Finally call the setter.
+	out := p.setFn.Call(append([]reflect.Value{reflect.ValueOf(dst)}, vals...))
+	return out[0].Interface().(lipgloss.Style), nil
+}
+
+// parseArgs reads the arguments of the setter from the input string.
+func (p prop) parseArgs(args string, rc *rangeCheck) ([]reflect.Value, error) {
+	args = expandConstants(args)
+
+	vals := make([]reflect.Value, 0, len(p.args))
 	pos := 0
 	input := []byte(args)
 	for i, arg := range p.args {
@@ -558,13 +1434,13 @@ func (p prop) assign(dst S, args string) (S, error) {
 				// It's ok for a variadic arg list to have zero argument.
 				break
 			}
-			return dst, fmt.Errorf("missing value")
+			return nil, fmt.Errorf("missing value")
 		}
 		var err error
 		var val reflect.Value
-		pos, val, err = arg.parse(input, pos)
+		pos, val, err = arg.parse(input, pos, rc)
 		if err != nil {
-			return dst, err
+			return nil, err
 		}
 		vals = append(vals, val)
 	}
@@ -572,18 +1448,15 @@ func (p prop) assign(dst S, args string) (S, error) {
 		for pos < len(input) {
 			var val reflect.Value
 			var err error
-			pos, val, err = p.args[len(p.args)-1].parse(input, pos)
+			pos, val, err = p.args[len(p.args)-1].parse(input, pos, rc)
 			if err != nil {
-				return dst, err
+				return nil, err
 			}
 			vals = append(vals, val)
 		}
 	}
 	if pos < len(input) {
-		return dst, fmt.Errorf("excess values at end: ...%s", string(input[pos:]))
+		return nil, fmt.Errorf("excess values at end: ...%s", string(input[pos:]))
 	}
-
-	// Finally call the setter.
-	out := p.setFn.Call(vals)
-	return out[0].Interface().(lipgloss.Style), nil
+	return vals, nil
 }
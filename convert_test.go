@@ -2,6 +2,8 @@ package lipglossc
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
@@ -25,38 +27,65 @@ func TestImport(t *testing.T) {
 		{emptyStyle, `unsupported: foo`, ``, `in "unsupported: foo": property not supported: "unsupported"`},
 		{emptyStyle, `render: foo`, ``, `in "render: foo": method "Render" exists but does not return Style`},
 		{emptyStyle.PaddingLeft(11), `padding-left:22`, `padding-left: 22;`, ``},
-		{emptyStyle, `padding-left:aaa`, ``, `in "padding-left:aaa": no value found`},
-		{emptyStyle, `padding-left:9999999999999999999999`, ``, `in "padding-left:9999999999999999999999": strconv.Atoi: parsing "9999999999999999999999": value out of range`},
+		{emptyStyle, `padding-left:aaa`, ``, `in "padding-left:aaa": no value found; padding-left expects an integer`},
+		{emptyStyle, `padding-left:9999999999999999999999`, ``, `in "padding-left:9999999999999999999999": strconv.Atoi: parsing "9999999999999999999999": value out of range; padding-left expects an integer`},
 		{emptyStyle, `bold: true`, `bold: true;`, ``},
-		{emptyStyle, `bold: aa`, ``, `in "bold: aa": no value found`},
-		{emptyStyle, `bold: true extra`, ``, `in "bold: true extra": excess values at end: ...extra`},
+		{emptyStyle, `bold: aa`, ``, `in "bold: aa": no value found; bold expects true or false`},
+		{emptyStyle, `bold: on`, `bold: true;`, ``},
+		{emptyStyle, `bold: Yes`, `bold: true;`, ``},
+		{emptyStyle.Bold(true), `bold: OFF`, ``, ``},
+		{emptyStyle.Bold(true), `bold: no`, ``, ``},
+		{emptyStyle, `bold: true extra`, ``, `in "bold: true extra": excess values at end: ...extra; bold expects true or false`},
 		{emptyStyle.Foreground(lipgloss.Color("11")), `foreground: unset`, ``, ``},
 		{emptyStyle, `align-horizontal: left`, ``, ``},
 		{emptyStyle, `align: left`, ``, ``},
-		{emptyStyle, `align: xx`, ``, `in "align: xx": no value found`},
+		{emptyStyle, `align: xx`, ``, `in "align: xx": no value found; align expects a number between 0 and 1, or top, bottom, center, middle, left or right (repeatable)`},
 		{emptyStyle, `align: center`, `align-horizontal: 0.5;`, ``},
 		{emptyStyle, `align: right`, `align-horizontal: 1;`, ``},
 		{emptyStyle, `align: 1.0`, `align-horizontal: 1;`, ``},
+		{emptyStyle, `align: 0.25`, `align-horizontal: 0.25;`, ``},
+		{emptyStyle, `align: 1.5`, ``, `in "align: 1.5": position out of range [0,1]: 1.5`},
+		{emptyStyle, `align-vertical: -0.5`, ``, `in "align-vertical: -0.5": position out of range [0,1]: -0.5`},
+		{emptyStyle, `padding-left: -1`, ``, `in "padding-left: -1": negative value not allowed: -1`},
+		{emptyStyle, `foreground: 255`, `foreground: 255;`, ``},
+		{emptyStyle, `foreground: 256`, ``, `in "foreground: 256": color index out of range [0,255]: 256`},
+		{emptyStyle, `background: adaptive(1,300)`, ``, `in "background: adaptive(1,300)": color index out of range [0,255]: 300`},
 		{emptyStyle, `align-horizontal: right`, `align-horizontal: 1;`, ``},
 		{emptyStyle, `align-vertical: top`, ``, ``},
 		{emptyStyle, `align-vertical: center`, `align-vertical: 0.5;`, ``},
 		{emptyStyle, `align-vertical: bottom`, `align-vertical: 1;`, ``},
 		{emptyStyle, `align: bottom right`, `align-horizontal: 1;
 align-vertical: 1;`, ``},
+		{emptyStyle, `align: center middle`, `align-horizontal: 0.5;
+align-vertical: 0.5;`, ``},
+		{emptyStyle, `align-vertical: middle`, `align-vertical: 0.5;`, ``},
 		{emptyStyle.Foreground(lipgloss.Color("11")), `foreground: none`, ``, ``},
 		{emptyStyle.Foreground(lipgloss.Color("11")), `clear`, ``, ``},
 		{emptyStyle.Foreground(lipgloss.Color("11")), `background: 12; clear`, ``, ``},
+		{emptyStyle, `bold: true; foreground: 11; border-top-background: 12; width: 10; clear-colors`, `bold: true;
+width: 10;`, ``},
+		{emptyStyle, `bold: true; border: rounded; border-top-foreground: 12; clear-border`, `bold: true;
+border-top-foreground: 12;`, ``},
+		{emptyStyle, `bold: true; padding: 1; margin: 2; width: 10; foreground: 11; clear-layout`, `bold: true;
+foreground: 11;`, ``},
+		{emptyStyle, `bold: true; italic: true; foreground: 11; clear-text`, `foreground: 11;`, ``},
+		{emptyStyle, `clear-foo`, ``, `invalid syntax: "clear-foo"`},
+		{emptyStyle, `border-top-background: 12; border-top-background: unset`, ``, ``},
 		{emptyStyle, `foreground: 11`, `foreground: 11;`, ``},
 		{emptyStyle, `foreground: #123`, `foreground: #123;`, ``},
 		{emptyStyle, `foreground: #123456`, `foreground: #123456;`, ``},
-		{emptyStyle, `foreground: #axxa`, ``, `in "foreground: #axxa": color not recognized`},
+		{emptyStyle, `foreground: #axxa`, ``, `in "foreground: #axxa": color not recognized; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
 		{emptyStyle, `foreground: adaptive(1,2)`, `foreground: adaptive(1,2);`, ``},
 		{emptyStyle, `foreground: complete(#111, 22, 3)`, `foreground: complete(#111,22,3);`, ``},
 		{emptyStyle, `foreground: adaptive(complete(#111, 22, 3), complete(#444,55,6))`, `foreground: adaptive(complete(#111,22,3),complete(#444,55,6));`, ``},
-		{emptyStyle, `foreground: adaptive(a,b)`, ``, `in "foreground: adaptive(a,b)": color not recognized: "a"`},
-		{emptyStyle, `foreground: adaptive(1,b)`, ``, `in "foreground: adaptive(1,b)": color not recognized: "b"`},
-		{emptyStyle, `foreground: complete(1,1,b)`, ``, `in "foreground: complete(1,1,b)": color not recognized: "b"`},
-		{emptyStyle, `foreground: adaptive(complete(1,1,b),complete(2,2,b))`, ``, `in "foreground: adaptive(complete(1,1,b),complete(2,2,b))": color not recognized: "b"`},
+		{emptyStyle, `foreground: adaptive(a,b)`, ``, `in "foreground: adaptive(a,b)": color not recognized: "a"; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{emptyStyle, `foreground: red`, `foreground: #ff0000;`, ``},
+		{emptyStyle, `foreground: DarkBlue`, `foreground: #00008b;`, ``},
+		{emptyStyle, `foreground: adaptive(white, black)`, `foreground: adaptive(#ffffff,#000000);`, ``},
+		{emptyStyle, `foreground: redd`, ``, `in "foreground: redd": color not recognized: "redd"; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{emptyStyle, `foreground: adaptive(1,b)`, ``, `in "foreground: adaptive(1,b)": color not recognized: "b"; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{emptyStyle, `foreground: complete(1,1,b)`, ``, `in "foreground: complete(1,1,b)": color not recognized: "b"; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{emptyStyle, `foreground: adaptive(complete(1,1,b),complete(2,2,b))`, ``, `in "foreground: adaptive(complete(1,1,b),complete(2,2,b))": color not recognized: "b"; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
 		{emptyStyle, `margin: 10`, `margin-bottom: 10;
 margin-left: 10;
 margin-right: 10;
@@ -70,7 +99,7 @@ margin-left: 40;
 margin-right: 20;
 margin-top: 10;`, ``},
 		{emptyStyle, `border-style: border("","","","","","","","")`, ``, ``},
-		{emptyStyle, `border-style: xx`, ``, `in "border-style: xx": no valid border value found`},
+		{emptyStyle, `border-style: xx`, ``, `in "border-style: xx": no valid border value found; border-style expects a preset name or border("t","b","l","r","tl","tr","br","bl")`},
 		{emptyStyle,
 			`border-style: border("a","b","c","d","e","f","g","h")`,
 			`border-style: border("a","b","c","d","e","f","g","h");`, ``},
@@ -93,7 +122,7 @@ border-top: true;`,
 		{emptyStyle,
 			`border: border("a","b","c","d","e","f","g","h") true xx`,
 			``,
-			`in "border: border(\"a\",\"b\",\"c\",\"d\",\"e\",\"f\",\"g\",\"h\") true xx": no value found`},
+			`in "border: border(\"a\",\"b\",\"c\",\"d\",\"e\",\"f\",\"g\",\"h\") true xx": no value found; border expects a preset name or border("t","b","l","r","tl","tr","br","bl"), then true or false (repeatable)`},
 		{emptyStyle,
 			`border-style: rounded`,
 			`border-style: border("─","─","│","│","╭","╮","╯","╰");`, ``},
@@ -129,20 +158,7 @@ border-top: true;`,
 			}
 			t.Logf("%# v", pretty.Formatter(result))
 			actual := Export(result, WithSeparator("\n"))
-			if actual != tc.out {
-				expectedLines := difflib.SplitLines(tc.out)
-				actualLines := difflib.SplitLines(actual)
-				diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
-					Context: 5,
-					A:       expectedLines,
-					B:       actualLines,
-				})
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				t.Fatalf("mismatch:\n%s\ndiff:\n%s", actual, diff)
-			}
+			checkOutput(t, tc.out, actual)
 		})
 	}
 }
@@ -170,20 +186,7 @@ padding-left: 4;
 padding-top: 2;
 width: 22;`
 		result := Export(style, WithSeparator("\n"))
-		if result != exp {
-			expectedLines := difflib.SplitLines(exp)
-			actualLines := difflib.SplitLines(result)
-			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
-				Context: 5,
-				A:       expectedLines,
-				B:       actualLines,
-			})
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			t.Errorf("mismatch:\n%s\ndiff:\n%s", result, diff)
-		}
+		checkOutput(t, exp, result)
 	})
 
 	t.Run("full", func(t *testing.T) {
@@ -228,21 +231,89 @@ underline: false;
 underline-spaces: false;
 width: 22;`
 		result := Export(style, WithExportDefaults(), WithSeparator("\n"))
-		if result != exp {
-			expectedLines := difflib.SplitLines(exp)
-			actualLines := difflib.SplitLines(result)
-			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
-				Context: 5,
-				A:       expectedLines,
-				B:       actualLines,
-			})
-			if err != nil {
-				t.Fatal(err)
-			}
+		checkOutput(t, exp, result)
+	})
+
+	t.Run("hex-case", func(t *testing.T) {
+		s := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FaFaFa")).
+			Background(lipgloss.AdaptiveColor{Light: "#7D56F4", Dark: "#abc"}).
+			BorderTopForeground(lipgloss.CompleteColor{TrueColor: "#aBcDeF", ANSI256: "12", ANSI: "3"})
+		checkOutput(t, `background: adaptive(#7d56f4,#abc); border-top-foreground: complete(#abcdef,12,3); foreground: #fafafa;`,
+			Export(s, WithHexCase(HexLower)))
+		checkOutput(t, `background: adaptive(#7D56F4,#ABC); border-top-foreground: complete(#ABCDEF,12,3); foreground: #FAFAFA;`,
+			Export(s, WithHexCase(HexUpper)))
+		checkOutput(t, `background: adaptive(#7D56F4,#abc); border-top-foreground: complete(#aBcDeF,12,3); foreground: #FaFaFa;`,
+			Export(s))
+	})
+
+	t.Run("shell", func(t *testing.T) {
+		s := lipgloss.NewStyle().
+			Bold(true).
+			BorderStyle(lipgloss.Border{Top: "'", Bottom: "\n"})
+		checkOutput(t, `'bold: true; border-style: border("'\''","\n","","","","","","");'`,
+			Export(s, WithShellQuoting(), WithSeparator("\n")))
+	})
+
+	t.Run("named-colors", func(t *testing.T) {
+		s := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF0001")).
+			Background(lipgloss.AdaptiveColor{Light: "#fff", Dark: "#123456"}).
+			BorderTopForeground(lipgloss.Color("12"))
+		checkOutput(t, `background: adaptive(white,#123456); border-top-foreground: 12; foreground: red;`,
+			Export(s, WithNamedColors()))
+	})
+
+	t.Run("position-keywords", func(t *testing.T) {
+		s := lipgloss.NewStyle().Align(lipgloss.Right).AlignVertical(lipgloss.Bottom)
+		checkOutput(t, `align-horizontal: right; align-vertical: bottom;`, Export(s, WithPositionKeywords()))
+		s = lipgloss.NewStyle().Align(lipgloss.Center).AlignVertical(0.25)
+		checkOutput(t, `align-horizontal: center; align-vertical: 0.25;`, Export(s, WithPositionKeywords()))
+		if res := Export(lipgloss.NewStyle(), WithPositionKeywords(), WithExportDefaults()); !strings.Contains(res, "align-horizontal: left; align-vertical: top;") {
+			t.Errorf("unexpected output: %s", res)
+		}
+	})
 
-			t.Errorf("mismatch:\n%s\ndiff:\n%s", result, diff)
+	t.Run("bool-words", func(t *testing.T) {
+		s := lipgloss.NewStyle().Bold(true)
+		checkOutput(t, `bold: on;`, Export(s, WithBoolWords(BoolOnOff)))
+		checkOutput(t, `bold: yes;`, Export(s, WithBoolWords(BoolYesNo)))
+		if res := Export(s, WithBoolWords(BoolOnOff), WithExportDefaults()); !strings.Contains(res, "blink: off;") {
+			t.Errorf("expected blink: off, got %s", res)
 		}
 	})
+
+	t.Run("resolved-background", func(t *testing.T) {
+		s := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#fafafa")).
+			Background(lipgloss.AdaptiveColor{Light: "#fff", Dark: "#123456"}).
+			BorderTopForeground(lipgloss.CompleteAdaptiveColor{
+				Light: lipgloss.CompleteColor{TrueColor: "#111", ANSI256: "233", ANSI: "0"},
+				Dark:  lipgloss.CompleteColor{TrueColor: "#eee", ANSI256: "255", ANSI: "15"},
+			})
+		checkOutput(t, `background: #123456; border-top-foreground: complete(#eee,255,15); foreground: #fafafa;`,
+			Export(s, WithResolvedBackground(true)))
+		checkOutput(t, `background: #fff; border-top-foreground: complete(#111,233,0); foreground: #fafafa;`,
+			Export(s, WithResolvedBackground(false)))
+	})
+}
+
+func checkOutput(t *testing.T, exp, actual string) {
+	t.Helper()
+	if actual != exp {
+		expectedLines := difflib.SplitLines(exp)
+		actualLines := difflib.SplitLines(actual)
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			Context: 5,
+			A:       expectedLines,
+			B:       actualLines,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Fatalf("mismatch:\n%s\ndiff:\n%s", actual, diff)
+	}
 }
 
 func TestCamelCase(t *testing.T) {
@@ -282,3 +353,193 @@ func TestSnakeCase(t *testing.T) {
 		})
 	}
 }
+
+func TestImportSeparator(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), "bold: true\nborder-style: border(\";\",\"b\",\"c\",\"d\",\"e\",\"f\",\"g\",\"h\")\n\nwidth: 10\n",
+		WithImportSeparator("\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; border-style: border(";","b","c","d","e","f","g","h"); width: 10;`, Export(s))
+
+	s, err = Import(lipgloss.NewStyle(), "bold: true | italic: true", WithImportSeparator("|"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; italic: true;`, Export(s))
+
+	if err := Validate("bold: true\nitalic: maybe", WithImportSeparator("\n")); err == nil || err.Error() != `in "italic: maybe": no value found; italic expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImportImportant(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `foreground: red !important; padding: 1 !important;
+foreground: 12; padding-left: 3; clear-colors; bold: true !important; clear`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; foreground: #ff0000; padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`, Export(s))
+
+	// A later important directive overrides an earlier one.
+	s, err = Import(lipgloss.NewStyle(), `bold: true !important; bold: false !important; bold: true`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, ``, Export(s))
+}
+
+func TestImportDirectiveCallback(t *testing.T) {
+	var seen []string
+	cb := func(prop, value string, s S) error {
+		if prop == "italic" {
+			return fmt.Errorf("stop")
+		}
+		seen = append(seen, fmt.Sprintf("%s=%s => %s", prop, value, Export(s)))
+		return nil
+	}
+	s, err := Import(lipgloss.NewStyle(), `bold: true; padding: 1 !important; clear-text; italic: true; faint: true`,
+		WithDirectiveCallback(cb))
+	if err == nil || err.Error() != `in "italic: true": stop` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	exp := []string{
+		`bold=true => bold: true;`,
+		`padding=1 => bold: true; padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`,
+		`clear-text= => padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`,
+	}
+	if fmt.Sprint(seen) != fmt.Sprint(exp) {
+		t.Errorf("expected:\n%q\ngot:\n%q", exp, seen)
+	}
+	// The directive rejected by the callback is not applied.
+	checkOutput(t, `padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`, Export(s))
+}
+
+func TestExportMap(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `bold: true; foreground: #ABC; padding: 0 2; border: rounded true false`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]string{
+		"bold":          "true",
+		"border-bottom": "true",
+		"border-style":  `border("─","─","│","│","╭","╮","╯","╰")`,
+		"border-top":    "true",
+		"foreground":    "#ABC",
+		"padding-left":  "2",
+		"padding-right": "2",
+	}
+	if m := ExportMap(s); fmt.Sprint(m) != fmt.Sprint(exp) {
+		t.Errorf("expected %v, got %v", exp, m)
+	}
+
+	m := ExportMap(s, WithHexCase(HexLower), WithExportDefaults())
+	if m["foreground"] != "#abc" || m["italic"] != "false" {
+		t.Errorf("unexpected map: %v", m)
+	}
+}
+
+func TestCanonicalOrder(t *testing.T) {
+	// Every property of lipgloss.Style has a place in the canonical
+	// order, so that the order of the output of Export does not depend
+	// on reflect.
+	if len(styleGetters) != len(canonicalProps) {
+		t.Errorf("expected %d properties, got %d", len(canonicalProps), len(styleGetters))
+	}
+	for i, g := range styleGetters {
+		if i < len(canonicalProps) && g.name != canonicalProps[i] {
+			t.Errorf("%d: expected %q, got %q", i, canonicalProps[i], g.name)
+		}
+	}
+
+	getters := []getter{{name: "zebra"}, {name: "width"}, {name: "aardvark"}, {name: "bold"}}
+	sortGetters(getters)
+	var names []string
+	for _, g := range getters {
+		names = append(names, g.name)
+	}
+	if fmt.Sprint(names) != "[bold width aardvark zebra]" {
+		t.Errorf("unexpected order: %v", names)
+	}
+
+	// The output is byte-stable.
+	const exp = `align-horizontal: 0.5; align-vertical: 1; background: 0; blink: true; bold: true; ` +
+		`border-bottom: true; border-bottom-background: 2; border-bottom-foreground: 1; ` +
+		`border-left: true; border-left-background: 2; border-left-foreground: 1; ` +
+		`border-right: true; border-right-background: 2; border-right-foreground: 1; ` +
+		`border-style: border("─","─","│","│","╭","╮","╯","╰"); ` +
+		`border-top: true; border-top-background: 2; border-top-foreground: 1; ` +
+		`faint: true; foreground: 15; height: 3; inline: true; italic: true; ` +
+		`margin-bottom: 1; margin-left: 1; margin-right: 1; margin-top: 1; max-height: 10; max-width: 20; ` +
+		`padding-bottom: 2; padding-left: 2; padding-right: 2; padding-top: 2; reverse: true; ` +
+		`strikethrough: true; strikethrough-spaces: true; underline: true; underline-spaces: true; width: 12;`
+	for i := 0; i < 10; i++ {
+		s, err := Import(lipgloss.NewStyle(), exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkOutput(t, exp, Export(s))
+	}
+}
+
+func TestExportConcurrent(t *testing.T) {
+	// Export only reads the style, so that multiple goroutines can
+	// export shared styles and sheets. Run with -race to check.
+	s, err := Import(lipgloss.NewStyle(), `bold: true; padding: 1 2; border: rounded;
+foreground: adaptive(#000000,#ffffff); background: 236; width: 20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ss StyleSheet
+	ss.Set("title", s)
+	ss, err = ImportSheet(ss, `@palette { accent: 236; } $pad: 1; footer { faint: true; foreground: accent; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	optSets := [][]ExportOption{
+		nil,
+		{WithSeparator("\n")},
+		{WithResolvedBackground(true), WithHexCase(HexUpper)},
+		{WithVariables(map[string]string{"accent": "236"}), WithShellQuoting()},
+	}
+	var exp []string
+	for _, opts := range optSets {
+		exp = append(exp, Export(s, opts...)+"\n"+ss.Export(opts...))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 64)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				k := j % len(optSets)
+				if res := Export(s, optSets[k]...) + "\n" + ss.Export(optSets[k]...); res != exp[k] {
+					errs <- res
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for res := range errs {
+		t.Errorf("unexpected concurrent output: %q", res)
+	}
+	// The style was not modified.
+	checkOutput(t, exp[0], Export(s)+"\n"+ss.Export())
+}
+
+func BenchmarkExportParallel(b *testing.B) {
+	s, err := Import(lipgloss.NewStyle(), `bold: true; padding: 1 2; border: rounded;
+foreground: adaptive(#000000,#ffffff); background: 236; width: 20`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = Export(s)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package lipglossc
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Converter imports styles for a specific terminal, which may differ
+// from the terminal of the current process. This is useful in SSH
+// servers (e.g. built with charmbracelet/wish) where each client
+// session has its own color profile and background.
+//
+// lipgloss chooses between the variants of adaptive and complete
+// colors, and downsamples colors, using the color profile of the
+// process. A Converter instead resolves the colors during Import,
+// for its own profile and background. The resulting styles are meant
+// to be rendered with the process-wide profile set to
+// termenv.TrueColor, so that lipgloss does not alter them further.
+type Converter struct {
+	profile termenv.Profile
+	dark    bool
+	// defaults is applied before the input of every Import.
+	defaults string
+}
+
+// ConverterOption configures a Converter.
+type ConverterOption func(*Converter)
+
+// WithColorProfile sets the color profile of the target terminal.
+// The default is termenv.TrueColor.
+func WithColorProfile(p termenv.Profile) ConverterOption {
+	return func(c *Converter) {
+		c.profile = p
+	}
+}
+
+// WithDarkBackground indicates whether the target terminal has a dark
+// background. The default is true.
+func WithDarkBackground(dark bool) ConverterOption {
+	return func(c *Converter) {
+		c.dark = dark
+	}
+}
+
+// NewConverter creates a Converter.
+func NewConverter(opts ...ConverterOption) *Converter {
+	c := &Converter{profile: termenv.TrueColor, dark: true}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// NewSessionConverter creates a Converter for a remote terminal
+// session, given the terminal type and the environment of the
+// session, e.g. from ssh.Session.Pty() and ssh.Session.Environ() with
+// charmbracelet/wish. The background of a remote terminal cannot be
+// detected, and must be provided by the caller.
+func NewSessionConverter(term string, environ []string, hasDarkBackground bool) *Converter {
+	return NewConverter(
+		WithColorProfile(SessionProfile(term, environ)),
+		WithDarkBackground(hasDarkBackground),
+	)
+}
+
+// SessionProfile determines the color profile of a terminal given its
+// type and environment, the same way termenv does for the terminal of
+// the current process.
+func SessionProfile(term string, environ []string) termenv.Profile {
+	var colorTerm, termProgram string
+	for _, kv := range environ {
+		switch {
+		case strings.HasPrefix(kv, "COLORTERM="):
+			colorTerm = strings.TrimPrefix(kv, "COLORTERM=")
+		case strings.HasPrefix(kv, "TERM_PROGRAM="):
+			termProgram = strings.TrimPrefix(kv, "TERM_PROGRAM=")
+		case strings.HasPrefix(kv, "TERM=") && term == "":
+			term = strings.TrimPrefix(kv, "TERM=")
+		}
+	}
+
+	switch strings.ToLower(colorTerm) {
+	case "24bit", "truecolor":
+		if strings.HasPrefix(term, "screen") && termProgram != "tmux" {
+			// tmux supports TrueColor, screen only ANSI256.
+			return termenv.ANSI256
+		}
+		return termenv.TrueColor
+	case "yes", "true":
+		return termenv.ANSI256
+	}
+
+	switch {
+	case term == "xterm-kitty":
+		return termenv.TrueColor
+	case term == "linux":
+		return termenv.ANSI
+	case strings.Contains(term, "256color"):
+		return termenv.ANSI256
+	case strings.Contains(term, "color"), strings.Contains(term, "ansi"):
+		return termenv.ANSI
+	}
+	return termenv.Ascii
+}
+
+// SetDefaults sets directives that Import applies before every input,
+// e.g. an application-wide baseline such as "color-whitespace: true".
+// The input can override them, except those marked !important. An
+// invalid specification is rejected and leaves the defaults unchanged.
+//
+// SetDefaults must not be called concurrently with Import.
+func (c *Converter) SetDefaults(spec string) error {
+	if err := Validate(spec); err != nil {
+		return err
+	}
+	c.defaults = spec
+	return nil
+}
+
+// Import is like the Import function, but applies the converter's
+// defaults first and resolves the colors for the converter's terminal.
+// The @dark, @light and @profile blocks are selected by the converter's
+// background mode and color profile.
+func (c *Converter) Import(dst S, input string) (S, error) {
+	opts := []ImportOption{
+		withImportant(map[string]bool{}),
+		WithBackgroundMode(c.dark),
+		WithImportProfile(c.profile),
+	}
+	dst, err := Import(dst, c.defaults, opts...)
+	if err == nil {
+		dst, err = Import(dst, input, opts...)
+	}
+	return c.resolveColors(dst), err
+}
+
+// resolveColors replaces the colors in the style by their variant for
+// the converter's terminal.
+func (c *Converter) resolveColors(s S) S {
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		if val.Type().Name() != "TerminalColor" || isDefault(val) {
+			continue
+		}
+		rc := c.resolveColor(val.Interface().(lipgloss.TerminalColor))
+		s = g.setFn.Call([]reflect.Value{reflect.ValueOf(s), reflect.ValueOf(rc)})[0].Interface().(S)
+	}
+	return s
+}
+
+func (c *Converter) resolveColor(tc lipgloss.TerminalColor) lipgloss.TerminalColor {
+	switch tc := tc.(type) {
+	case lipgloss.Color:
+		return c.convert(string(tc))
+	case lipgloss.AdaptiveColor:
+		if c.dark {
+			return c.convert(tc.Dark)
+		}
+		return c.convert(tc.Light)
+	case lipgloss.CompleteColor:
+		return c.complete(tc)
+	case lipgloss.CompleteAdaptiveColor:
+		if c.dark {
+			return c.complete(tc.Dark)
+		}
+		return c.complete(tc.Light)
+	}
+	return tc
+}
+
+// complete chooses the variant of a CompleteColor for the profile.
+func (c *Converter) complete(cc lipgloss.CompleteColor) lipgloss.TerminalColor {
+	switch c.profile {
+	case termenv.TrueColor:
+		return c.convert(cc.TrueColor)
+	case termenv.ANSI256:
+		return c.convert(cc.ANSI256)
+	case termenv.ANSI:
+		return c.convert(cc.ANSI)
+	}
+	return lipgloss.NoColor{}
+}
+
+// convert downsamples a color to the profile.
+func (c *Converter) convert(s string) lipgloss.TerminalColor {
+	switch tc := c.profile.Color(s).(type) {
+	case termenv.RGBColor:
+		return lipgloss.Color(string(tc))
+	case termenv.ANSI256Color:
+		return lipgloss.Color(strconv.Itoa(int(tc)))
+	case termenv.ANSIColor:
+		return lipgloss.Color(strconv.Itoa(int(tc)))
+	}
+	return lipgloss.NoColor{}
+}
@@ -0,0 +1,84 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestConverter(t *testing.T) {
+	const spec = `foreground: adaptive(#ffffff, #ff0000);
+background: complete(#0000ff, 21, 4);
+border-top-foreground: adaptive(complete(#111111, 233, 0), complete(#eeeeee, 255, 15));
+margin-left: 2`
+	td := []struct {
+		profile termenv.Profile
+		dark    bool
+		exp     string
+	}{
+		{termenv.TrueColor, true, `background: #0000ff; border-top-foreground: #eeeeee; foreground: #ff0000; margin-left: 2;`},
+		{termenv.TrueColor, false, `background: #0000ff; border-top-foreground: #111111; foreground: #ffffff; margin-left: 2;`},
+		{termenv.ANSI256, true, `background: 21; border-top-foreground: 255; foreground: 196; margin-left: 2;`},
+		{termenv.ANSI, false, `background: 4; border-top-foreground: 0; foreground: 15; margin-left: 2;`},
+		{termenv.Ascii, true, `margin-left: 2;`},
+	}
+	for _, tc := range td {
+		c := NewConverter(WithColorProfile(tc.profile), WithDarkBackground(tc.dark))
+		s, err := c.Import(lipgloss.NewStyle(), spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkOutput(t, tc.exp, Export(s))
+	}
+}
+
+func TestConverterDefaults(t *testing.T) {
+	c := NewConverter()
+	if err := c.SetDefaults(`color-whitespace: false; foreground: #fff; bold: true !important`); err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.Import(lipgloss.NewStyle(), `foreground: #f00; bold: false`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; foreground: #f00;`, Export(s))
+	if s.GetColorWhitespace() {
+		t.Errorf("expected color-whitespace to be disabled")
+	}
+
+	if err := c.SetDefaults(`bold: maybe`); err == nil || err.Error() != `in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	s, err = c.Import(lipgloss.NewStyle(), ``)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; foreground: #fff;`, Export(s))
+}
+
+func TestSessionProfile(t *testing.T) {
+	td := []struct {
+		term    string
+		environ []string
+		exp     termenv.Profile
+	}{
+		{"xterm-256color", nil, termenv.ANSI256},
+		{"xterm-256color", []string{"COLORTERM=truecolor"}, termenv.TrueColor},
+		{"screen", []string{"COLORTERM=24bit"}, termenv.ANSI256},
+		{"screen", []string{"COLORTERM=24bit", "TERM_PROGRAM=tmux"}, termenv.TrueColor},
+		{"", []string{"TERM=xterm-color"}, termenv.ANSI},
+		{"linux", nil, termenv.ANSI},
+		{"xterm-kitty", nil, termenv.TrueColor},
+		{"dumb", nil, termenv.Ascii},
+	}
+	for _, tc := range td {
+		if p := SessionProfile(tc.term, tc.environ); p != tc.exp {
+			t.Errorf("%s %v: expected %v, got %v", tc.term, tc.environ, tc.exp, p)
+		}
+	}
+	c := NewSessionConverter("xterm-256color", nil, false)
+	if c.profile != termenv.ANSI256 || c.dark {
+		t.Errorf("unexpected converter: %+v", c)
+	}
+}
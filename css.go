@@ -0,0 +1,86 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ExportCSSVariables emits CSS custom properties for the colors and
+// sizes of the given named styles, one declaration per line, e.g.
+// "--app-title-foreground: #fafafa;" for the foreground of the style
+// "title" with prefix "app". This lets a web UI use the same theme
+// values as the terminal application.
+//
+// Colors are emitted in hex form; ANSI colors are converted to their
+// usual RGB values, and complete colors use their true color variant.
+// Adaptive colors produce two variables, suffixed with -light and
+// -dark. Sizes are emitted as unitless numbers of terminal cells, to
+// be scaled with e.g. calc(var(--app-title-width) * 1ch).
+//
+// The characters that are not valid in CSS identifiers are replaced by
+// "-" in the variable names, e.g. "--app-button-focused-foreground" for
+// the style "button:focused", and "--app-help-text-foreground" for the
+// style "help.text".
+//
+// The declarations can be placed in any CSS rule, e.g. ":root { ... }".
+func ExportCSSVariables(prefix string, styles map[string]S) string {
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if prefix != "" {
+		prefix = cssIdent(prefix) + "-"
+	}
+	var buf strings.Builder
+	for _, name := range names {
+		v := reflect.ValueOf(styles[name])
+		for _, g := range styleGetters {
+			val := g.getFn.Call([]reflect.Value{v})[0]
+			if isDefault(val) {
+				continue
+			}
+			varName := "--" + prefix + cssIdent(name) + "-" + g.name
+			switch x := val.Interface().(type) {
+			case int:
+				fmt.Fprintf(&buf, "%s: %d;\n", varName, x)
+			case lipgloss.TerminalColor:
+				if c, adaptive := colorBranch(x, false); adaptive {
+					writeCSSColor(&buf, varName+"-light", c)
+					c, _ = colorBranch(x, true)
+					writeCSSColor(&buf, varName+"-dark", c)
+				} else {
+					writeCSSColor(&buf, varName, c)
+				}
+			}
+		}
+	}
+	return buf.String()
+}
+
+// writeCSSColor emits a color declaration, if the color is valid.
+func writeCSSColor(buf *strings.Builder, varName, c string) {
+	r, g, b, ok := colorRGB(c)
+	if !ok {
+		return
+	}
+	fmt.Fprintf(buf, "%s: #%02x%02x%02x;\n", varName, r, g, b)
+}
+
+// cssIdent replaces the characters that are not valid in CSS
+// identifiers by "-". The characters outside of ASCII are valid.
+func cssIdent(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r >= 0x80:
+			return r
+		}
+		return '-'
+	}, name)
+}
@@ -0,0 +1,38 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestExportCSSVariables(t *testing.T) {
+	title, err := Import(lipgloss.NewStyle(), `bold: true; foreground: #FAFAFA; background: 4; width: 20; padding-left: 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	help, err := Import(lipgloss.NewStyle(), `foreground: adaptive(#333,#ccc); border-top-background: complete(#123456,21,4)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	styles := map[string]S{"title": title, "help": help}
+	checkOutput(t, `--app-help-border-top-background: #123456;
+--app-help-foreground-light: #333333;
+--app-help-foreground-dark: #cccccc;
+--app-title-background: #000080;
+--app-title-foreground: #fafafa;
+--app-title-padding-left: 2;
+--app-title-width: 20;
+`, ExportCSSVariables("app", styles))
+
+	checkOutput(t, `--title-width: 20;
+`, ExportCSSVariables("", map[string]S{"title": lipgloss.NewStyle().Width(20)}))
+
+	// The names of dotted styles and variants are valid identifiers.
+	checkOutput(t, `--my-app-button-focused-width: 20;
+--my-app-help-text-width: 10;
+`, ExportCSSVariables("my.app", map[string]S{
+		"help.text":      lipgloss.NewStyle().Width(10),
+		"button:focused": lipgloss.NewStyle().Width(20),
+	}))
+}
@@ -0,0 +1,124 @@
+// Package demo produces animated previews of a set of styles, either
+// as a stream of ANSI frames or as an asciinema cast file, for
+// publishing previews of a theme.
+//
+// Each frame shows the name of a style and the sample text rendered
+// with it. The styles are presented in alphabetical order.
+package demo
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// clearScreen clears the terminal and moves the cursor home.
+const clearScreen = "\x1b[2J\x1b[H"
+
+// frame is a rendered style.
+type frame struct {
+	name string
+	text string
+}
+
+// renderFrames renders the sample with each style. To capture the
+// colors, the lipgloss color profile is temporarily set to
+// termenv.TrueColor.
+func renderFrames(styles map[string]lipgloss.Style, sample string) []frame {
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	frames := make([]frame, len(names))
+	for i, name := range names {
+		frames[i] = frame{name: name, text: styles[name].Render(sample)}
+	}
+	return frames
+}
+
+// output returns the terminal output for the frame.
+func (f frame) output() string {
+	return clearScreen + f.name + "\r\n" + crlf(f.text) + "\r\n"
+}
+
+// crlf converts newlines to the CR-LF sequences expected by terminals.
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+// WriteANSI writes the frames to w one after the other, waiting for
+// delay between frames, e.g. to play the demo in a terminal.
+//
+// The rendering uses 24-bit colors: the lipgloss color profile is
+// temporarily set to termenv.TrueColor, which affects the other
+// goroutines that render styles at the same time. The same applies to
+// WriteCast.
+func WriteANSI(w io.Writer, styles map[string]lipgloss.Style, sample string, delay time.Duration) error {
+	for i, f := range renderFrames(styles, sample) {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		if _, err := io.WriteString(w, f.output()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// castHeader is the header of an asciinema cast file (version 2).
+type castHeader struct {
+	Version int    `json:"version"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Title   string `json:"title,omitempty"`
+}
+
+// WriteCast writes an asciinema cast file (version 2) to w, showing
+// each style for the given duration. The title is recorded in the cast
+// header.
+func WriteCast(w io.Writer, styles map[string]lipgloss.Style, sample, title string, each time.Duration) error {
+	frames := renderFrames(styles, sample)
+	h := castHeader{Version: 2, Title: title}
+	for _, f := range frames {
+		if width := lipgloss.Width(f.text); width > h.Width {
+			h.Width = width
+		}
+		if width := lipgloss.Width(f.name); width > h.Width {
+			h.Width = width
+		}
+		// The style name takes one line, and the cursor ends on the
+		// line after the text.
+		if height := lipgloss.Height(f.text) + 2; height > h.Height {
+			h.Height = height
+		}
+	}
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(h); err != nil {
+		return err
+	}
+	for i, f := range frames {
+		ts := (time.Duration(i) * each).Seconds()
+		if err := enc.Encode([]interface{}{ts, "o", f.output()}); err != nil {
+			return err
+		}
+	}
+	if len(frames) > 0 {
+		// A final empty event keeps the last frame on screen.
+		ts := (time.Duration(len(frames)) * each).Seconds()
+		if err := enc.Encode([]interface{}{ts, "o", ""}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package demo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var styles = map[string]lipgloss.Style{
+	"title":  lipgloss.NewStyle().Bold(true),
+	"footer": lipgloss.NewStyle().Border(lipgloss.NormalBorder()),
+}
+
+func TestWriteANSI(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteANSI(&buf, styles, "hi", 0); err != nil {
+		t.Fatal(err)
+	}
+	exp := "\x1b[2J\x1b[Hfooter\r\n┌──┐\r\n│hi│\r\n└──┘\r\n" +
+		"\x1b[2J\x1b[Htitle\r\n\x1b[1mhi\x1b[0m\r\n"
+	if buf.String() != exp {
+		t.Errorf("expected:\n%q\ngot:\n%q", exp, buf.String())
+	}
+}
+
+func TestWriteCast(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCast(&buf, styles, "hi", "my theme", 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	sc := bufio.NewScanner(&buf)
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %q", lines)
+	}
+	if lines[0] != `{"version":2,"width":6,"height":5,"title":"my theme"}` {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	var ev []interface{}
+	if err := json.Unmarshal([]byte(lines[2]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev[0] != 2.0 || ev[1] != "o" || ev[2] != "\x1b[2J\x1b[Htitle\r\n\x1b[1mhi\x1b[0m\r\n" {
+		t.Errorf("unexpected event: %q", ev)
+	}
+	if lines[3] != `[4,"o",""]` {
+		t.Errorf("unexpected final event: %s", lines[3])
+	}
+}
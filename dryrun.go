@@ -0,0 +1,68 @@
+package lipglossc
+
+// Change describes the effect of an import on a property, as reported
+// by ImportDryRun.
+type Change struct {
+	// Prop is the name of the property, e.g. "foreground".
+	Prop string
+	// Old is the textual value before the import, or empty if the
+	// property was not set.
+	Old string
+	// New is the textual value after the import, or empty if the
+	// property is not set anymore.
+	New string
+}
+
+// String returns the change in a human-readable form, e.g.
+// "foreground: 12 -> #f00".
+func (c Change) String() string {
+	from, to := c.Old, c.New
+	if from == "" {
+		from = "(unset)"
+	}
+	if to == "" {
+		to = "(unset)"
+	}
+	return c.Prop + ": " + from + " -> " + to
+}
+
+// ImportDryRun reports the changes that Import would make to dst,
+// without modifying it. The properties are reported in the order of
+// Export. This lets interactive tools show a preview of user edits
+// before applying them.
+//
+// The options are those of Import. If the input is invalid, the error
+// is that of Import and no changes are reported.
+func ImportDryRun(dst S, input string, opts ...ImportOption) ([]Change, error) {
+	res, err := Import(dst.Copy(), input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt := options{includeDefaults: true}
+	before, after := exportProps(dst, &opt), exportProps(res, &opt)
+	opt.includeDefaults = false
+	set := func(s S) map[string]bool {
+		m := map[string]bool{}
+		for _, pv := range exportProps(s, &opt) {
+			m[pv.name] = true
+		}
+		return m
+	}
+	beforeSet, afterSet := set(dst), set(res)
+
+	var changes []Change
+	for i, pv := range after {
+		if before[i].value == pv.value && beforeSet[pv.name] == afterSet[pv.name] {
+			continue
+		}
+		c := Change{Prop: pv.name}
+		if beforeSet[pv.name] {
+			c.Old = before[i].value
+		}
+		if afterSet[pv.name] {
+			c.New = pv.value
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
@@ -0,0 +1,43 @@
+package lipglossc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestImportDryRun(t *testing.T) {
+	dst, err := Import(lipgloss.NewStyle(), `bold: true; foreground: 12; padding-left: 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := ImportDryRun(dst, `foreground: #f00; bold: false; italic: true; padding-left: 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []Change{
+		{Prop: "bold", Old: "true"},
+		{Prop: "foreground", Old: "12", New: "#f00"},
+		{Prop: "italic", New: "true"},
+	}
+	if fmt.Sprint(changes) != fmt.Sprint(exp) {
+		t.Errorf("expected %v, got %v", exp, changes)
+	}
+	if s := changes[0].String(); s != "bold: true -> (unset)" {
+		t.Errorf("unexpected string: %q", s)
+	}
+	if s := changes[2].String(); s != "italic: (unset) -> true" {
+		t.Errorf("unexpected string: %q", s)
+	}
+
+	// dst is not modified.
+	checkOutput(t, `bold: true; foreground: 12; padding-left: 2;`, Export(dst))
+
+	if changes, err := ImportDryRun(dst, `bold: true`); err != nil || len(changes) != 0 {
+		t.Errorf("unexpected result: %v, %v", changes, err)
+	}
+	if _, err := ImportDryRun(dst, `bold: maybe`); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,43 @@
+package lipglossc
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ImportFromEnv reads styles from the environment variables whose
+// name starts with the given prefix. The rest of the variable name,
+// lowercased and with underscores replaced by dashes, is used as the
+// style name. For example, with prefix "MYAPP_STYLE_", the variable
+// MYAPP_STYLE_STATUS_BAR="bold: true" defines the style "status-bar".
+func ImportFromEnv(prefix string) (map[string]S, error) {
+	return importFromEnviron(prefix, os.Environ())
+}
+
+func importFromEnviron(prefix string, environ []string) (map[string]S, error) {
+	// Process the variables in a deterministic order, without
+	// modifying the caller's slice.
+	environ = append([]string(nil), environ...)
+	sort.Strings(environ)
+	styles := map[string]S{}
+	for _, kv := range environ {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 || !strings.HasPrefix(pair[0], prefix) {
+			continue
+		}
+		name := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(pair[0], prefix), "_", "-"))
+		if name == "" {
+			continue
+		}
+		s, err := Import(lipgloss.NewStyle(), pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", pair[0], err)
+		}
+		styles[name] = s
+	}
+	return styles, nil
+}
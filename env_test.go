@@ -0,0 +1,42 @@
+package lipglossc
+
+import (
+	"os"
+	"testing"
+)
+
+func TestImportFromEnv(t *testing.T) {
+	environ := []string{
+		"HOME=/home/user",
+		"MYAPP_STYLE_TITLE=bold: true; foreground: 12",
+		"MYAPP_STYLE_STATUS_BAR=faint: true",
+		"MYAPP_STYLE_=bold: true",
+		"MYAPP_OTHER=invalid",
+	}
+	styles, err := importFromEnviron("MYAPP_STYLE_", environ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The caller's slice is not modified.
+	if environ[0] != "HOME=/home/user" || environ[4] != "MYAPP_OTHER=invalid" {
+		t.Errorf("environ was modified: %v", environ)
+	}
+	if len(styles) != 2 {
+		t.Fatalf("expected 2 styles, got %v", styles)
+	}
+	checkOutput(t, `bold: true; foreground: 12;`, Export(styles["title"]))
+	checkOutput(t, `faint: true;`, Export(styles["status-bar"]))
+
+	_, err = importFromEnviron("MYAPP_STYLE_", []string{"MYAPP_STYLE_TITLE=bold: maybe"})
+	if err == nil || err.Error() != `MYAPP_STYLE_TITLE: in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	os.Setenv("LIPGLOSSC_TEST_STYLE_X", "italic: true")
+	defer os.Unsetenv("LIPGLOSSC_TEST_STYLE_X")
+	styles, err = ImportFromEnv("LIPGLOSSC_TEST_STYLE_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `italic: true;`, Export(styles["x"]))
+}
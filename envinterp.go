@@ -0,0 +1,73 @@
+package lipglossc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WithEnvInterpolation enables the references to environment variables
+// in the input, so that a theme can be tweaked by end users without
+// editing it:
+//
+//	foreground: ${APP_ACCENT_COLOR};
+//	border-foreground: ${APP_BORDER_COLOR:-#7D56F4};
+//
+// The value of a reference is obtained with the lookup function,
+// typically os.LookupEnv or a function that only exposes some
+// variables. A reference with ":-" defaults to the text that follows
+// if the variable is undefined or empty; the other references to
+// undefined variables are errors.
+//
+// References are replaced before the input is parsed, outside of the
+// comments. To prevent the environment from injecting directives, the
+// values cannot contain ";", "{", "}", double quotes or newlines.
+//
+// Without this option, references outside of quoted values are
+// reported as errors that mention the option. ImportSheet, ImportSpec
+// and Validate accept the option too.
+func WithEnvInterpolation(lookup func(name string) (string, bool)) ImportOption {
+	return func(o *importOptions) {
+		o.lookupEnv = lookup
+	}
+}
+
+// reEnvRef matches the ${NAME} and ${NAME:-default} references to
+// environment variables.
+var reEnvRef = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)(:-[^{}]*)?\}`)
+
+// interpolateEnv replaces the references to environment variables in
+// the input, if enabled. In case of error, it also returns the offset
+// of the reference in the input.
+func (opt *importOptions) interpolateEnv(input string) (string, int, error) {
+	if !strings.Contains(input, "${") {
+		return input, 0, nil
+	}
+	if opt.lookupEnv == nil {
+		// Quoted values may contain "${" literally.
+		if m := reEnvRef.FindStringIndex(blankStrings(input)); m != nil {
+			return input, m[0], fmt.Errorf("environment variable reference %s requires WithEnvInterpolation", input[m[0]:m[1]])
+		}
+		return input, 0, nil
+	}
+	var buf strings.Builder
+	last := 0
+	for _, m := range reEnvRef.FindAllStringSubmatchIndex(input, -1) {
+		name := input[m[2]:m[3]]
+		value, ok := opt.lookupEnv(name)
+		if m[4] >= 0 && value == "" {
+			value, ok = input[m[4]+2:m[5]], true
+		}
+		if !ok {
+			return input, m[0], fmt.Errorf("undefined environment variable: %s", name)
+		}
+		if strings.ContainsAny(value, ";{}\"\r\n") {
+			return input, m[0], fmt.Errorf("invalid value for environment variable %s: %q", name, value)
+		}
+		buf.WriteString(input[last:m[0]])
+		buf.WriteString(value)
+		last = m[1]
+	}
+	buf.WriteString(input[last:])
+	return buf.String(), 0, nil
+}
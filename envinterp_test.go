@@ -0,0 +1,107 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestEnvInterpolation(t *testing.T) {
+	env := map[string]string{
+		"ACCENT": "#7D56F4",
+		"PAD":    "2",
+		"EMPTY":  "",
+		"EVIL":   "red; bold: true",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	td := []struct {
+		in  string
+		exp string
+		err string
+	}{
+		{`foreground: ${ACCENT}`, `foreground: #7D56F4;`, ``},
+		{`padding: 0 ${PAD}`, `padding-left: 2; padding-right: 2;`, ``},
+		{`foreground: ${MISSING:-#f00}`, `foreground: #f00;`, ``},
+		{`foreground: ${EMPTY:-12}`, `foreground: 12;`, ``},
+		{`foreground: ${ACCENT:-12}`, `foreground: #7D56F4;`, ``},
+		{`/* ${MISSING} */ bold: true`, `bold: true;`, ``},
+		{`$c: ${ACCENT}; background: $c`, `background: #7D56F4;`, ``},
+		{`foreground: ${MISSING}`, ``, `undefined environment variable: MISSING`},
+		{`foreground: ${EMPTY}`, ``, `in "foreground:": missing value; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{`foreground: ${EVIL}`, ``, `invalid value for environment variable EVIL: "red; bold: true"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			s, err := Import(lipgloss.NewStyle(), tc.in, WithEnvInterpolation(lookup))
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				if verr := Validate(tc.in, WithEnvInterpolation(lookup)); verr == nil || verr.Error() != tc.err {
+					t.Errorf("expected validation error %q, got %v", tc.err, verr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkOutput(t, tc.exp, Export(s))
+			if err := Validate(tc.in, WithEnvInterpolation(lookup)); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+		})
+	}
+
+	// The references are not replaced without the option.
+	if _, err := Import(lipgloss.NewStyle(), `foreground: ${ACCENT}`); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestEnvInterpolationSheet(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		if name == "ACCENT" {
+			return "#7D56F4", true
+		}
+		return "", false
+	}
+	ss, err := ImportSheet(StyleSheet{}, `$accent: ${ACCENT};
+title { foreground: $accent; border-foreground: ${BORDER:-8}; }
+`, WithEnvInterpolation(lookup))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("title")
+	checkOutput(t, `border-bottom-foreground: 8; border-left-foreground: 8; border-right-foreground: 8; border-top-foreground: 8; foreground: #7D56F4;`, Export(s))
+
+	if _, err := ImportSheet(StyleSheet{}, "title { bold: true; }\n\nfooter { foreground: ${MISSING}; }", WithEnvInterpolation(lookup)); err == nil ||
+		err.Error() != `line 3: undefined environment variable: MISSING` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEnvInterpolationDisabled(t *testing.T) {
+	const msg = `environment variable reference ${ACCENT} requires WithEnvInterpolation`
+	if _, err := Import(lipgloss.NewStyle(), "bold: true; foreground: ${ACCENT}"); err == nil || err.Error() != msg {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate("foreground: ${ACCENT}"); err == nil || err.Error() != msg {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ImportSheet(StyleSheet{}, "title { bold: true; }\n\nfooter { foreground: ${ACCENT}; }"); err == nil ||
+		err.Error() != "line 3: "+msg {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// References in comments and quoted values are left alone.
+	s, err := Import(lipgloss.NewStyle(), "/* ${ACCENT} */ bold: true; border: border(\"${X}\",\"}\",\"$\",\"$\",\"+\",\"+\",\"+\",\"+\")")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if top := s.GetBorderStyle().Top; top != "${X}" {
+		t.Errorf("unexpected border: %q", top)
+	}
+}
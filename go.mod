@@ -3,7 +3,10 @@ module github.com/knz/lipgloss-convert
 go 1.13
 
 require (
+	github.com/charmbracelet/bubbletea v0.22.1
 	github.com/charmbracelet/lipgloss v0.6.0
+	github.com/charmbracelet/log v0.1.2
 	github.com/kr/pretty v0.3.0
+	github.com/muesli/termenv v0.11.1-0.20220212125758-44cd13922739
 	github.com/pmezard/go-difflib v1.0.0
 )
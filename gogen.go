@@ -0,0 +1,129 @@
+package lipglossc
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// GenerateGo writes the source code of a Go package named pkg that
+// exposes the given styles as functions, so that production binaries
+// do not need to parse style specifications at run time. For example,
+// the style "status-bar" becomes:
+//
+//	// StatusBar returns the "status-bar" style.
+//	func StatusBar() lipgloss.Style {
+//		return lipgloss.NewStyle().
+//			Bold(true).
+//			...
+//	}
+//
+// Functions are used instead of variables because lipgloss styles
+// share their properties with their copies: a variable could be
+// modified inadvertently by its users.
+//
+// The style names must translate to distinct Go identifiers.
+func GenerateGo(w io.Writer, pkg string, styles map[string]S) error {
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("invalid package name: %q", pkg)
+	}
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by lipglossc; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import \"github.com/charmbracelet/lipgloss\"\n")
+
+	seen := map[string]string{}
+	for _, name := range names {
+		fn := goIdentifier(name)
+		if !token.IsIdentifier(fn) {
+			return fmt.Errorf("style %q: cannot be translated to a Go identifier", name)
+		}
+		if other, ok := seen[fn]; ok {
+			return fmt.Errorf("styles %q and %q both translate to %s", other, name, fn)
+		}
+		seen[fn] = name
+
+		fmt.Fprintf(&buf, "\n// %s returns the %q style.\n", fn, name)
+		fmt.Fprintf(&buf, "func %s() lipgloss.Style {\n\treturn lipgloss.NewStyle()", fn)
+		v := reflect.ValueOf(styles[name])
+		for _, g := range styleGetters {
+			val := g.getFn.Call([]reflect.Value{v})[0]
+			if isDefault(val) {
+				continue
+			}
+			fmt.Fprintf(&buf, ".\n\t\t%s(%s)", camelCase(g.name), goValue(val.Interface()))
+		}
+		fmt.Fprintf(&buf, "\n}\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// GenerateGo returns the source code of a Go package named pkg that
+// exposes the styles of the sheet as functions, as per the GenerateGo
+// function. This compiles a stylesheet into code, so that production
+// builds can skip parsing entirely.
+func (ss StyleSheet) GenerateGo(pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := GenerateGo(&buf, pkg, ss.styles); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// goIdentifier converts a style name to an exported Go identifier,
+// e.g. "status-bar" to StatusBar or "button:focused" to ButtonFocused.
+func goIdentifier(name string) string {
+	return camelCase(strings.NewReplacer("_", "-", ".", "-", ":", "-", " ", "-").Replace(name))
+}
+
+// goValue returns the Go expression for a property value.
+func goValue(v interface{}) string {
+	switch v := v.(type) {
+	case lipgloss.Position:
+		return fmt.Sprintf("lipgloss.Position(%v)", float64(v))
+	case lipgloss.NoColor:
+		return "lipgloss.NoColor{}"
+	case lipgloss.Color:
+		return fmt.Sprintf("lipgloss.Color(%q)", string(v))
+	case lipgloss.AdaptiveColor:
+		return fmt.Sprintf("lipgloss.AdaptiveColor{Light: %q, Dark: %q}", v.Light, v.Dark)
+	case lipgloss.CompleteColor:
+		return goCompleteColor(v)
+	case lipgloss.CompleteAdaptiveColor:
+		return fmt.Sprintf("lipgloss.CompleteAdaptiveColor{Light: %s, Dark: %s}",
+			goCompleteColor(v.Light), goCompleteColor(v.Dark))
+	case lipgloss.Border:
+		for _, b := range borderPresets {
+			if reflect.DeepEqual(b.fn(), v) {
+				return fmt.Sprintf("lipgloss.%sBorder()", camelCase(b.name))
+			}
+		}
+		return fmt.Sprintf("lipgloss.Border{Top: %q, Bottom: %q, Left: %q, Right: %q, "+
+			"TopLeft: %q, TopRight: %q, BottomRight: %q, BottomLeft: %q}",
+			v.Top, v.Bottom, v.Left, v.Right, v.TopLeft, v.TopRight, v.BottomRight, v.BottomLeft)
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func goCompleteColor(c lipgloss.CompleteColor) string {
+	return fmt.Sprintf("lipgloss.CompleteColor{TrueColor: %q, ANSI256: %q, ANSI: %q}", c.TrueColor, c.ANSI256, c.ANSI)
+}
@@ -0,0 +1,105 @@
+package lipglossc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGenerateGo(t *testing.T) {
+	title, err := Import(lipgloss.NewStyle(), `bold: true; foreground: adaptive(#fff,12); align: center;
+border: rounded; border-top-background: complete(#123456,21,4); padding-left: 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	footer := lipgloss.NewStyle().BorderStyle(lipgloss.Border{Top: "=", Bottom: "\""})
+
+	var buf bytes.Buffer
+	if err := GenerateGo(&buf, "theme", map[string]S{"title": title, "status-bar": footer, "button:focused": lipgloss.NewStyle().Bold(true)}); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `// Code generated by lipglossc; DO NOT EDIT.
+
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// ButtonFocused returns the "button:focused" style.
+func ButtonFocused() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true)
+}
+
+// StatusBar returns the "status-bar" style.
+func StatusBar() lipgloss.Style {
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.Border{Top: "=", Bottom: "\"", Left: "", Right: "", TopLeft: "", TopRight: "", BottomRight: "", BottomLeft: ""})
+}
+
+// Title returns the "title" style.
+func Title() lipgloss.Style {
+	return lipgloss.NewStyle().
+		AlignHorizontal(lipgloss.Position(0.5)).
+		Bold(true).
+		BorderBottom(true).
+		BorderLeft(true).
+		BorderRight(true).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderTop(true).
+		BorderTopBackground(lipgloss.CompleteColor{TrueColor: "#123456", ANSI256: "21", ANSI: "4"}).
+		Foreground(lipgloss.AdaptiveColor{Light: "#fff", Dark: "12"}).
+		PaddingLeft(2)
+}
+`, buf.String())
+
+	for _, tc := range []struct {
+		pkg    string
+		styles map[string]S
+		expErr string
+	}{
+		{"my-theme", nil, `invalid package name: "my-theme"`},
+		{"theme", map[string]S{"1st": footer}, `style "1st": cannot be translated to a Go identifier`},
+		{"theme", map[string]S{"a-b": footer, "a_b": footer}, `styles "a-b" and "a_b" both translate to AB`},
+	} {
+		if err := GenerateGo(&buf, tc.pkg, tc.styles); err == nil || err.Error() != tc.expErr {
+			t.Errorf("expected %q, got %v", tc.expErr, err)
+		}
+	}
+}
+
+func TestStyleSheetGenerateGo(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `@palette { accent: #7D56F4; }
+title { bold: true; foreground: accent; }
+help.text { faint: true; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := ss.GenerateGo("theme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `// Code generated by lipglossc; DO NOT EDIT.
+
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// HelpText returns the "help.text" style.
+func HelpText() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Faint(true)
+}
+
+// Title returns the "title" style.
+func Title() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4"))
+}
+`, string(src))
+
+	if _, err := ss.GenerateGo("my-theme"); err == nil || err.Error() != `invalid package name: "my-theme"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
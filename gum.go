@@ -0,0 +1,228 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ExportGum converts a style into the equivalent command-line flags
+// for "gum style" (https://github.com/charmbracelet/gum), for example
+// []string{"--bold", "--foreground", "212", "--border", "rounded"}, so
+// that shell scripts can reuse themes defined for Go programs.
+//
+// The properties that gum does not support are omitted. This includes
+// adaptive and complete colors, custom borders and borders on only
+// some sides, and border colors that differ between sides.
+func ExportGum(s S) []string {
+	var args []string
+	add := func(flag string, val ...string) {
+		args = append(args, "--"+flag)
+		args = append(args, val...)
+	}
+	color := func(flag string, tc lipgloss.TerminalColor) {
+		if c, ok := tc.(lipgloss.Color); ok {
+			add(flag, string(c))
+		}
+	}
+
+	color("foreground", s.GetForeground())
+	color("background", s.GetBackground())
+
+	if name, ok := gumBorder(s); ok {
+		add("border", name)
+		color("border-background", uniformColor(
+			s.GetBorderTopBackground(), s.GetBorderRightBackground(),
+			s.GetBorderBottomBackground(), s.GetBorderLeftBackground()))
+		color("border-foreground", uniformColor(
+			s.GetBorderTopForeground(), s.GetBorderRightForeground(),
+			s.GetBorderBottomForeground(), s.GetBorderLeftForeground()))
+	}
+
+	if p := s.GetAlignHorizontal(); p != lipgloss.Left {
+		if k, ok := positionKeyword("align-horizontal", p); ok {
+			add("align", k)
+		}
+	}
+	if h := s.GetHeight(); h > 0 {
+		add("height", strconv.Itoa(h))
+	}
+	if w := s.GetWidth(); w > 0 {
+		add("width", strconv.Itoa(w))
+	}
+	if t, r, b, l := s.GetMargin(); t|r|b|l != 0 {
+		add("margin", joinInts(t, r, b, l))
+	}
+	if t, r, b, l := s.GetPadding(); t|r|b|l != 0 {
+		add("padding", joinInts(t, r, b, l))
+	}
+
+	for _, f := range []struct {
+		flag string
+		set  bool
+	}{
+		{"bold", s.GetBold()},
+		{"faint", s.GetFaint()},
+		{"italic", s.GetItalic()},
+		{"strikethrough", s.GetStrikethrough()},
+		{"underline", s.GetUnderline()},
+	} {
+		if f.set {
+			add(f.flag)
+		}
+	}
+	return args
+}
+
+// gumFlags maps the flags of "gum style" to properties.
+var gumFlags = map[string]struct {
+	prop    string
+	boolean bool
+}{
+	"foreground":        {prop: "foreground"},
+	"background":        {prop: "background"},
+	"border":            {prop: "border-style"},
+	"border-background": {prop: "border-background"},
+	"border-foreground": {prop: "border-foreground"},
+	"align":             {prop: "align-horizontal"},
+	"height":            {prop: "height"},
+	"width":             {prop: "width"},
+	"margin":            {prop: "margin"},
+	"padding":           {prop: "padding"},
+	"bold":              {prop: "bold", boolean: true},
+	"faint":             {prop: "faint", boolean: true},
+	"italic":            {prop: "italic", boolean: true},
+	"strikethrough":     {prop: "strikethrough", boolean: true},
+	"underline":         {prop: "underline", boolean: true},
+}
+
+// ImportGum applies "gum style" command-line flags to the dst style,
+// for example []string{"--bold", "--foreground", "212"}. Both the
+// "--flag value" and "--flag=value" forms are accepted. The arguments
+// that are not flags, i.e. the text to style, are ignored.
+func ImportGum(dst S, args []string) (S, error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if j := strings.IndexByte(name, '='); j >= 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+		f, ok := gumFlags[name]
+		if !ok {
+			return dst, fmt.Errorf("unsupported gum flag: %q", arg)
+		}
+		switch {
+		case f.boolean && !hasValue:
+			value = "true"
+		case !hasValue:
+			if i+1 >= len(args) {
+				return dst, fmt.Errorf("%s: missing value", arg)
+			}
+			i++
+			value = args[i]
+		}
+		if f.prop == "border-style" && value == "none" {
+			// gum's default: no border.
+			continue
+		}
+		// The value is quoted if needed, so that it cannot inject other
+		// directives, e.g. with "--foreground '1; bold: true'".
+		var err error
+		dst, err = Import(dst, f.prop+": "+quoteValue(value))
+		if err != nil {
+			return dst, fmt.Errorf("%s: %v", arg, err)
+		}
+	}
+	return dst, nil
+}
+
+// ImportGumString is like ImportGum, but takes the flags as a single
+// string, e.g. `--bold --border "rounded"`. The string is split into
+// words as per the shell rules for spaces and quotes.
+func ImportGumString(dst S, flags string) (S, error) {
+	args, err := splitWords(flags)
+	if err != nil {
+		return dst, err
+	}
+	return ImportGum(dst, args)
+}
+
+// splitWords splits a string into words separated by spaces, removing
+// single and double quotes.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var buf strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			buf.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, buf.String())
+				buf.Reset()
+				inWord = false
+			}
+		default:
+			buf.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote: %s", string(quote))
+	}
+	if inWord {
+		words = append(words, buf.String())
+	}
+	return words, nil
+}
+
+// gumBorder returns the name of the border of the style, if it is a
+// predefined border drawn on all sides. Like lipgloss, this considers
+// that a border style without any side enabled is drawn on all sides.
+func gumBorder(s S) (string, bool) {
+	top, right, bottom, left := s.GetBorderTop(), s.GetBorderRight(), s.GetBorderBottom(), s.GetBorderLeft()
+	if (top || right || bottom || left) && !(top && right && bottom && left) {
+		return "", false
+	}
+	b := s.GetBorderStyle()
+	for _, p := range borderPresets {
+		if reflect.DeepEqual(p.fn(), b) {
+			return p.name, true
+		}
+	}
+	return "", false
+}
+
+// uniformColor returns the color if all the arguments are equal, and
+// NoColor otherwise.
+func uniformColor(colors ...lipgloss.TerminalColor) lipgloss.TerminalColor {
+	for _, c := range colors[1:] {
+		if !reflect.DeepEqual(c, colors[0]) {
+			return lipgloss.NoColor{}
+		}
+	}
+	return colors[0]
+}
+
+func joinInts(vals ...int) string {
+	s := make([]string, len(vals))
+	for i, v := range vals {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,69 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestExportGum(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `bold: true; italic: true; foreground: 212; background: adaptive(#fff,#000);
+border-style: rounded; border-foreground: #7D56F4; align: center; width: 50; margin: 1 2; padding: 2 4`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `--foreground 212 --border rounded --border-foreground #7D56F4 --align center --width 50 --margin 1 2 1 2 --padding 2 4 2 4 --bold --italic`,
+		strings.Join(ExportGum(s), " "))
+
+	// Borders on only some sides and non-uniform border colors are not supported.
+	s = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false).BorderTopForeground(lipgloss.Color("1"))
+	checkOutput(t, ``, strings.Join(ExportGum(s), " "))
+	s = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderTopForeground(lipgloss.Color("1"))
+	checkOutput(t, `--border double`, strings.Join(ExportGum(s), " "))
+}
+
+func TestImportGum(t *testing.T) {
+	s, err := ImportGum(lipgloss.NewStyle(), []string{
+		"--bold", "--foreground", "212", "--border=rounded", "--border-foreground", "#7D56F4",
+		"--align", "center", "--margin", "1 2", "--padding=2 4", "--italic=false", "Hello",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `align-horizontal: 0.5; bold: true; border-bottom-foreground: #7D56F4; border-left-foreground: #7D56F4; `+
+		`border-right-foreground: #7D56F4; border-style: border("─","─","│","│","╭","╮","╯","╰"); border-top-foreground: #7D56F4; `+
+		`foreground: 212; margin-bottom: 1; margin-left: 2; margin-right: 2; margin-top: 1; `+
+		`padding-bottom: 2; padding-left: 4; padding-right: 4; padding-top: 2;`, Export(s))
+
+	// Round trip.
+	s2, err := ImportGum(lipgloss.NewStyle(), ExportGum(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, Export(s), Export(s2))
+
+	s, err = ImportGumString(lipgloss.NewStyle(), `--border none --width 10 --margin "1 2" 'some text'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `margin-bottom: 1; margin-left: 2; margin-right: 2; margin-top: 1; width: 10;`, Export(s))
+
+	for _, tc := range []struct {
+		flags  string
+		expErr string
+	}{
+		{`--blink`, `unsupported gum flag: "--blink"`},
+		{`--width`, `--width: missing value`},
+		{`--width abc`, `--width: in "width: abc": no value found; width expects an integer`},
+		{`--margin "1 2`, `unterminated quote: "`},
+		// The values cannot inject other directives.
+		{`--foreground "1; bold: true"`, `--foreground: in "foreground: \"1; bold: true\"": color not recognized; ` +
+			`foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{`--bold="true; italic: true"`, `--bold=true; italic: true: in "bold: \"true; italic: true\"": no value found; bold expects true or false`},
+	} {
+		if _, err := ImportGumString(lipgloss.NewStyle(), tc.flags); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected error %q, got %v", tc.flags, tc.expErr, err)
+		}
+	}
+}
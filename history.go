@@ -0,0 +1,75 @@
+package lipglossc
+
+// History records the successive states of a style as it is edited,
+// with undo and redo. This provides the state management of
+// interactive style editors.
+//
+// History is not safe for concurrent use.
+type History struct {
+	// states holds the recorded styles; states[cur] is the current one.
+	states []S
+	cur    int
+}
+
+// NewHistory creates a History starting from the given style.
+func NewHistory(s S) *History {
+	return &History{states: []S{s.Copy()}}
+}
+
+// Current returns the current style. The result is a copy: modifying
+// it does not affect the history.
+func (h *History) Current() S {
+	return h.states[h.cur].Copy()
+}
+
+// Import applies the given style specification, as per Import, and
+// records the result as a new state. The states previously undone
+// are forgotten. If the input is invalid, the history is unchanged.
+func (h *History) Import(input string, opts ...ImportOption) error {
+	s, err := Import(h.Current(), input, opts...)
+	if err != nil {
+		return err
+	}
+	h.push(s)
+	return nil
+}
+
+// SetProp sets a single property, e.g. SetProp("foreground", "12"),
+// and records the result as a new state.
+func (h *History) SetProp(prop, value string) error {
+	p, err := getProp(prop)
+	if err != nil {
+		return err
+	}
+	s, err := p.assign(h.Current(), value, nil)
+	if err != nil {
+		return p.withSyntax(prop, err)
+	}
+	h.push(s)
+	return nil
+}
+
+func (h *History) push(s S) {
+	h.states = append(h.states[:h.cur+1], s)
+	h.cur++
+}
+
+// Undo reverts to the previous state. It returns false if there is
+// nothing to undo.
+func (h *History) Undo() bool {
+	if h.cur == 0 {
+		return false
+	}
+	h.cur--
+	return true
+}
+
+// Redo re-applies the last undone change. It returns false if there is
+// nothing to redo.
+func (h *History) Redo() bool {
+	if h.cur == len(h.states)-1 {
+		return false
+	}
+	h.cur++
+	return true
+}
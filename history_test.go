@@ -0,0 +1,55 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(lipgloss.NewStyle().Bold(true))
+	if h.Undo() || h.Redo() {
+		t.Fatal("unexpected undo/redo on empty history")
+	}
+	if err := h.Import(`foreground: 12; padding: 1`); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.SetProp("padding-left", "3"); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; foreground: 12; padding-bottom: 1; padding-left: 3; padding-right: 1; padding-top: 1;`, Export(h.Current()))
+
+	// Invalid edits are not recorded.
+	if err := h.SetProp("bold", "maybe"); err == nil || err.Error() != "no value found; bold expects true or false" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := h.Import(`italic: maybe`); err == nil {
+		t.Errorf("expected error")
+	}
+
+	// Modifying the current style does not alter the history.
+	h.Current().Italic(true)
+
+	if !h.Undo() {
+		t.Fatal("expected undo")
+	}
+	checkOutput(t, `bold: true; foreground: 12; padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`, Export(h.Current()))
+	if !h.Undo() || h.Undo() {
+		t.Fatal("expected exactly one more undo")
+	}
+	checkOutput(t, `bold: true;`, Export(h.Current()))
+
+	if !h.Redo() {
+		t.Fatal("expected redo")
+	}
+	checkOutput(t, `bold: true; foreground: 12; padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`, Export(h.Current()))
+
+	// A new edit forgets the undone states.
+	if err := h.SetProp("foreground", "unset"); err != nil {
+		t.Fatal(err)
+	}
+	if h.Redo() {
+		t.Error("unexpected redo")
+	}
+	checkOutput(t, `bold: true; padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1;`, Export(h.Current()))
+}
@@ -0,0 +1,191 @@
+package lipglossc
+
+import (
+	"strings"
+)
+
+// sheetChunk is a top-level construct of a stylesheet document: a
+// style block, or a definition or directive applying to the whole
+// document.
+type sheetChunk struct {
+	text string
+	// names are the selectors of a style block, and nil for the other
+	// constructs.
+	names []string
+	// body is the body of a style block, between the braces.
+	body string
+}
+
+// splitChunks splits a stylesheet document into its top-level
+// constructs, without interpreting them. ok is false if the document
+// is not well formed.
+func splitChunks(input string) (chunks []sheetChunk, ok bool) {
+	input, unterminated := blankComments(input)
+	if unterminated >= 0 {
+		return nil, false
+	}
+	for {
+		input = strings.TrimLeft(input, " \t\r\n")
+		if input == "" {
+			return chunks, true
+		}
+		i := indexOutsideStrings(input, "{};")
+		if i < 0 || input[i] == '}' {
+			return nil, false
+		}
+		if input[i] == ';' {
+			chunks = append(chunks, sheetChunk{text: input[:i+1]})
+			input = input[i+1:]
+			continue
+		}
+		end := i + 1
+		for depth := 1; depth > 0; end++ {
+			j := indexOutsideStrings(input[end:], "{}")
+			if j < 0 {
+				return nil, false
+			}
+			end += j
+			if input[end] == '{' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		c := sheetChunk{text: input[:end]}
+		if header := strings.TrimSpace(input[:i]); !strings.HasPrefix(header, "@") {
+			for _, name := range strings.Split(header, ",") {
+				c.names = append(c.names, strings.TrimSpace(name))
+			}
+			c.body = input[i+1 : end-1]
+		}
+		chunks = append(chunks, c)
+		input = input[end:]
+	}
+}
+
+// importIncremental imports the new version of a stylesheet document,
+// given the sheet imported from the previous version, by re-applying
+// only the style blocks that changed, and those of the styles that
+// depend on them. ok is false if the changes are not limited to style
+// blocks, in which case the whole document must be imported again.
+//
+// The styles of prev that are not affected by the changes are kept
+// as-is; the definitions are read again from the new version. The
+// result is the same as that of ImportSheet with an empty sheet.
+func importIncremental(prev StyleSheet, oldInput, newInput string, opts []ImportOption) (res StyleSheet, affected map[string]bool, ok bool, err error) {
+	affected = changedStyles(oldInput, newInput)
+	if affected == nil {
+		return StyleSheet{}, nil, false, nil
+	}
+	ss := StyleSheet{styles: prev.Styles()}
+	for name := range prev.important {
+		if name != "" {
+			ss.setImportant(name, prev.importantOf(name))
+		}
+	}
+	for name := range affected {
+		delete(ss.styles, name)
+		delete(ss.important, name)
+	}
+	p := sheetParser{input: newInput, line: 1, only: affected}
+	if err := p.parseDocument(&ss, opts); err != nil {
+		return prev, affected, true, err
+	}
+	return ss, affected, true, nil
+}
+
+// changedStyles returns the names of the styles affected by the
+// changes between two versions of a stylesheet document, or nil if
+// the changes are not limited to style blocks.
+func changedStyles(oldInput, newInput string) map[string]bool {
+	if strings.Contains(newInput, "${") {
+		// The environment may have changed too.
+		return nil
+	}
+	oldChunks, ok1 := splitChunks(oldInput)
+	newChunks, ok2 := splitChunks(newInput)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	oldBlocks, oldOthers, ok1 := partitionChunks(oldChunks)
+	newBlocks, newOthers, ok2 := partitionChunks(newChunks)
+	if !ok1 || !ok2 || len(oldOthers) != len(newOthers) {
+		return nil
+	}
+	for i := range oldOthers {
+		if oldOthers[i] != newOthers[i] {
+			return nil
+		}
+	}
+
+	// The blocks between the common prefix and the common suffix of
+	// both versions changed.
+	prefix := 0
+	for prefix < len(oldBlocks) && prefix < len(newBlocks) && oldBlocks[prefix].text == newBlocks[prefix].text {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldBlocks)-prefix && suffix < len(newBlocks)-prefix &&
+		oldBlocks[len(oldBlocks)-1-suffix].text == newBlocks[len(newBlocks)-1-suffix].text {
+		suffix++
+	}
+	affected := map[string]bool{}
+	for _, c := range oldBlocks[prefix : len(oldBlocks)-suffix] {
+		for _, name := range c.names {
+			affected[name] = true
+		}
+	}
+	for _, c := range newBlocks[prefix : len(newBlocks)-suffix] {
+		for _, name := range c.names {
+			affected[name] = true
+		}
+	}
+
+	// The styles whose blocks mention an affected style, e.g. with
+	// extends or styleref(), are affected too. The test is textual,
+	// and thus conservative.
+	for changed := true; changed; {
+		changed = false
+		for _, c := range newBlocks {
+			for name := range affected {
+				if !strings.Contains(c.body, name) {
+					continue
+				}
+				for _, other := range c.names {
+					if !affected[other] {
+						affected[other] = true
+						changed = true
+					}
+				}
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// partitionChunks separates the style blocks from the other
+// constructs of a document. ok is false if the document uses
+// constructs whose effect on the styles cannot be tracked by
+// changedStyles: imports, removals, conditional and version sections,
+// and selectors with wildcards.
+func partitionChunks(chunks []sheetChunk) (blocks []sheetChunk, others []string, ok bool) {
+	for _, c := range chunks {
+		if c.names == nil {
+			for _, kw := range []string{"@import", "@remove", "@dark", "@light", "@profile", "@version"} {
+				if strings.HasPrefix(c.text, kw) {
+					return nil, nil, false
+				}
+			}
+			others = append(others, c.text)
+			continue
+		}
+		for _, name := range c.names {
+			if strings.ContainsAny(name, "*?[") {
+				return nil, nil, false
+			}
+		}
+		blocks = append(blocks, c)
+	}
+	return blocks, others, true
+}
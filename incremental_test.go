@@ -0,0 +1,77 @@
+package lipglossc
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestImportIncremental(t *testing.T) {
+	const v1 = `$accent: #7D56F4;
+@mixin loud { bold: true; }
+base { padding: 1; }
+title { extends: base; foreground: $accent; }
+footer { faint: true; }
+help, hint { italic: true; }
+button:focused { underline: true; }
+`
+	prev, err := ImportSheet(StyleSheet{}, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		input    string
+		affected string
+	}{
+		// A changed block re-applies its styles.
+		{strings.Replace(v1, "faint: true", "faint: false", 1), `footer`},
+		// As well as those of the styles that depend on them.
+		{strings.Replace(v1, "padding: 1", "padding: 2", 1), `base title`},
+		// New blocks and removed blocks.
+		{v1 + "footer { @include loud; }\n", `footer`},
+		{strings.Replace(v1, "help, hint { italic: true; }\n", "", 1), `help hint`},
+		{strings.Replace(v1, "underline: true", "reverse: true", 1), `button:focused`},
+		// Comments do not count.
+		{v1 + "// done\n", ``},
+	}
+	for _, tc := range testData {
+		exp, err := ImportSheet(StyleSheet{}, tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ss, affected, ok, err := importIncremental(prev, v1, tc.input, nil)
+		if !ok || err != nil {
+			t.Errorf("%q: unexpected result: %v, %v", tc.input, ok, err)
+			continue
+		}
+		var names []string
+		for name := range affected {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		checkOutput(t, tc.affected, strings.Join(names, " "))
+		checkOutput(t, exp.Export(), ss.Export())
+	}
+
+	// The changes to the definitions require a full import.
+	for _, input := range []string{
+		strings.Replace(v1, "#7D56F4", "#ff0000", 1),
+		v1 + "@remove footer;\n",
+		v1 + "@dark { footer { bold: true; } }\n",
+		v1 + "list.* { bold: true; }\n",
+		v1 + "footer {",
+	} {
+		if _, _, ok, _ := importIncremental(prev, v1, input, nil); ok {
+			t.Errorf("%q: expected a full import", input)
+		}
+	}
+
+	// Errors in the changed blocks are reported.
+	input := strings.Replace(v1, "faint: true", "faint: maybe", 1)
+	if _, _, _, err := importIncremental(prev, v1, input, nil); err == nil {
+		t.Errorf("expected error")
+	} else {
+		checkOutput(t, `line 5: style "footer": in "faint: maybe": no value found; faint expects true or false`, err.Error())
+	}
+}
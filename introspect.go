@@ -0,0 +1,127 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Properties returns the names of all the properties supported by
+// Import, in sorted order.
+func Properties() []string {
+	var names []string
+	for i := 0; i < styleType.NumMethod(); i++ {
+		m := styleType.Method(i)
+		if m.Type.NumIn() < 2 || strings.HasPrefix(m.Name, "Set") {
+			// Not a property setter.
+			continue
+		}
+		name := snakeCase(m.Name)
+		if _, err := getProp(name); err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Keywords returns the keywords accepted as value by the given
+// property, for example "top", "bottom" etc for positions or
+// "rounded", "double" etc for borders. The special keyword "unset"
+// is included if the property can be unset.
+func Keywords(propName string) ([]string, error) {
+	p, err := getProp(propName)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	seen := map[string]bool{}
+	for _, arg := range p.args {
+		for _, k := range arg.keywords() {
+			if !seen[k] {
+				seen[k] = true
+				res = append(res, k)
+			}
+		}
+	}
+	if p.unsetFn.IsValid() {
+		res = append(res, "unset")
+	}
+	return res, nil
+}
+
+// PropNameFor returns the name of the property corresponding to a
+// setter method of lipgloss.Style, e.g. "padding-left" for
+// PaddingLeft. It returns false if the method does not exist or is not
+// supported by Import. This lets code generators and debugging tools
+// map Go API calls to directives.
+func PropNameFor(method string) (string, bool) {
+	if _, ok := styleType.MethodByName(method); !ok {
+		return "", false
+	}
+	name := snakeCase(method)
+	if !isProperty(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// MethodFor is the inverse of PropNameFor: it returns the name of the
+// setter method of lipgloss.Style corresponding to a property, e.g.
+// PaddingLeft for "padding-left". It returns false if the property is
+// not supported.
+func MethodFor(propName string) (string, bool) {
+	if !isProperty(propName) {
+		return "", false
+	}
+	return camelCase(propName), true
+}
+
+// isProperty reports whether the name is the canonical name of a
+// property supported by Import. Methods without arguments, e.g. Copy,
+// are not properties.
+func isProperty(name string) bool {
+	if snakeCase(camelCase(name)) != name {
+		return false
+	}
+	p, err := getProp(name)
+	return err == nil && len(p.args) > 0
+}
+
+// Syntax returns a one-line description of the values accepted by the
+// given property, e.g. "width expects an integer". This is the hint
+// added to the errors for the values that Import cannot parse.
+func Syntax(propName string) (string, error) {
+	p, err := getProp(propName)
+	if err != nil {
+		return "", err
+	}
+	return p.syntax(propName), nil
+}
+
+// syntax describes the values accepted by the property. The last
+// argument of variadic properties is marked as repeatable.
+func (p prop) syntax(name string) string {
+	parts := make([]string, len(p.args))
+	for i, arg := range p.args {
+		parts[i] = arg.syntax()
+	}
+	if p.isVariadic && len(parts) > 0 {
+		parts[len(parts)-1] += " (repeatable)"
+	}
+	if len(parts) == 0 {
+		return name + " expects no value"
+	}
+	return name + " expects " + strings.Join(parts, ", then ")
+}
+
+// withSyntax adds the syntax hint of the property to the errors
+// reported for its value, except for out-of-range values which are
+// syntactically valid.
+func (p prop) withSyntax(name string, err error) error {
+	if _, ok := err.(rangeError); ok {
+		return err
+	}
+	return fmt.Errorf("%v; %s", err, p.syntax(name))
+}
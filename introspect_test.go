@@ -0,0 +1,136 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestProperties(t *testing.T) {
+	props := Properties()
+	for _, exp := range []string{"align", "align-horizontal", "bold", "border", "border-style", "foreground", "padding", "width"} {
+		found := false
+		for _, p := range props {
+			if p == exp {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected property %q in %v", exp, props)
+		}
+	}
+	for _, p := range props {
+		if strings.HasPrefix(p, "get-") || strings.HasPrefix(p, "unset-") || strings.HasPrefix(p, "set-") || p == "copy" || p == "render" {
+			t.Errorf("unexpected property %q", p)
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	td := []struct {
+		prop string
+		exp  string
+	}{
+		{"width", "unset"},
+		{"padding", "unset"},
+		{"bold", "true false on off yes no unset"},
+		{"align-vertical", "top bottom center middle left right unset"},
+		{"border-style", "rounded normal thick hidden double unset"},
+		{"border", "rounded normal thick hidden double true false on off yes no"},
+	}
+	for _, tc := range td {
+		t.Run(tc.prop, func(t *testing.T) {
+			kw, err := Keywords(tc.prop)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res := strings.Join(kw, " "); res != tc.exp {
+				t.Errorf("expected %q, got %q", tc.exp, res)
+			}
+		})
+	}
+
+	kw, err := Keywords("foreground")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kw[0] != "none" || kw[len(kw)-1] != "unset" || len(kw) != len(namedColors)+2 {
+		t.Errorf("unexpected color keywords: %v", kw)
+	}
+
+	if _, err := Keywords("foo"); err == nil || err.Error() != `property not supported: "foo"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPropNameFor(t *testing.T) {
+	for method, prop := range map[string]string{
+		"PaddingLeft":         "padding-left",
+		"Padding":             "padding",
+		"BorderTopForeground": "border-top-foreground",
+		"MaxWidth":            "max-width",
+		"Bold":                "bold",
+	} {
+		if res, ok := PropNameFor(method); !ok || res != prop {
+			t.Errorf("%s: expected %q, got %q, %v", method, prop, res, ok)
+		}
+		if res, ok := MethodFor(prop); !ok || res != method {
+			t.Errorf("%s: expected %q, got %q, %v", prop, method, res, ok)
+		}
+	}
+	for _, method := range []string{"paddingLeft", "GetBold", "UnsetBold", "Copy", "Render", "SetString", "Foo"} {
+		if res, ok := PropNameFor(method); ok {
+			t.Errorf("%s: unexpected property %q", method, res)
+		}
+	}
+	for _, prop := range []string{"copy", "get-bold", "foo", "PaddingLeft"} {
+		if res, ok := MethodFor(prop); ok {
+			t.Errorf("%s: unexpected method %q", prop, res)
+		}
+	}
+
+	// Every property maps to a method and back.
+	for _, prop := range Properties() {
+		method, ok := MethodFor(prop)
+		if !ok {
+			t.Errorf("%s: no method", prop)
+			continue
+		}
+		if res, ok := PropNameFor(method); !ok || res != prop {
+			t.Errorf("%s: %s maps back to %q, %v", prop, method, res, ok)
+		}
+	}
+}
+
+func TestSyntax(t *testing.T) {
+	td := []struct {
+		prop string
+		exp  string
+	}{
+		{"width", "width expects an integer"},
+		{"padding", "padding expects an integer (repeatable)"},
+		{"bold", "bold expects true or false"},
+		{"border-style", `border-style expects a preset name or border("t","b","l","r","tl","tr","br","bl")`},
+		{"border", `border expects a preset name or border("t","b","l","r","tl","tr","br","bl"), then true or false (repeatable)`},
+	}
+	for _, tc := range td {
+		res, err := Syntax(tc.prop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkOutput(t, tc.exp, res)
+	}
+
+	// Every property has a hint.
+	for _, prop := range Properties() {
+		if res, err := Syntax(prop); err != nil || !strings.HasPrefix(res, prop+" expects ") {
+			t.Errorf("%s: unexpected syntax: %q, %v", prop, res, err)
+		}
+	}
+
+	// Out-of-range values are not syntax errors.
+	if _, err := Import(lipgloss.NewStyle(), "width: -1"); err == nil || strings.Contains(err.Error(), "expects") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package lipglossc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Lerp interpolates between styles a and b. t=0 returns the
+// properties of a, t=1 those of b. Colors and numeric properties
+// (padding, margins, sizes, alignment) are interpolated linearly;
+// other properties (e.g. bold or the border style) switch from a to b
+// at t=0.5.
+//
+// The result only contains properties set in either a or b.
+// Interpolated colors are expressed as RGB hex values, even if the
+// original colors used the ANSI palette.
+func Lerp(a, b S, t float64) S {
+	t = math.Max(0, math.Min(1, t))
+	result := lipgloss.NewStyle()
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for _, g := range styleGetters {
+		ra := g.getFn.Call([]reflect.Value{va})[0]
+		rb := g.getFn.Call([]reflect.Value{vb})[0]
+		if isDefault(ra) && isDefault(rb) {
+			continue
+		}
+		var val reflect.Value
+		switch x := ra.Interface().(type) {
+		case int:
+			y := rb.Interface().(int)
+			val = reflect.ValueOf(int(math.Round(float64(x) + float64(y-x)*t)))
+		case lipgloss.Position:
+			y := rb.Interface().(lipgloss.Position)
+			val = reflect.ValueOf(lipgloss.Position(float64(x) + float64(y-x)*t))
+		case lipgloss.TerminalColor:
+			y := rb.Interface().(lipgloss.TerminalColor)
+			val = reflect.ValueOf(lerpColor(x, y, t))
+		default:
+			val = ra
+			if t >= 0.5 {
+				val = rb
+			}
+		}
+		result = g.setFn.Call([]reflect.Value{reflect.ValueOf(result), val})[0].Interface().(S)
+	}
+	return result
+}
+
+// lerpColor interpolates between two colors. If the colors cannot be
+// interpolated (e.g. one of them is NoColor), the result switches
+// from a to b at t=0.5.
+func lerpColor(a, b lipgloss.TerminalColor, t float64) lipgloss.TerminalColor {
+	snap := a
+	if t >= 0.5 {
+		snap = b
+	}
+	switch ca := a.(type) {
+	case lipgloss.Color:
+		switch cb := b.(type) {
+		case lipgloss.Color:
+			if c, ok := lerpHex(string(ca), string(cb), t); ok {
+				return lipgloss.Color(c)
+			}
+		case lipgloss.AdaptiveColor:
+			return lerpColor(lipgloss.AdaptiveColor{Light: string(ca), Dark: string(ca)}, cb, t)
+		}
+	case lipgloss.AdaptiveColor:
+		switch cb := b.(type) {
+		case lipgloss.Color:
+			return lerpColor(ca, lipgloss.AdaptiveColor{Light: string(cb), Dark: string(cb)}, t)
+		case lipgloss.AdaptiveColor:
+			light, ok1 := lerpHex(ca.Light, cb.Light, t)
+			dark, ok2 := lerpHex(ca.Dark, cb.Dark, t)
+			if ok1 && ok2 {
+				return lipgloss.AdaptiveColor{Light: light, Dark: dark}
+			}
+		}
+	}
+	return snap
+}
+
+// lerpHex interpolates between two hex or ANSI colors, and returns
+// the result as a hex color.
+func lerpHex(a, b string, t float64) (string, bool) {
+	ar, ag, ab, ok := colorRGB(a)
+	if !ok {
+		return "", false
+	}
+	br, bg, bb, ok := colorRGB(b)
+	if !ok {
+		return "", false
+	}
+	mix := func(x, y int) int { return int(math.Round(float64(x) + float64(y-x)*t)) }
+	return fmt.Sprintf("#%02x%02x%02x", mix(ar, br), mix(ag, bg), mix(ab, bb)), true
+}
+
+// ColorRamp returns steps styles whose foreground and background
+// colors progress evenly from those of a to those of b, for example
+// to render progress bars or heat maps from a theme's endpoints.
+// The other properties of each style are copied from a.
+func ColorRamp(a, b S, steps int) []S {
+	if steps <= 0 {
+		return nil
+	}
+	ramp := make([]S, steps)
+	for i := range ramp {
+		t := 0.0
+		if steps > 1 {
+			t = float64(i) / float64(steps-1)
+		}
+		s := a.Copy()
+		if fa, fb := a.GetForeground(), b.GetForeground(); !isNoColor(fa) || !isNoColor(fb) {
+			s = s.Foreground(lerpColor(fa, fb, t))
+		}
+		if ba, bb := a.GetBackground(), b.GetBackground(); !isNoColor(ba) || !isNoColor(bb) {
+			s = s.Background(lerpColor(ba, bb, t))
+		}
+		ramp[i] = s
+	}
+	return ramp
+}
+
+func isNoColor(c lipgloss.TerminalColor) bool {
+	_, ok := c.(lipgloss.NoColor)
+	return ok
+}
@@ -0,0 +1,86 @@
+package lipglossc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLerp(t *testing.T) {
+	a := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#000000")).
+		Background(lipgloss.AdaptiveColor{Light: "#ffffff", Dark: "#000000"}).
+		BorderTopForeground(lipgloss.Color("15")).
+		PaddingLeft(1).
+		Width(10)
+	b := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ffffff")).
+		Background(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#ffffff"}).
+		BorderTopForeground(lipgloss.Color("0")).
+		PaddingLeft(4).
+		Width(20).
+		AlignHorizontal(lipgloss.Right)
+
+	td := []struct {
+		t   float64
+		exp string
+	}{
+		{0, `background: adaptive(#ffffff,#000000); bold: true; border-top-foreground: #ffffff; foreground: #000000; padding-left: 1; width: 10;`},
+		{0.25, `align-horizontal: 0.25; background: adaptive(#bfbfbf,#404040); bold: true; border-top-foreground: #bfbfbf; foreground: #404040; padding-left: 2; width: 13;`},
+		{0.5, `align-horizontal: 0.5; background: adaptive(#808080,#808080); border-top-foreground: #808080; foreground: #808080; padding-left: 3; width: 15;`},
+		{1, `align-horizontal: 1; background: adaptive(#000000,#ffffff); border-top-foreground: #000000; foreground: #ffffff; padding-left: 4; width: 20;`},
+		{2, `align-horizontal: 1; background: adaptive(#000000,#ffffff); border-top-foreground: #000000; foreground: #ffffff; padding-left: 4; width: 20;`},
+	}
+	for _, tc := range td {
+		t.Run(fmt.Sprint(tc.t), func(t *testing.T) {
+			checkOutput(t, tc.exp, Export(Lerp(a, b, tc.t)))
+		})
+	}
+}
+
+func TestLerpColor(t *testing.T) {
+	td := []struct {
+		a, b lipgloss.TerminalColor
+		t    float64
+		exp  lipgloss.TerminalColor
+	}{
+		{lipgloss.NoColor{}, lipgloss.Color("#fff"), 0.4, lipgloss.NoColor{}},
+		{lipgloss.NoColor{}, lipgloss.Color("#fff"), 0.6, lipgloss.Color("#fff")},
+		{lipgloss.Color("#000"), lipgloss.AdaptiveColor{Light: "#fff", Dark: "#000"}, 0.5,
+			lipgloss.AdaptiveColor{Light: "#808080", Dark: "#000000"}},
+		{lipgloss.Color("#000"), lipgloss.Color("invalid"), 0.2, lipgloss.Color("#000")},
+	}
+	for i, tc := range td {
+		res := lerpColor(tc.a, tc.b, tc.t)
+		if res != tc.exp {
+			t.Errorf("%d: expected %#v, got %#v", i, tc.exp, res)
+		}
+	}
+}
+
+func TestColorRamp(t *testing.T) {
+	a := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#000000"))
+	b := lipgloss.NewStyle().Foreground(lipgloss.Color("#ffffff")).Background(lipgloss.Color("#ff0000"))
+
+	ramp := ColorRamp(a, b, 3)
+	exp := []string{
+		`bold: true; foreground: #000000;`,
+		`background: #ff0000; bold: true; foreground: #808080;`,
+		`background: #ff0000; bold: true; foreground: #ffffff;`,
+	}
+	if len(ramp) != len(exp) {
+		t.Fatalf("expected %d styles, got %d", len(exp), len(ramp))
+	}
+	for i, s := range ramp {
+		checkOutput(t, exp[i], Export(s))
+	}
+
+	if r := ColorRamp(a, b, 1); len(r) != 1 || Export(r[0]) != exp[0] {
+		t.Errorf("unexpected single-step ramp: %v", r)
+	}
+	if r := ColorRamp(a, b, 0); r != nil {
+		t.Errorf("expected nil ramp, got %v", r)
+	}
+}
@@ -0,0 +1,77 @@
+// Package logstyle applies styles parsed by lipglossc to the
+// charmbracelet/log package, so that the logger and the rest of the
+// application can share a single theme source.
+package logstyle
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+// styleVars maps style names to the corresponding log style variables.
+var styleVars = map[string]*lipgloss.Style{
+	"timestamp":   &log.TimestampStyle,
+	"caller":      &log.CallerStyle,
+	"prefix":      &log.PrefixStyle,
+	"message":     &log.MessageStyle,
+	"key":         &log.KeyStyle,
+	"value":       &log.ValueStyle,
+	"separator":   &log.SeparatorStyle,
+	"level-debug": &log.DebugLevelStyle,
+	"level-info":  &log.InfoLevelStyle,
+	"level-warn":  &log.WarnLevelStyle,
+	"level-error": &log.ErrorLevelStyle,
+	"level-fatal": &log.FatalLevelStyle,
+}
+
+// keyPrefix is the prefix of the style names that define the style of
+// specific keys, via log.KeyStyles.
+const keyPrefix = "key-"
+
+// Apply overlays the styles whose name starts with the given prefix
+// onto the log package styles. After the prefix, the following names
+// are recognized:
+//
+//	timestamp, caller, prefix, message, key, value, separator,
+//	level-debug, level-info, level-warn, level-error, level-fatal,
+//	key-<name> (style for the log key <name>).
+//
+// For example, with prefix "log.", the style "log.level-info"
+// customizes the INFO level. Other styles are ignored.
+//
+// The properties set in the given styles override those of the
+// current log styles, as per lipglossc.Compose. The level names
+// (e.g. "INFO") are preserved.
+func Apply(styles map[string]lipgloss.Style, prefix string) {
+	for name, s := range styles {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		name = strings.TrimPrefix(name, prefix)
+		if v, ok := styleVars[name]; ok {
+			*v = lipglossc.Compose(*v, s).SetString(v.Value())
+			continue
+		}
+		if strings.HasPrefix(name, keyPrefix) {
+			key := strings.TrimPrefix(name, keyPrefix)
+			log.KeyStyles[key] = lipglossc.Compose(log.KeyStyles[key], s)
+		}
+	}
+}
+
+// Current returns the current log package styles, named as in Apply
+// with the given prefix. This can be used with lipglossc.Export to
+// produce a starting point for a theme.
+func Current(prefix string) map[string]lipgloss.Style {
+	res := make(map[string]lipgloss.Style, len(styleVars)+len(log.KeyStyles))
+	for name, v := range styleVars {
+		res[prefix+name] = *v
+	}
+	for key, s := range log.KeyStyles {
+		res[prefix+keyPrefix+key] = s
+	}
+	return res
+}
@@ -0,0 +1,50 @@
+package logstyle
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	lipglossc "github.com/knz/lipgloss-convert"
+)
+
+func TestApply(t *testing.T) {
+	savedInfo, savedKey := log.InfoLevelStyle, log.KeyStyle
+	defer func() {
+		log.InfoLevelStyle, log.KeyStyle = savedInfo, savedKey
+		delete(log.KeyStyles, "err")
+	}()
+
+	title, _ := lipglossc.Import(lipgloss.NewStyle(), "bold: true")
+	info, _ := lipglossc.Import(lipgloss.NewStyle(), "foreground: 12; max-width: 5")
+	key, _ := lipglossc.Import(lipgloss.NewStyle(), "italic: true")
+	errKey, _ := lipglossc.Import(lipgloss.NewStyle(), "foreground: 9")
+	Apply(map[string]lipgloss.Style{
+		"title":          title,
+		"log.level-info": info,
+		"log.key":        key,
+		"log.key-err":    errKey,
+		"log.unknown":    key,
+	}, "log.")
+
+	if exp, actual := `bold: true; foreground: 12; max-width: 5;`, lipglossc.Export(log.InfoLevelStyle); actual != exp {
+		t.Errorf("expected %q, got %q", exp, actual)
+	}
+	if v := log.InfoLevelStyle.Value(); v != "INFO" {
+		t.Errorf("expected level name to be preserved, got %q", v)
+	}
+	if exp, actual := `faint: true; italic: true;`, lipglossc.Export(log.KeyStyle); actual != exp {
+		t.Errorf("expected %q, got %q", exp, actual)
+	}
+	if exp, actual := `foreground: 9;`, lipglossc.Export(log.KeyStyles["err"]); actual != exp {
+		t.Errorf("expected %q, got %q", exp, actual)
+	}
+
+	cur := Current("log.")
+	if exp, actual := `foreground: 9;`, lipglossc.Export(cur["log.key-err"]); actual != exp {
+		t.Errorf("expected %q, got %q", exp, actual)
+	}
+	if _, ok := cur["log.timestamp"]; !ok {
+		t.Errorf("expected timestamp style in %v", cur)
+	}
+}
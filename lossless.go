@@ -0,0 +1,169 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CheckLossless reports the properties of the style that cannot be
+// represented exactly in the textual format, and would thus be lost
+// or altered by Export followed by Import. This is the case, for
+// example, for colors that use a syntax not recognized by Import,
+// borders containing control characters, or the underlying string
+// set with SetString.
+//
+// The margin background color is not checked, because lipgloss does
+// not provide a way to retrieve it.
+func CheckLossless(s S) []string {
+	var problems []string
+	opt := options{}
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		if isDefault(val) {
+			continue
+		}
+		var buf strings.Builder
+		printValue(&buf, g.name, val, &opt)
+		text := buf.String()
+
+		p, err := getProp(g.name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", g.name, err))
+			continue
+		}
+		s2, err := p.assign(lipgloss.NewStyle(), text, nil)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: value %s cannot be imported: %v", g.name, text, err))
+			continue
+		}
+		val2 := g.getFn.Call([]reflect.Value{reflect.ValueOf(s2)})[0]
+		if !reflect.DeepEqual(val.Interface(), val2.Interface()) {
+			problems = append(problems, fmt.Sprintf("%s: value %#v is exported as %s and imported as %#v",
+				g.name, val.Interface(), text, val2.Interface()))
+		}
+	}
+	if s.Value() != "" {
+		problems = append(problems, fmt.Sprintf("string value %q cannot be exported", s.Value()))
+	}
+	return problems
+}
+
+// construct is a construct of the textual format that Export does not
+// reproduce, with the pattern that detects it once the comments and
+// strings are blanked.
+type construct struct {
+	name string
+	re   *regexp.Regexp
+}
+
+var (
+	reConditional = regexp.MustCompile(`@(dark|light|profile)\b`)
+	reEnvRefStart = regexp.MustCompile(`\$\{`)
+	// reCall matches the function-like values, to detect the calls to
+	// macros.
+	reCall = regexp.MustCompile(`([a-zA-Z_][-a-zA-Z0-9_]*)\(`)
+)
+
+// sheetConstructs are the constructs of stylesheet documents that
+// StyleSheet.Export does not reproduce.
+var sheetConstructs = []construct{
+	{"@import directives", regexp.MustCompile(`@import\b`)},
+	{"@theme sections", regexp.MustCompile(`@theme\b`)},
+	{"@version directives", regexp.MustCompile(`@version\b`)},
+	{"@dark, @light and @profile sections", reConditional},
+	{"mixins", regexp.MustCompile(`@(mixin|include)\b`)},
+	{"@remove directives", regexp.MustCompile(`@remove\b`)},
+	{"extends and apply directives", regexp.MustCompile(`(^|[\s;{])(extends|apply)\s*:`)},
+	{"style references", regexp.MustCompile(`styleref\(|(^|[\s(,:])styles\.`)},
+	{"environment variable references", reEnvRefStart},
+}
+
+// specConstructs are the constructs of style specifications that
+// Export does not reproduce.
+var specConstructs = []construct{
+	{"variables", regexp.MustCompile(`\$[a-zA-Z_]`)},
+	{"environment variable references", reEnvRefStart},
+	{"@dark, @light and @profile sections", reConditional},
+	{"!important markers", regexp.MustCompile(`!important\b`)},
+	{"apply directives", regexp.MustCompile(`(^|[\s;{])apply\s*:`)},
+}
+
+// CheckSpecLossless reports the constructs of a style specification
+// that Import followed by Export does not preserve, so that tools can
+// refuse to rewrite the specification. Export only writes the
+// resulting style: the comments are dropped, the variables, references
+// to environment variables and macro calls are replaced by their
+// values, only the applicable @dark, @light and @profile sections are
+// kept, and the !important markers and apply directives are lost.
+func CheckSpecLossless(input string) []string {
+	return checkConstructs(input, specConstructs)
+}
+
+// CheckSheetLossless reports the constructs of a stylesheet document
+// that ImportSheet followed by StyleSheet.Export does not preserve, so
+// that tools can refuse to rewrite the document. Export only writes the
+// evaluated sheet: the comments are dropped, only the applicable
+// sections are kept, the mixins, extends directives and style
+// references are replaced by the properties they produce, and the
+// wildcard selectors by the styles they matched.
+func CheckSheetLossless(input string) []string {
+	problems := checkConstructs(input, sheetConstructs)
+	if chunks, ok := splitChunks(blankStrings(input)); ok {
+		for _, c := range chunks {
+			if strings.ContainsAny(strings.Join(c.names, ","), "*?[") {
+				problems = append(problems, "wildcard selectors")
+				break
+			}
+		}
+	}
+	return problems
+}
+
+// checkConstructs reports the comments, the given constructs and the
+// calls to macros found in the input.
+func checkConstructs(input string, constructs []construct) []string {
+	var problems []string
+	blanked, _ := blankComments(input)
+	if blanked != input {
+		problems = append(problems, "comments")
+	}
+	input = blankStrings(blanked)
+	for _, c := range constructs {
+		if c.re.MatchString(input) {
+			problems = append(problems, c.name)
+		}
+	}
+	macros.RLock()
+	defer macros.RUnlock()
+	for _, m := range reCall.FindAllStringSubmatch(input, -1) {
+		if _, ok := macros.funcs[m[1]]; ok {
+			problems = append(problems, "macro calls")
+			break
+		}
+	}
+	return problems
+}
+
+// blankStrings replaces the contents of the double-quoted strings in
+// the input by spaces.
+func blankStrings(input string) string {
+	buf := []byte(input)
+	inString := false
+	for i := 0; i < len(buf); i++ {
+		switch {
+		case buf[i] == '"':
+			inString = !inString
+		case inString && buf[i] == '\\' && i+1 < len(buf):
+			buf[i], buf[i+1] = ' ', ' '
+			i++
+		case inString && buf[i] != '\n':
+			buf[i] = ' '
+		}
+	}
+	return string(buf)
+}
@@ -0,0 +1,86 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCheckLossless(t *testing.T) {
+	s := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.AdaptiveColor{Light: "#fff", Dark: "12"}).
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1, 2)
+	if p := CheckLossless(s); len(p) != 0 {
+		t.Errorf("expected no problem, got %v", p)
+	}
+
+	s = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("red")).
+		Background(lipgloss.Color("bogus")).
+		BorderStyle(lipgloss.Border{Top: "\t"}).
+		SetString("hello")
+	exp := []string{
+		`background: value bogus cannot be imported: color not recognized: "bogus"`,
+		`border-style: value border("\t","","","","","","","") cannot be imported: no valid border value found`,
+		`foreground: value "red" is exported as red and imported as "#ff0000"`,
+		`string value "hello" cannot be exported`,
+	}
+	p := CheckLossless(s)
+	if len(p) != len(exp) {
+		t.Fatalf("expected %q, got %q", exp, p)
+	}
+	for i := range exp {
+		if p[i] != exp[i] {
+			t.Errorf("%d: expected:\n%s\ngot:\n%s", i, exp[i], p[i])
+		}
+	}
+}
+
+func TestCheckSheetLossless(t *testing.T) {
+	if p := CheckSheetLossless("$c: #fff;\n$label: \"// @mixin\";\nbold: true;\ntitle { foreground: $c; }\n"); p != nil {
+		t.Errorf("expected no problem, got %v", p)
+	}
+
+	const input = `// The main theme.
+@import "colors.gloss";
+@mixin loud { bold: true; }
+base { padding: 1; }
+title { extends: base; @include loud; }
+help { foreground: styles.title.foreground; }
+@dark { footer { faint: true; } }
+list.* { italic: true; }
+`
+	checkOutput(t, `comments
+@import directives
+@dark, @light and @profile sections
+mixins
+extends and apply directives
+style references
+wildcard selectors`, strings.Join(CheckSheetLossless(input), "\n"))
+}
+
+func TestCheckSpecLossless(t *testing.T) {
+	if p := CheckSpecLossless("bold: true; foreground: \"$x // !important\"; border: rounded"); p != nil {
+		t.Errorf("expected no problem, got %v", p)
+	}
+
+	RegisterMacro("lossless-gutter", func(args []string) (string, error) { return args[0], nil })
+	const input = `/* brand colors */
+$accent: #7D56F4;
+foreground: $accent;
+background: ${APP_BACKGROUND};
+bold: true !important;
+@dark { padding: 0 lossless-gutter(1); }
+apply: base;
+`
+	checkOutput(t, `comments
+variables
+environment variable references
+@dark, @light and @profile sections
+!important markers
+apply directives
+macro calls`, strings.Join(CheckSpecLossless(input), "\n"))
+}
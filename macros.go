@@ -0,0 +1,146 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var macros = struct {
+	sync.RWMutex
+	funcs map[string]func(args []string) (string, error)
+}{funcs: map[string]func(args []string) (string, error){}}
+
+// RegisterMacro defines a function-like value that is expanded by the
+// host application in any spec subsequently imported, for example:
+//
+//	RegisterMacro("gutter", func(args []string) (string, error) {
+//		n, err := strconv.Atoi(args[0])
+//		return strconv.Itoa(n * 2), err
+//	})
+//
+// makes "padding: 0 gutter(2);" valid. The arguments are separated by
+// commas; they are trimmed and unquoted if wrapped in double quotes.
+// Macros in the arguments are expanded first, but the result of a
+// macro is not expanded further. An error from expand aborts the
+// import.
+//
+// The name must be valid as per RegisterConstant, and cannot be the
+// name of a built-in function such as adaptive or border;
+// RegisterMacro panics otherwise. Macros are rejected by WithStrict.
+func RegisterMacro(name string, expand func(args []string) (string, error)) {
+	if !reConstName.MatchString(name) {
+		panic(fmt.Sprintf("invalid macro name: %q", name))
+	}
+	switch name {
+	case "adaptive", "complete", "border", "styleref":
+		panic(fmt.Sprintf("cannot redefine the built-in function %q", name))
+	}
+	macros.Lock()
+	defer macros.Unlock()
+	macros.funcs[name] = expand
+}
+
+// expandMacros replaces the calls to registered macros in the given
+// property value. Quoted strings are left unchanged.
+func (opt *importOptions) expandMacros(args string) (string, error) {
+	if !strings.Contains(args, "(") {
+		return args, nil
+	}
+	// The macros are called without holding the lock, so that they can
+	// import specs or register macros themselves.
+	macros.RLock()
+	funcs := make(map[string]func(args []string) (string, error), len(macros.funcs))
+	for name, fn := range macros.funcs {
+		funcs[name] = fn
+	}
+	macros.RUnlock()
+	if len(funcs) == 0 {
+		return args, nil
+	}
+	return opt.expandMacroCalls(args, funcs)
+}
+
+func (opt *importOptions) expandMacroCalls(args string, funcs map[string]func(args []string) (string, error)) (string, error) {
+	var buf strings.Builder
+	inString := false
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case inString && c == '\\' && i+1 < len(args):
+			buf.WriteByte(c)
+			i++
+			c = args[i]
+		case c == '"':
+			inString = !inString
+		case !inString && isIdentStart(c) && (i == 0 || !isWordByte(args[i-1])):
+			j := i + 1
+			for j < len(args) && isWordByte(args[j]) {
+				j++
+			}
+			name := args[i:j]
+			fn, ok := funcs[name]
+			if !ok || j >= len(args) || args[j] != '(' {
+				buf.WriteString(name)
+				i = j - 1
+				continue
+			}
+			end := matchingParen(args, j)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated call to macro %q", name)
+			}
+			if opt.strict {
+				return "", fmt.Errorf("macro %q not allowed in strict mode", name)
+			}
+			var params []string
+			if inner := strings.TrimSpace(args[j+1 : end]); inner != "" {
+				for _, a := range splitArgs(inner) {
+					a, err := opt.expandMacroCalls(a, funcs)
+					if err != nil {
+						return "", err
+					}
+					params = append(params, unquoteValue(strings.TrimSpace(a)))
+				}
+			}
+			res, err := fn(params)
+			if err != nil {
+				return "", fmt.Errorf("macro %q: %v", name, err)
+			}
+			buf.WriteString(res)
+			i = end
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String(), nil
+}
+
+// isWordByte reports whether c can appear in a word of a property
+// value, e.g. the name of a macro or constant.
+func isWordByte(c byte) bool {
+	return isIdentStart(c) || c == '-' || (c >= '0' && c <= '9')
+}
+
+// matchingParen returns the offset of the parenthesis closing the one
+// at offset open, ignoring those within double-quoted strings, or -1
+// if there is none.
+func matchingParen(s string, open int) int {
+	depth, inString := 0, false
+	for i := open; i < len(s); i++ {
+		switch c := s[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
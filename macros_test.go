@@ -0,0 +1,107 @@
+package lipglossc
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMacros(t *testing.T) {
+	RegisterMacro("theme", func(args []string) (string, error) {
+		if len(args) != 1 {
+			return "", errors.New("expected 1 argument")
+		}
+		switch args[0] {
+		case "accent":
+			return "#7D56F4", nil
+		case "muted":
+			return "8", nil
+		}
+		return "", errors.New("unknown color: " + args[0])
+	})
+	RegisterMacro("gutter", func(args []string) (string, error) {
+		n, err := strconv.Atoi(args[0])
+		return strconv.Itoa(n * 2), err
+	})
+	RegisterMacro("join", func(args []string) (string, error) {
+		return strings.Join(args, ","), nil
+	})
+	defer func() {
+		macros.Lock()
+		defer macros.Unlock()
+		macros.funcs = map[string]func([]string) (string, error){}
+	}()
+
+	td := []struct {
+		in  string
+		out string
+		err string
+	}{
+		{`foreground: theme(accent)`, `foreground: #7D56F4;`, ``},
+		{`padding: 0 gutter(2)`, `padding-left: 4; padding-right: 4;`, ``},
+		{`padding: gutter( 1 ) gutter(gutter(1))`, `padding-bottom: 2; padding-left: 4; padding-right: 4; padding-top: 2;`, ``},
+		{`foreground: adaptive(theme(accent),theme("muted"))`, `foreground: adaptive(#7D56F4,8);`, ``},
+		{`$c: theme(muted); background: $c`, `background: 8;`, ``},
+		{`border-style: border("theme(accent)","b","c","d","e","f","g","h")`, `border-style: border("theme(accent)","b","c","d","e","f","g","h");`, ``},
+		{`foreground: adaptive(join(1,2))`, `foreground: adaptive(1,2);`, ``},
+		{`foreground: theme(nope)`, ``, `in "foreground: theme(nope)": macro "theme": unknown color: nope`},
+		{`foreground: theme()`, ``, `in "foreground: theme()": macro "theme": expected 1 argument`},
+		{`foreground: theme(accent`, ``, `in "foreground: theme(accent": unterminated call to macro "theme"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			s, err := Import(lipgloss.NewStyle(), tc.in)
+			verr := Validate(tc.in)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Errorf("expected error %q, got %v", tc.err, err)
+				}
+				if verr == nil || verr.Error() != tc.err {
+					t.Errorf("expected validation error %q, got %v", tc.err, verr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if verr != nil {
+				t.Errorf("unexpected validation error: %v", verr)
+			}
+			checkOutput(t, tc.out, Export(s))
+		})
+	}
+
+	// Macros can import specs and register macros themselves.
+	RegisterMacro("nested", func(args []string) (string, error) {
+		RegisterMacro("late", func([]string) (string, error) { return "3", nil })
+		s, err := Import(lipgloss.NewStyle(), "foreground: theme("+args[0]+")")
+		if err != nil {
+			return "", err
+		}
+		return string(s.GetForeground().(lipgloss.Color)), nil
+	})
+	s, err := Import(lipgloss.NewStyle(), `foreground: nested(accent); padding-left: late()`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `foreground: #7D56F4; padding-left: 3;`, Export(s))
+
+	if _, err := Import(lipgloss.NewStyle(), `foreground: theme(accent)`, WithStrict()); err == nil ||
+		err.Error() != `in "foreground: theme(accent)": macro "theme" not allowed in strict mode` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"border", "adaptive", "9lives", "a b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q: expected panic", name)
+				}
+			}()
+			RegisterMacro(name, nil)
+		}()
+	}
+}
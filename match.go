@@ -0,0 +1,60 @@
+package lipglossc
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ApplyMatching applies the style specification to every style in
+// the map whose name matches the pattern, and returns the number of
+// styles modified. This is meant for cross-cutting tweaks such as
+// making every title bold:
+//
+//	ApplyMatching(styles, "*.title", "bold: true")
+//
+// Style names and patterns are made of segments separated by periods,
+// e.g. "list.title". A pattern matches names with the same number of
+// segments, where each segment matches as per path.Match; in
+// particular "*" matches any one segment.
+//
+// The specification is validated before any style is modified.
+func ApplyMatching(styles map[string]S, pattern, spec string) (int, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return 0, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+	}
+	if err := Validate(spec); err != nil {
+		return 0, err
+	}
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		if matchName(pattern, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s, err := Import(styles[name], spec)
+		if err != nil {
+			return 0, fmt.Errorf("style %q: %v", name, err)
+		}
+		styles[name] = s
+	}
+	return len(names), nil
+}
+
+// matchName reports whether the style name matches the pattern, segment
+// by segment.
+func matchName(pattern, name string) bool {
+	ps, ns := strings.Split(pattern, "."), strings.Split(name, ".")
+	if len(ps) != len(ns) {
+		return false
+	}
+	for i := range ps {
+		if ok, _ := path.Match(ps[i], ns[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
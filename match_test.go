@@ -0,0 +1,44 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestApplyMatching(t *testing.T) {
+	styles := map[string]S{
+		"list.title":   lipgloss.NewStyle(),
+		"list.item":    lipgloss.NewStyle().Faint(true),
+		"dialog.title": lipgloss.NewStyle(),
+		"title":        lipgloss.NewStyle(),
+	}
+	n, err := ApplyMatching(styles, "*.title", "bold: true")
+	if err != nil || n != 2 {
+		t.Fatalf("unexpected result: %d, %v", n, err)
+	}
+	n, err = ApplyMatching(styles, "list.*", "italic: true")
+	if err != nil || n != 2 {
+		t.Fatalf("unexpected result: %d, %v", n, err)
+	}
+	for name, exp := range map[string]string{
+		"list.title":   `bold: true; italic: true;`,
+		"list.item":    `faint: true; italic: true;`,
+		"dialog.title": `bold: true;`,
+		"title":        ``,
+	} {
+		if res := Export(styles[name]); res != exp {
+			t.Errorf("%s: expected %q, got %q", name, exp, res)
+		}
+	}
+
+	if n, err := ApplyMatching(styles, "*", "bold: maybe"); err == nil || n != 0 || err.Error() != `in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected result: %d, %v", n, err)
+	}
+	if Export(styles["title"]) != "" {
+		t.Errorf("style modified by invalid spec")
+	}
+	if _, err := ApplyMatching(styles, "[", "bold: true"); err == nil || err.Error() != `invalid pattern "[": syntax error in pattern` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package lipglossc
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics receives instrumentation events from the converter, so
+// that applications can wire it into their metrics system (expvar,
+// Prometheus, etc.). See also Counters.
+//
+// The methods can be called concurrently and must not block.
+type Metrics interface {
+	// ImportDone is called after each call to Import, with the time
+	// spent, the number of directives in the input and the resulting
+	// error, if any.
+	ImportDone(d time.Duration, directives int, err error)
+	// PropertyLookup is called every time a property name is resolved
+	// to a lipgloss method; cached is false when the name was not
+	// resolved before.
+	PropertyLookup(cached bool)
+}
+
+var metrics atomic.Value // metricsHolder
+
+// metricsHolder wraps Metrics so that atomic.Value always stores the
+// same concrete type.
+type metricsHolder struct{ m Metrics }
+
+// SetMetrics installs the given instrumentation hooks. Use nil to
+// disable instrumentation, which is the default.
+func SetMetrics(m Metrics) {
+	metrics.Store(metricsHolder{m})
+}
+
+func loadMetrics() Metrics {
+	h, _ := metrics.Load().(metricsHolder)
+	return h.m
+}
+
+// Counters is a simple implementation of Metrics that accumulates
+// counters. Its fields must be accessed atomically, e.g. with
+// atomic.LoadInt64, for example in an expvar.Func.
+type Counters struct {
+	// Imports is the number of calls to Import.
+	Imports int64
+	// ImportErrors is the number of calls to Import that failed.
+	ImportErrors int64
+	// ImportNanos is the total time spent in Import, in nanoseconds.
+	ImportNanos int64
+	// Directives is the total number of directives imported.
+	Directives int64
+	// CacheHits and CacheMisses count the property lookups.
+	CacheHits   int64
+	CacheMisses int64
+}
+
+var _ Metrics = (*Counters)(nil)
+
+// ImportDone implements Metrics.
+func (c *Counters) ImportDone(d time.Duration, directives int, err error) {
+	atomic.AddInt64(&c.Imports, 1)
+	atomic.AddInt64(&c.ImportNanos, int64(d))
+	atomic.AddInt64(&c.Directives, int64(directives))
+	if err != nil {
+		atomic.AddInt64(&c.ImportErrors, 1)
+	}
+}
+
+// PropertyLookup implements Metrics.
+func (c *Counters) PropertyLookup(cached bool) {
+	if cached {
+		atomic.AddInt64(&c.CacheHits, 1)
+	} else {
+		atomic.AddInt64(&c.CacheMisses, 1)
+	}
+}
+
+// CacheHitRate returns the ratio of property lookups served from the
+// cache, between 0 and 1.
+func (c *Counters) CacheHitRate() float64 {
+	hits := atomic.LoadInt64(&c.CacheHits)
+	total := hits + atomic.LoadInt64(&c.CacheMisses)
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
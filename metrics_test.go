@@ -0,0 +1,43 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMetrics(t *testing.T) {
+	var c Counters
+	SetMetrics(&c)
+	defer SetMetrics(nil)
+
+	if _, err := Import(lipgloss.NewStyle(), `bold: true; uncached-property-xyz: 1`); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := Import(lipgloss.NewStyle(), `bold: false; italic: true; bold: true`); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Imports != 2 || c.ImportErrors != 1 || c.Directives != 5 {
+		t.Errorf("unexpected counters: %+v", c)
+	}
+	if c.ImportNanos <= 0 {
+		t.Errorf("expected import time, got %d", c.ImportNanos)
+	}
+	// The bold property is looked up 3 times: it is cached at least
+	// for the last 2. The unknown property is never cached.
+	if c.CacheHits < 2 || c.CacheMisses < 1 || c.CacheHits+c.CacheMisses != 5 {
+		t.Errorf("unexpected cache counters: %+v", c)
+	}
+	if r := c.CacheHitRate(); r <= 0 || r >= 1 {
+		t.Errorf("unexpected hit rate: %v", r)
+	}
+
+	SetMetrics(nil)
+	if _, err := Import(lipgloss.NewStyle(), `bold: true`); err != nil {
+		t.Fatal(err)
+	}
+	if c.Imports != 2 {
+		t.Errorf("expected metrics to be disabled")
+	}
+}
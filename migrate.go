@@ -0,0 +1,185 @@
+package lipglossc
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Migration describes renamings to apply to stylesheet documents with
+// MigrateSheet.
+type Migration struct {
+	// Styles maps old style names to new ones.
+	Styles map[string]string
+	// Properties maps old property names to new ones.
+	Properties map[string]string
+}
+
+// MigrateSheet applies the renamings to a stylesheet document, as
+// accepted by ImportSheet, and returns the resulting document. Only
+// the names are changed: the formatting, comments and order of the
+// document are preserved. This supports renaming components in large
+// applications.
+//
+// Style names are renamed in block names, extends and apply
+// directives, and styleref() and styles.xxx references; names with
+// wildcards are left unchanged. Property names are renamed in the
+// blocks and mixins.
+func MigrateSheet(input string, m Migration) (string, error) {
+	var buf strings.Builder
+	p := sheetParser{input: input, line: 1}
+	for {
+		start := p.pos
+		p.skipSpace()
+		buf.WriteString(input[start:p.pos])
+		if p.pos >= len(input) {
+			return buf.String(), nil
+		}
+
+		start = p.pos
+		rest := input[p.pos:]
+		if strings.HasPrefix(rest, "@import") || strings.HasPrefix(rest, "@remove") || strings.HasPrefix(rest, "@define-border") || rest[0] == '$' {
+			// Directives outside of blocks are kept as-is, except for
+			// the names of the removed styles.
+			i := indexOutsideStrings(rest, "{};")
+			if i < 0 || rest[i] != ';' {
+				return "", p.errorf(p.line, "expected \";\"")
+			}
+			p.advance(i + 1)
+			a := input[start:p.pos]
+			if strings.HasPrefix(a, "@remove") {
+				a = "@remove" + m.renameSelectors(a[len("@remove"):len(a)-1]) + ";"
+			}
+			buf.WriteString(a)
+			continue
+		}
+
+		i := strings.IndexAny(rest, "{};")
+		if i < 0 || rest[i] != '{' {
+			return "", p.errorf(p.line, "expected style name followed by \"{\"")
+		}
+		header := rest[:i]
+		kw := strings.TrimSpace(header)
+		if !strings.HasPrefix(kw, "@") {
+			header = m.renameSelectors(header)
+		}
+		buf.WriteString(header)
+		buf.WriteByte('{')
+		p.advance(i + 1)
+
+		body, err := p.block([]string{strings.TrimSpace(rest[:i])})
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case kw == "@palette" || kw == "@theme":
+			// The section does not contain properties.
+		case kw == "@dark" || kw == "@light" || strings.HasPrefix(kw, "@profile"):
+			// The section contains blocks.
+			body, err = MigrateSheet(body, m)
+			if err != nil {
+				return "", err
+			}
+		default:
+			body = m.renameBody(body)
+		}
+		buf.WriteString(body)
+		buf.WriteByte('}')
+	}
+}
+
+// renameSelectors renames the comma-separated style names, keeping
+// the surrounding spaces.
+func (m Migration) renameSelectors(text string) string {
+	parts := strings.Split(text, ",")
+	for i, part := range parts {
+		name := strings.TrimSpace(part)
+		if n, ok := m.renameStyle(name); ok {
+			j := strings.Index(part, name)
+			parts[i] = part[:j] + n + part[j+len(name):]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// renameBody renames the properties and style references in the
+// directives of a block.
+func (m Migration) renameBody(body string) string {
+	directives := splitOutsideStrings(body, ";")
+	for i, a := range directives {
+		j := strings.IndexByte(a, ':')
+		if j < 0 {
+			continue
+		}
+		key, value := a[:j], a[j+1:]
+		if k := strings.TrimSpace(key); k == "extends" || k == "apply" {
+			value = reWord.ReplaceAllStringFunc(value, func(name string) string {
+				n, _ := m.renameStyle(name)
+				return n
+			})
+		} else {
+			key = replaceTrimmed(key, m.Properties)
+			value = reStyleRef.ReplaceAllStringFunc(value, func(ref string) string {
+				target, ok := m.renameRef(reStyleRef.FindStringSubmatch(ref)[1])
+				if !ok {
+					return ref
+				}
+				return "styleref(" + target + ")"
+			})
+			value = reStylesRef.ReplaceAllStringFunc(value, func(ref string) string {
+				sm := reStylesRef.FindStringSubmatch(ref)
+				target, ok := m.renameRef(sm[2])
+				if !ok {
+					return ref
+				}
+				return sm[1] + "styles." + target
+			})
+		}
+		directives[i] = key + ":" + value
+	}
+	return strings.Join(directives, ";")
+}
+
+// renameRef renames the style and property of a <style>.<property>
+// reference. It returns false if the reference is invalid.
+func (m Migration) renameRef(target string) (string, bool) {
+	k := strings.LastIndexByte(target, '.')
+	if k < 0 {
+		return "", false
+	}
+	name, prop := target[:k], target[k+1:]
+	name, _ = m.renameStyle(name)
+	if n, ok := m.Properties[prop]; ok {
+		prop = n
+	}
+	return name + "." + prop, true
+}
+
+// renameStyle returns the new name of a style, and whether it is
+// renamed. The variants of a renamed style, e.g. "button:focused", are
+// renamed too.
+func (m Migration) renameStyle(name string) (string, bool) {
+	if n, ok := m.Styles[name]; ok {
+		return n, true
+	}
+	if i := strings.IndexByte(name, ':'); i > 0 {
+		if n, ok := m.Styles[name[:i]]; ok {
+			return n + name[i:], true
+		}
+	}
+	return name, false
+}
+
+// reWord matches the space-separated words in a value.
+var reWord = regexp.MustCompile(`\S+`)
+
+// replaceTrimmed replaces s by its renaming, if any, keeping the
+// spaces around it.
+func replaceTrimmed(s string, names map[string]string) string {
+	name := strings.TrimSpace(s)
+	n, ok := names[name]
+	if !ok {
+		return s
+	}
+	i := strings.Index(s, name)
+	return s[:i] + n + s[i+len(name):]
+}
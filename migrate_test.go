@@ -0,0 +1,56 @@
+package lipglossc
+
+import "testing"
+
+func TestMigrateSheet(t *testing.T) {
+	const input = `$accent: #7D56F4;
+@define-border braces("{","}",";",";","+","+","+","+");
+@mixin emphasized { bold: true;  colour-fg: red; }
+
+list.title ,  dialog.title {
+    padding:  0 1;
+    colour-fg: $accent;
+}
+status-line { extends: list.title  base; border-top-foreground: styleref(list.title.colour-fg) }
+footer { foreground: styles.status-line.colour-fg; padding: 0 styles.list.title.padding-left }
+help { apply: status-line; faint: true }
+list.title:focused { extends: list.title; foreground: styles.list.title:focused.colour-fg }
+*.title { italic: true }
+box { $chars: "; colour-fg: x"; colour-fg: red }
+`
+	m := Migration{
+		Styles:     map[string]string{"list.title": "list.heading", "status-line": "status-bar"},
+		Properties: map[string]string{"colour-fg": "foreground"},
+	}
+	res, err := MigrateSheet(input, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `$accent: #7D56F4;
+@define-border braces("{","}",";",";","+","+","+","+");
+@mixin emphasized { bold: true;  foreground: red; }
+
+list.heading ,  dialog.title {
+    padding:  0 1;
+    foreground: $accent;
+}
+status-bar { extends: list.heading  base; border-top-foreground: styleref(list.heading.foreground) }
+footer { foreground: styles.status-bar.foreground; padding: 0 styles.list.heading.padding-left }
+help { apply: status-bar; faint: true }
+list.heading:focused { extends: list.heading; foreground: styles.list.heading:focused.foreground }
+*.title { italic: true }
+box { $chars: "; colour-fg: x"; foreground: red }
+`, res)
+
+	if _, err := MigrateSheet("a { bold: true }\nb { bold: true", m); err == nil || err.Error() != `line 2: unterminated block for "b"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMigrateSheetConditional(t *testing.T) {
+	res, err := MigrateSheet("@dark {\n  title { bold: true }\n}\n@remove footer, title;\n", Migration{Styles: map[string]string{"title": "heading"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "@dark {\n  heading { bold: true }\n}\n@remove footer, heading;\n", res)
+}
@@ -0,0 +1,62 @@
+package lipglossc
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Minimize returns the smallest specification equivalent to spec,
+// when applied to a new style: the directives that have no effect on
+// the result are dropped, e.g. those whose value is the lipgloss
+// default ("bold: false") or that are overridden by later directives.
+// The other directives are kept as written, so that shorthands such as
+// "padding: 1" and references to variables are preserved. Comments are
+// removed and nested blocks are flattened.
+//
+// The options are those of Import. The conditional blocks, e.g. @dark,
+// are resolved as per Import.
+func Minimize(spec string, opts ...ImportOption) (string, error) {
+	opt := makeImportOptions(opts)
+	input, err := preprocess(spec, opt.sep, &opt)
+	if err != nil {
+		return "", err
+	}
+	directives := splitAssignments(input, opt.sep)
+	// The conditional blocks are resolved already.
+	opts = append(opts[:len(opts):len(opts)], WithImportSeparator(opt.sep))
+	apply := func(directives []string) ([]interface{}, error) {
+		s, err := Import(lipgloss.NewStyle(), strings.Join(directives, opt.sep), opts...)
+		if err != nil {
+			return nil, err
+		}
+		return propValues(s), nil
+	}
+	expected, err := apply(directives)
+	if err != nil {
+		return "", err
+	}
+
+	// Try to remove each directive, starting with the last ones since
+	// directives are more often overridden by later ones. Removing a
+	// directive can make an earlier one useless, e.g. "bold: true;
+	// bold: false", so repeat until no more can be removed.
+	for changed := true; changed; {
+		changed = false
+		for i := len(directives) - 1; i >= 0; i-- {
+			candidate := append(directives[:i:i], directives[i+1:]...)
+			if vals, err := apply(candidate); err == nil && reflect.DeepEqual(vals, expected) {
+				directives, changed = candidate, true
+			}
+		}
+	}
+
+	if opt.sep == ";" {
+		if len(directives) == 0 {
+			return "", nil
+		}
+		return strings.Join(directives, "; ") + ";", nil
+	}
+	return strings.Join(directives, opt.sep), nil
+}
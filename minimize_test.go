@@ -0,0 +1,44 @@
+package lipglossc
+
+import "testing"
+
+func TestMinimize(t *testing.T) {
+	td := []struct {
+		in, exp string
+	}{
+		{`bold: false; italic: true`, `italic: true;`},
+		{`foreground: red; bold: true; foreground: #00f`, `bold: true; foreground: #00f;`},
+		{`padding: 1; padding-top: 0 // comment`, `padding: 1; padding-top: 0;`},
+		{`padding: 0; border-style: hidden; border-style: normal`, `border-style: normal;`},
+		{`$accent: #7D56F4; $unused: red; foreground: $accent;`, `$accent: #7D56F4; foreground: $accent;`},
+		{`bold: true; clear; faint: true`, `faint: true;`},
+		{`foreground: red !important; foreground: blue`, `foreground: red !important;`},
+		{`border { top: true; top: false }`, ``},
+		{`align: 0; width: 0; `, ``},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			res, err := Minimize(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkOutput(t, tc.exp, res)
+		})
+	}
+
+	res, err := Minimize("bold: false\nfaint: true\n", WithImportSeparator("\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "faint: true", res)
+
+	res, err = Minimize(`@dark { bold: true } @light { faint: true }`, WithBackgroundMode(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "faint: true;", res)
+
+	if _, err := Minimize(`bold: maybe`); err == nil || err.Error() != `in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
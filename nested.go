@@ -0,0 +1,94 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expandNested rewrites the nested property blocks in the input into
+// flat directives, for example:
+//
+//	border { top: true; style: rounded; }
+//
+// becomes "border-top: true; border-style: rounded;". Blocks can be
+// nested further. The directives keep their surrounding spaces and
+// the newlines of the block headers are preserved, so that the line
+// numbers of the directives do not change. Braces within
+// double-quoted strings do not count.
+//
+// The @dark, @light and @profile blocks are conditional: their
+// directives are kept only if their condition holds for opt. If opt is
+// nil, the directives of all the conditional blocks are kept.
+func expandNested(input, sep string, opt *importOptions) (string, error) {
+	if !strings.ContainsAny(input, "{}") {
+		return input, nil
+	}
+	type frame struct {
+		name   string
+		prefix string
+		skip   bool
+	}
+	var frames []frame
+	cur := frame{}
+
+	var buf strings.Builder
+	emit := func(d string) {
+		if cur.skip {
+			buf.WriteString(strings.Repeat("\n", strings.Count(d, "\n")))
+			return
+		}
+		trimmed := strings.TrimLeft(d, " \t\r\n")
+		buf.WriteString(d[:len(d)-len(trimmed)])
+		if strings.TrimSpace(trimmed) != "" {
+			buf.WriteString(cur.prefix)
+		}
+		buf.WriteString(trimmed)
+	}
+	start := 0
+	inString := false
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case strings.HasPrefix(input[i:], sep):
+			emit(input[start:i])
+			buf.WriteString(sep)
+			i += len(sep) - 1
+			start = i + 1
+		case c == '{':
+			name := strings.TrimSpace(input[start:i])
+			if !strings.HasPrefix(name, "@") && (name == "" || strings.ContainsAny(name, ": \t\r\n")) {
+				return "", fmt.Errorf("invalid syntax: %q", strings.TrimSpace(input[start:i+1]))
+			}
+			buf.WriteString(strings.Repeat("\n", strings.Count(input[start:i], "\n")))
+			frames = append(frames, cur)
+			cur.name = name
+			if strings.HasPrefix(name, "@") {
+				c, err := parseCondition(name)
+				if err != nil {
+					return "", err
+				}
+				cur.skip = cur.skip || (opt != nil && !opt.holds(c))
+			} else {
+				cur.prefix += name + "-"
+			}
+			start = i + 1
+		case c == '}':
+			if len(frames) == 0 {
+				return "", fmt.Errorf("invalid syntax: unexpected \"}\"")
+			}
+			emit(input[start:i])
+			buf.WriteString(sep)
+			cur, frames = frames[len(frames)-1], frames[:len(frames)-1]
+			start = i + 1
+		}
+	}
+	if len(frames) > 0 {
+		return "", fmt.Errorf("invalid syntax: unterminated block for %q", cur.name)
+	}
+	emit(input[start:])
+	return buf.String(), nil
+}
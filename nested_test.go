@@ -0,0 +1,197 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestImportNested(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `
+bold: true;
+border { style: rounded; top: true; foreground: 12; }
+padding { left: 2; right: 1 }
+border { bottom { foreground: 13 } }
+italic: true`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true;
+border-bottom-foreground: 13;
+border-left-foreground: 12;
+border-right-foreground: 12;
+border-style: border("─","─","│","│","╭","╮","╯","╰");
+border-top: true;
+border-top-foreground: 12;
+italic: true;
+padding-left: 2;
+padding-right: 1;`, Export(s, WithSeparator("\n")))
+
+	// Nesting also works with other separators.
+	s, err = Import(lipgloss.NewStyle(), "padding {\n  top: 1\n  bottom: 2\n}\nfaint: true", WithImportSeparator("\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `faint: true; padding-bottom: 2; padding-top: 1;`, Export(s))
+}
+
+func TestImportNestedErrors(t *testing.T) {
+	td := []struct {
+		in     string
+		expErr string
+	}{
+		{`border { top: true`, `invalid syntax: unterminated block for "border"`},
+		{`bold: true }`, `invalid syntax: unexpected "}"`},
+		{`{ bold: true }`, `invalid syntax: "{"`},
+		{`border: { top: true }`, `invalid syntax: "border: {"`},
+		{`padding { up: 1 }`, `in "padding-up: 1": property not supported: "padding-up"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			_, err := Import(lipgloss.NewStyle(), tc.in)
+			if err == nil || err.Error() != tc.expErr {
+				t.Errorf("expected %q, got %v", tc.expErr, err)
+			}
+			if err := Validate(tc.in); err == nil || err.Error() != tc.expErr {
+				t.Errorf("Validate: expected %q, got %v", tc.expErr, err)
+			}
+		})
+	}
+}
+
+func TestNestedSourceMap(t *testing.T) {
+	_, sm, err := ImportWithSourceMap(lipgloss.NewStyle(), "bold: true;\nborder {\n  top: true;\n  style: rounded;\n}\n", "a.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos := sm["border-style"]; pos.Line != 4 {
+		t.Errorf("expected line 4, got %v", pos)
+	}
+}
+
+func TestImportSheetNested(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `box { border { style: normal; left: true } padding { left: 1 } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("box")
+	checkOutput(t, `border-left: true; border-style: border("─","─","│","│","┌","┐","┘","└"); padding-left: 1;`, Export(s))
+}
+
+func TestImportConditional(t *testing.T) {
+	const spec = `foreground: 12; @dark { bold: true; border { style: rounded } } @light { faint: true }`
+	s, err := Import(lipgloss.NewStyle(), spec, WithBackgroundMode(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; border-style: border("─","─","│","│","╭","╮","╯","╰"); foreground: 12;`, Export(s))
+
+	s, err = Import(lipgloss.NewStyle(), spec, WithBackgroundMode(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `faint: true; foreground: 12;`, Export(s))
+
+	// Validate checks both branches.
+	if err := Validate(`@dark { bold: true } @light { bold: maybe }`); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := Import(lipgloss.NewStyle(), `@dim { bold: true }`); err == nil || err.Error() != `unknown block: "@dim"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	c := NewConverter(WithDarkBackground(false))
+	s, err = c.Import(lipgloss.NewStyle(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `faint: true; foreground: 12;`, Export(s))
+}
+
+func TestImportSheetConditional(t *testing.T) {
+	const sheet = `
+title { bold: true; }
+@dark {
+  title { foreground: #fafafa; }
+  footer { faint: true; }
+}
+@light { title { foreground: #111; } }
+footer { @light { italic: true } }
+`
+	ss, err := ImportSheet(StyleSheet{}, sheet, WithBackgroundMode(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("title")
+	checkOutput(t, `bold: true; foreground: #fafafa;`, Export(s))
+	s, _ = ss.Get("footer")
+	checkOutput(t, `faint: true;`, Export(s))
+
+	ss, err = ImportSheet(StyleSheet{}, sheet, WithBackgroundMode(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ = ss.Get("title")
+	checkOutput(t, `bold: true; foreground: #111;`, Export(s))
+	s, _ = ss.Get("footer")
+	checkOutput(t, `italic: true;`, Export(s))
+
+	_, err = ImportSheet(StyleSheet{}, "@dark {\n  title { bold: maybe }\n}", WithBackgroundMode(true))
+	if err == nil || err.Error() != `line 2: style "title": in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err = ImportSheet(StyleSheet{}, "@dark { title { bold: true }", WithBackgroundMode(true))
+	if err == nil || err.Error() != `line 1: unterminated block for "@dark"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImportProfile(t *testing.T) {
+	const spec = `foreground: #7D56F4; @profile 16 ascii { foreground: 5; bold: true } @profile truecolor { italic: true }`
+	td := []struct {
+		p   termenv.Profile
+		exp string
+	}{
+		{termenv.TrueColor, `foreground: #7D56F4; italic: true;`},
+		{termenv.ANSI256, `foreground: #7D56F4;`},
+		{termenv.ANSI, `bold: true; foreground: 5;`},
+		{termenv.Ascii, `bold: true; foreground: 5;`},
+	}
+	for _, tc := range td {
+		s, err := Import(lipgloss.NewStyle(), spec, WithImportProfile(tc.p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkOutput(t, tc.exp, Export(s))
+	}
+
+	c := NewConverter(WithColorProfile(termenv.ANSI))
+	s, err := c.Import(lipgloss.NewStyle(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; foreground: 5;`, Export(s))
+
+	for in, expErr := range map[string]string{
+		`@profile { bold: true }`:     `missing color profile in "@profile"`,
+		`@profile 8 { bold: true }`:   `unknown color profile: "8" (expected truecolor, 256, 16 or ascii)`,
+		`@dark light { bold: true }`:  `unknown block: "@dark light"`,
+		`@profile 16 { bold: maybe }`: `in "bold: maybe": no value found; bold expects true or false`,
+	} {
+		if err := Validate(in); err == nil || err.Error() != expErr {
+			t.Errorf("%s: expected %q, got %v", in, expErr, err)
+		}
+	}
+
+	ss, err := ImportSheet(StyleSheet{}, "title { foreground: #7D56F4 }\n@profile 256 16 {\n  title { foreground: 99 }\n}", WithImportProfile(termenv.ANSI256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ = ss.Get("title")
+	checkOutput(t, `foreground: 99;`, Export(s))
+	_, err = ImportSheet(StyleSheet{}, "\n@profile 8 { title { bold: true } }")
+	if err == nil || err.Error() != `line 2: unknown color profile: "8" (expected truecolor, 256, 16 or ascii)` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteTolerance is the maximum euclidean distance in RGB space
+// between two hex colors for ExtractPalette to merge them into the
+// same palette entry.
+const paletteTolerance = 8
+
+// ExtractPalette collects the distinct colors used across the
+// styles, including the variants of adaptive and complete colors. It
+// merges the hex colors that are nearly identical, and proposes a name
+// for each resulting palette entry. This is a refactoring aid for
+// themes that grew organically.
+//
+// The palette maps the proposed names to colors: hex colors are named
+// after the nearest X11/CSS color, e.g. "slateblue", and ANSI colors
+// as "ansi" followed by their index. Among the colors merged into an
+// entry, the one used most often is retained.
+//
+// The sheet is a stylesheet document, suitable for ImportSheet, that
+// defines the palette entries as variables and rewrites the styles to
+// refer to them.
+func ExtractPalette(styles map[string]S) (palette map[string]string, sheet string) {
+	// Count the uses of each color.
+	uses := map[string]int{}
+	for _, s := range styles {
+		v := reflect.ValueOf(s)
+		for _, g := range styleGetters {
+			val := g.getFn.Call([]reflect.Value{v})[0]
+			if val.Type().Name() != "TerminalColor" || isDefault(val) {
+				continue
+			}
+			for _, c := range colorVariants(val.Interface().(lipgloss.TerminalColor)) {
+				uses[c]++
+			}
+		}
+	}
+	colors := make([]string, 0, len(uses))
+	for c := range uses {
+		colors = append(colors, c)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if uses[colors[i]] != uses[colors[j]] {
+			return uses[colors[i]] > uses[colors[j]]
+		}
+		return colors[i] < colors[j]
+	})
+
+	// Assign each color to the first close enough entry, in order of
+	// decreasing use.
+	palette = map[string]string{}
+	refs := map[string]string{}
+	var entries []string
+	for _, c := range colors {
+		name := ""
+		for _, e := range entries {
+			if closeColors(palette[e], c) {
+				name = e
+				break
+			}
+		}
+		if name == "" {
+			name = paletteName(c, palette)
+			palette[name] = c
+			entries = append(entries, name)
+		}
+		refs[c] = "$" + name
+	}
+
+	// Rewrite the styles.
+	ss := StyleSheet{styles: styles}
+	return palette, ss.Export(WithSeparator("\n"), WithVariables(palette), withColorRefs(refs))
+}
+
+// withColorRefs replaces the colors by references to variables in
+// Export.
+func withColorRefs(refs map[string]string) ExportOption {
+	return func(e *options) {
+		e.colorRefs = refs
+	}
+}
+
+// colorVariants returns the colors that make up a terminal color.
+func colorVariants(tc lipgloss.TerminalColor) []string {
+	switch tc := tc.(type) {
+	case lipgloss.Color:
+		return []string{string(tc)}
+	case lipgloss.AdaptiveColor:
+		return []string{tc.Light, tc.Dark}
+	case lipgloss.CompleteColor:
+		return []string{tc.TrueColor, tc.ANSI256, tc.ANSI}
+	case lipgloss.CompleteAdaptiveColor:
+		return []string{
+			tc.Light.TrueColor, tc.Light.ANSI256, tc.Light.ANSI,
+			tc.Dark.TrueColor, tc.Dark.ANSI256, tc.Dark.ANSI,
+		}
+	}
+	return nil
+}
+
+// closeColors reports whether two colors can share a palette entry.
+// Only hex colors are merged; other colors must be identical.
+func closeColors(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ar, ag, ab, ok := parseHex(a)
+	if !ok {
+		return false
+	}
+	br, bg, bb, ok := parseHex(b)
+	if !ok {
+		return false
+	}
+	return (ar-br)*(ar-br)+(ag-bg)*(ag-bg)+(ab-bb)*(ab-bb) <= paletteTolerance*paletteTolerance
+}
+
+// paletteName proposes a name for a color that is not already in the
+// palette.
+func paletteName(c string, palette map[string]string) string {
+	base := "color"
+	if r, g, b, ok := parseHex(c); ok {
+		bestDist := -1
+		for _, nc := range namedColors {
+			cr, cg, cb, _ := parseHex(nc.hex)
+			dist := (r-cr)*(r-cr) + (g-cg)*(g-cg) + (b-cb)*(b-cb)
+			if bestDist < 0 || dist < bestDist {
+				base, bestDist = nc.name, dist
+			}
+		}
+	} else if _, _, _, ok := colorRGB(c); ok {
+		base = "ansi" + c
+	}
+	name := base
+	for i := 2; palette[name] != ""; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return name
+}
@@ -0,0 +1,70 @@
+package lipglossc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestExtractPalette(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+title { bold: true; foreground: #7D56F4; background: adaptive(#fafafa, #1a1a1a) }
+help { foreground: #7c57f3; border-top-foreground: 5 }
+footer { foreground: #7D56F4; faint: true }
+status { background: #fbfbfb; border-top-foreground: #7b68ee }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	palette, sheet := ExtractPalette(ss.Styles())
+	expPalette := map[string]string{
+		"mediumslateblue":   "#7D56F4",
+		"mediumslateblue-2": "#7b68ee",
+		"ansi5":             "5",
+		"snow":              "#fafafa",
+		"black":             "#1a1a1a",
+	}
+	if !reflect.DeepEqual(palette, expPalette) {
+		t.Errorf("unexpected palette: %v", palette)
+	}
+	checkOutput(t, `$ansi5: 5;
+$black: #1a1a1a;
+$mediumslateblue: #7D56F4;
+$mediumslateblue-2: #7b68ee;
+$snow: #fafafa;
+
+footer {
+  faint: true;
+  foreground: $mediumslateblue;
+}
+
+help {
+  border-top-foreground: $ansi5;
+  foreground: $mediumslateblue;
+}
+
+status {
+  background: $snow;
+  border-top-foreground: $mediumslateblue-2;
+}
+
+title {
+  background: adaptive($snow,$black);
+  bold: true;
+  foreground: $mediumslateblue;
+}
+`, sheet)
+
+	// The sheet is valid and refers to the palette.
+	res, err := ImportSheet(StyleSheet{}, sheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := res.Get("help")
+	checkOutput(t, `border-top-foreground: 5; foreground: #7D56F4;`, Export(s))
+
+	if palette, sheet := ExtractPalette(map[string]S{"a": lipgloss.NewStyle().Bold(true)}); len(palette) != 0 || sheet != "a {\n  bold: true;\n}\n" {
+		t.Errorf("unexpected result: %v %q", palette, sheet)
+	}
+}
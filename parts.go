@@ -0,0 +1,43 @@
+package lipglossc
+
+// ExportParts is like Export, but splits the specification by
+// category of properties, keyed by the names of the clear-xxx
+// keywords: "text", "colors", "border" and "layout". This makes it
+// possible to store or expose partial customization surfaces, for
+// example to let users edit only the colors of a theme. The
+// categories without any property are omitted.
+//
+// Importing all the parts, in any order, reproduces the style.
+// Properties that belong to no category, e.g. added in a later version
+// of lipgloss, are reported under "other".
+func ExportParts(s S, opts ...ExportOption) map[string]string {
+	opt := makeExportOptions(opts)
+	if opt.shellQuote {
+		opt.sep = " "
+	}
+	category := map[string]string{}
+	for cat, props := range propCategories {
+		for _, name := range props {
+			category[name] = cat
+		}
+	}
+
+	defs := exportVariables(&opt)
+	parts := map[string][]propValue{}
+	for _, pv := range exportProps(s, &opt) {
+		cat, ok := category[pv.name]
+		if !ok {
+			cat = "other"
+		}
+		parts[cat] = append(parts[cat], pv)
+	}
+	res := make(map[string]string, len(parts))
+	for cat, props := range parts {
+		spec := printDirectives(defs, append(defs, props...), &opt)
+		if opt.shellQuote {
+			spec = shellQuote(spec)
+		}
+		res[cat] = spec
+	}
+	return res
+}
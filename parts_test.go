@@ -0,0 +1,44 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestExportParts(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `bold: true; foreground: #fafafa; border: rounded; border-top-foreground: 12; padding: 0 1; width: 20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := ExportParts(s)
+	exp := map[string]string{
+		"text":   `bold: true;`,
+		"colors": `border-top-foreground: 12; foreground: #fafafa;`,
+		"border": `border-bottom: true; border-left: true; border-right: true; border-style: border("─","─","│","│","╭","╮","╯","╰"); border-top: true;`,
+		"layout": `padding-left: 1; padding-right: 1; width: 20;`,
+	}
+	if len(parts) != len(exp) {
+		t.Errorf("expected %d parts, got %v", len(exp), parts)
+	}
+	for cat, e := range exp {
+		checkOutput(t, e, parts[cat])
+	}
+
+	// Importing the parts reproduces the style.
+	res := lipgloss.NewStyle()
+	for _, p := range parts {
+		if res, err = Import(res, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkOutput(t, Export(s), Export(res))
+
+	// The options of Export apply to each part.
+	parts = ExportParts(s, WithHexCase(HexUpper), WithSeparator("\n"))
+	checkOutput(t, "border-top-foreground: 12;\nforeground: #FAFAFA;", parts["colors"])
+
+	if parts := ExportParts(lipgloss.NewStyle()); len(parts) != 0 {
+		t.Errorf("expected no parts, got %v", parts)
+	}
+}
@@ -0,0 +1,119 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Patch is an ordered list of operations that transform a style.
+// A patch can be computed with Diff, stored as text with
+// Patch.String and ParsePatch, and replayed with ApplyPatch.
+type Patch []PatchOp
+
+// PatchOp is a single operation in a Patch.
+type PatchOp struct {
+	// Kind is the type of operation.
+	Kind PatchOpKind
+	// Prop is the name of the property, e.g. "foreground".
+	Prop string
+	// Value is the textual value, for PatchSet operations.
+	Value string
+}
+
+// PatchOpKind is the type of operation in a PatchOp.
+type PatchOpKind int
+
+const (
+	// PatchSet sets a property to a value.
+	PatchSet PatchOpKind = iota
+	// PatchUnset removes a property from the style.
+	PatchUnset
+)
+
+// String returns the operation in the textual syntax, e.g.
+// "foreground: 12;" or "foreground: unset;". The value is quoted if
+// it could not be read back as-is, as per Export.
+func (op PatchOp) String() string {
+	if op.Kind == PatchUnset {
+		return op.Prop + ": unset;"
+	}
+	return op.Prop + ": " + quoteValue(op.Value) + ";"
+}
+
+// String returns the patch in the textual syntax, suitable for
+// ParsePatch and Import.
+func (p Patch) String() string {
+	var buf strings.Builder
+	for i, op := range p {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(op.String())
+	}
+	return buf.String()
+}
+
+// ParsePatch reads a patch from its textual representation.
+// Only "prop: value" and "prop: unset" directives are allowed.
+func ParsePatch(input string) (Patch, error) {
+	var p Patch
+	for _, a := range splitAssignments(input, ";") {
+		propName, args, ok := splitAssignment(a)
+		if !ok {
+			return nil, fmt.Errorf("invalid syntax: %q", a)
+		}
+		if _, err := getProp(propName); err != nil {
+			return nil, fmt.Errorf("in %q: %v", a, err)
+		}
+		op := PatchOp{Kind: PatchSet, Prop: propName, Value: unquoteValue(args)}
+		if args == "unset" {
+			op = PatchOp{Kind: PatchUnset, Prop: propName}
+		}
+		p = append(p, op)
+	}
+	return p, nil
+}
+
+// Diff computes the patch that transforms style a into style b.
+// A property that b sets explicitly, even to its default value (e.g.
+// "bold: false"), is set by the patch; a property that a sets and b
+// does not is unset.
+func Diff(a, b S) Patch {
+	opt := options{includeDefaults: true}
+	aProps := exportProps(a, &opt)
+	bProps := exportProps(b, &opt)
+	aSet, bSet := setProps(a), setProps(b)
+
+	var p Patch
+	for i, pv := range bProps {
+		switch {
+		case bSet[pv.name]:
+			if aSet[pv.name] && aProps[i].value == pv.value {
+				continue
+			}
+			p = append(p, PatchOp{Kind: PatchSet, Prop: pv.name, Value: pv.value})
+		case aSet[pv.name]:
+			p = append(p, PatchOp{Kind: PatchUnset, Prop: pv.name})
+		}
+	}
+	return p
+}
+
+// ApplyPatch applies the operations in the patch to the given style.
+func ApplyPatch(s S, p Patch) (S, error) {
+	for _, op := range p {
+		prop, err := getProp(op.Prop)
+		if err != nil {
+			return s, fmt.Errorf("in %q: %v", op.String(), err)
+		}
+		args := op.Value
+		if op.Kind == PatchUnset {
+			args = "unset"
+		}
+		s, err = prop.assign(s, args, nil)
+		if err != nil {
+			return s, fmt.Errorf("in %q: %v", op.String(), prop.withSyntax(op.Prop, err))
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,106 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestDiffApplyPatch(t *testing.T) {
+	base := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("12")).
+		PaddingLeft(2)
+	custom := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#fff")).
+		PaddingLeft(2).
+		BorderStyle(lipgloss.RoundedBorder())
+
+	p := Diff(base, custom)
+	checkOutput(t, `bold: unset; border-style: border("─","─","│","│","╭","╮","╯","╰"); foreground: #fff;`, p.String())
+
+	result, err := ApplyPatch(base.Copy(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, Export(custom), Export(result))
+
+	// The patch can be replayed over an evolved base theme.
+	newBase := base.Copy().Italic(true)
+	result, err = ApplyPatch(newBase, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `border-style: border("─","─","│","│","╭","╮","╯","╰"); foreground: #fff; italic: true; padding-left: 2;`, Export(result))
+
+	if len(Diff(custom, custom)) != 0 {
+		t.Errorf("expected empty diff")
+	}
+}
+
+func TestDiffResetToDefault(t *testing.T) {
+	base := lipgloss.NewStyle().Bold(true).PaddingLeft(2).Italic(true)
+	custom := lipgloss.NewStyle().Bold(false).PaddingLeft(0)
+
+	p := Diff(base, custom)
+	checkOutput(t, `bold: false; italic: unset; padding-left: 0;`, p.String())
+
+	result, err := ApplyPatch(base.Copy(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(Diff(result, custom)) != 0 {
+		t.Errorf("expected %s, got %s", Export(custom, WithExportDefaults()), Export(result, WithExportDefaults()))
+	}
+}
+
+func TestPatchStringQuoting(t *testing.T) {
+	p := Diff(lipgloss.NewStyle(), lipgloss.NewStyle().Foreground(lipgloss.Color("#fff; bold: true")))
+	checkOutput(t, `foreground: "#fff; bold: true";`, p.String())
+
+	p2, err := ParsePatch(p.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p2) != 1 || p2[0] != p[0] {
+		t.Errorf("expected %+v, got %+v", p, p2)
+	}
+}
+
+func TestParsePatch(t *testing.T) {
+	p, err := ParsePatch(`bold: unset; foreground: #fff; padding: 1 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Patch{
+		{Kind: PatchUnset, Prop: "bold"},
+		{Kind: PatchSet, Prop: "foreground", Value: "#fff"},
+		{Kind: PatchSet, Prop: "padding", Value: "1 2"},
+	}
+	if len(p) != len(exp) {
+		t.Fatalf("expected %v, got %v", exp, p)
+	}
+	for i := range exp {
+		if p[i] != exp[i] {
+			t.Errorf("%d: expected %+v, got %+v", i, exp[i], p[i])
+		}
+	}
+
+	for _, tc := range []struct {
+		in     string
+		expErr string
+	}{
+		{`clear`, `invalid syntax: "clear"`},
+		{`foo: bar`, `in "foo: bar": property not supported: "foo"`},
+	} {
+		_, err := ParsePatch(tc.in)
+		if err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected error %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+
+	_, err = ApplyPatch(lipgloss.NewStyle(), Patch{{Kind: PatchSet, Prop: "bold", Value: "xx"}})
+	if err == nil || err.Error() != `in "bold: xx;": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
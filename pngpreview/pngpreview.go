@@ -0,0 +1,353 @@
+// Package pngpreview draws styled text to PNG images, for example so
+// that CI pipelines can attach visual previews of theme changes to
+// pull requests. It uses an embedded bitmap font and only depends on
+// the standard library besides lipgloss.
+//
+// The text is rendered by lipgloss, then the ANSI escape sequences in
+// the result are interpreted to draw the image. Box-drawing characters
+// used by the lipgloss borders are drawn as lines; other characters
+// outside of ASCII are drawn as empty boxes.
+package pngpreview
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Size of a character cell in pixels, before scaling.
+const (
+	cellWidth  = 6
+	cellHeight = 10
+)
+
+type config struct {
+	scale  int
+	fg, bg color.Color
+}
+
+// Option configures the rendering.
+type Option func(*config)
+
+// WithScale sets the size of each font pixel in image pixels. The
+// default is 2.
+func WithScale(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.scale = n
+		}
+	}
+}
+
+// WithColors sets the default foreground and background colors of the
+// terminal. The defaults are light gray on dark gray.
+func WithColors(fg, bg color.Color) Option {
+	return func(c *config) {
+		c.fg, c.bg = fg, bg
+	}
+}
+
+// Render renders the text with the given style and writes the result
+// to w as a PNG image.
+//
+// To capture the colors, the lipgloss color profile is temporarily
+// set to termenv.TrueColor, and restored before Render returns, even
+// if rendering panics. Concurrent calls to Render are serialized, but
+// the other goroutines that render styles at the same time see the
+// temporary profile. The light or dark variants of adaptive colors are
+// chosen as per lipgloss.HasDarkBackground.
+func Render(w io.Writer, style lipgloss.Style, text string, opts ...Option) error {
+	return png.Encode(w, DrawANSI(renderTrueColor(style, text), opts...))
+}
+
+// profileMu serializes the changes to the lipgloss color profile.
+var profileMu sync.Mutex
+
+// renderTrueColor renders the text with the truecolor profile, and
+// restores the previous profile afterwards.
+func renderTrueColor(style lipgloss.Style, text string) string {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	profile := lipgloss.ColorProfile()
+	if profile == termenv.TrueColor {
+		return style.Render(text)
+	}
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+	return style.Render(text)
+}
+
+// DrawANSI draws text containing ANSI escape sequences, as produced
+// by lipgloss, to an image. Only the SGR sequences (colors and text
+// attributes) are interpreted; other sequences are ignored.
+func DrawANSI(s string, opts ...Option) *image.RGBA {
+	cfg := config{
+		scale: 2,
+		fg:    color.RGBA{0xd0, 0xd0, 0xd0, 0xff},
+		bg:    color.RGBA{0x1c, 0x1c, 0x1c, 0xff},
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	lines := parseANSI(s)
+	cols := 0
+	for _, l := range lines {
+		if len(l) > cols {
+			cols = len(l)
+		}
+	}
+	d := drawer{
+		cfg: cfg,
+		img: image.NewRGBA(image.Rect(0, 0, cols*cellWidth*cfg.scale, len(lines)*cellHeight*cfg.scale)),
+	}
+	d.fill(0, 0, cols*cellWidth, len(lines)*cellHeight, cfg.bg)
+	for y, l := range lines {
+		for x, c := range l {
+			d.drawCell(x, y, c)
+		}
+	}
+	return d.img
+}
+
+// attrs are the display attributes of a cell.
+type attrs struct {
+	fg, bg                                  color.Color
+	bold, faint, underline, reverse, strike bool
+}
+
+// cell is a character cell. Wide characters occupy two cells; the
+// second one has r == 0.
+type cell struct {
+	r    rune
+	wide bool
+	attrs
+}
+
+// parseANSI splits the text into lines of cells.
+func parseANSI(s string) [][]cell {
+	var lines [][]cell
+	var line []cell
+	var cur attrs
+	for len(s) > 0 {
+		if strings.HasPrefix(s, "\x1b[") {
+			end := strings.IndexFunc(s[2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+			if end < 0 {
+				break
+			}
+			if s[2+end] == 'm' {
+				cur = applySGR(cur, s[2:2+end])
+			}
+			s = s[2+end+1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		switch {
+		case r == '\n':
+			lines = append(lines, line)
+			line = nil
+		case r < ' ' || r == 0x7f:
+			// Other control characters are not displayed.
+		default:
+			wide := lipgloss.Width(string(r)) == 2
+			line = append(line, cell{r: r, wide: wide, attrs: cur})
+			if wide {
+				line = append(line, cell{attrs: cur})
+			}
+		}
+	}
+	return append(lines, line)
+}
+
+// applySGR updates the attributes with the parameters of an SGR
+// sequence, e.g. "1;38;2;255;0;0".
+func applySGR(a attrs, params string) attrs {
+	var codes []int
+	for _, p := range strings.Split(params, ";") {
+		n, _ := strconv.Atoi(p)
+		codes = append(codes, n)
+	}
+	for i := 0; i < len(codes); i++ {
+		switch c := codes[i]; {
+		case c == 0:
+			a = attrs{}
+		case c == 1:
+			a.bold = true
+		case c == 2:
+			a.faint = true
+		case c == 4:
+			a.underline = true
+		case c == 7:
+			a.reverse = true
+		case c == 9:
+			a.strike = true
+		case c == 22:
+			a.bold, a.faint = false, false
+		case c == 24:
+			a.underline = false
+		case c == 27:
+			a.reverse = false
+		case c == 29:
+			a.strike = false
+		case c >= 30 && c <= 37:
+			a.fg = ansiColor(c - 30)
+		case c >= 90 && c <= 97:
+			a.fg = ansiColor(c - 90 + 8)
+		case c >= 40 && c <= 47:
+			a.bg = ansiColor(c - 40)
+		case c >= 100 && c <= 107:
+			a.bg = ansiColor(c - 100 + 8)
+		case c == 39:
+			a.fg = nil
+		case c == 49:
+			a.bg = nil
+		case c == 38 || c == 48:
+			var col color.Color
+			switch {
+			case i+2 < len(codes) && codes[i+1] == 5:
+				col = ansiColor(codes[i+2])
+				i += 2
+			case i+4 < len(codes) && codes[i+1] == 2:
+				col = color.RGBA{uint8(codes[i+2]), uint8(codes[i+3]), uint8(codes[i+4]), 0xff}
+				i += 4
+			default:
+				continue
+			}
+			if c == 38 {
+				a.fg = col
+			} else {
+				a.bg = col
+			}
+		}
+	}
+	return a
+}
+
+func ansiColor(n int) color.Color {
+	if n < 0 || n > 255 {
+		return nil
+	}
+	r, g, b := termenv.ConvertToRGB(termenv.ANSI256Color(n)).RGB255()
+	return color.RGBA{r, g, b, 0xff}
+}
+
+type drawer struct {
+	cfg config
+	img *image.RGBA
+}
+
+// fill paints a rectangle, in font pixels.
+func (d *drawer) fill(x, y, w, h int, c color.Color) {
+	s := d.cfg.scale
+	for py := y * s; py < (y+h)*s; py++ {
+		for px := x * s; px < (x+w)*s; px++ {
+			d.img.Set(px, py, c)
+		}
+	}
+}
+
+func (d *drawer) drawCell(col, row int, c cell) {
+	if c.r == 0 {
+		// Second half of a wide character.
+		return
+	}
+	fg, bg := c.fg, c.bg
+	if fg == nil {
+		fg = d.cfg.fg
+	}
+	if bg == nil {
+		bg = d.cfg.bg
+	}
+	if c.reverse {
+		fg, bg = bg, fg
+	}
+	if c.faint {
+		fg = blend(fg, bg)
+	}
+
+	width := cellWidth
+	if c.wide {
+		width *= 2
+	}
+	x, y := col*cellWidth, row*cellHeight
+	d.fill(x, y, width, cellHeight, bg)
+
+	switch {
+	case c.r >= ' ' && c.r <= '~':
+		g := glyphs[c.r-' ']
+		for gy, bits := range g {
+			for gx := 0; gx < 5; gx++ {
+				if bits&(0x10>>uint(gx)) == 0 {
+					continue
+				}
+				d.fill(x+gx, y+1+gy, 1, 1, fg)
+				if c.bold {
+					d.fill(x+gx+1, y+1+gy, 1, 1, fg)
+				}
+			}
+		}
+	case c.r == '█':
+		d.fill(x, y, width, cellHeight, fg)
+	default:
+		if b, ok := boxChars[c.r]; ok {
+			d.drawBox(x, y, b, fg)
+		} else {
+			// Unknown glyph: draw an empty box.
+			d.fill(x+1, y+2, width-2, 1, fg)
+			d.fill(x+1, y+8, width-2, 1, fg)
+			d.fill(x+1, y+2, 1, 7, fg)
+			d.fill(x+width-2, y+2, 1, 7, fg)
+		}
+	}
+
+	if c.underline {
+		d.fill(x, y+cellHeight-1, width, 1, fg)
+	}
+	if c.strike {
+		d.fill(x, y+cellHeight/2, width, 1, fg)
+	}
+}
+
+// drawBox draws a box-drawing character as lines from the center of
+// the cell to the edges.
+func (d *drawer) drawBox(x, y int, b boxLines, c color.Color) {
+	const cx, cy = 2, 4
+	offsets := []int{0}
+	thickness := 1
+	switch b.weight {
+	case 2:
+		thickness = 2
+	case 3:
+		offsets = []int{-1, 1}
+	}
+	for _, o := range offsets {
+		if b.left {
+			d.fill(x, y+cy+o, cx+1, thickness, c)
+		}
+		if b.right {
+			d.fill(x+cx, y+cy+o, cellWidth-cx, thickness, c)
+		}
+		if b.up {
+			d.fill(x+cx+o, y, thickness, cy+1, c)
+		}
+		if b.down {
+			d.fill(x+cx+o, y+cy, thickness, cellHeight-cy, c)
+		}
+	}
+}
+
+// blend mixes two colors equally.
+func blend(a, b color.Color) color.Color {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	return color.RGBA{uint8((ar + br) >> 9), uint8((ag + bg) >> 9), uint8((ab + bb) >> 9), 0xff}
+}
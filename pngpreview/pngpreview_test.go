@@ -0,0 +1,67 @@
+package pngpreview
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestDrawANSI(t *testing.T) {
+	// "A" in red on blue, then a bold wide character.
+	img := DrawANSI("\x1b[38;2;255;0;0;44mA\x1b[0m\x1b[1m世\x1b[0m\n│", WithScale(1))
+	if b := img.Bounds(); b.Dx() != 3*cellWidth || b.Dy() != 2*cellHeight {
+		t.Fatalf("unexpected size: %v", b)
+	}
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	if c := img.At(2, 1); c != red {
+		// The top of the A.
+		t.Errorf("expected red, got %v", c)
+	}
+	if c := img.At(0, 1); c != ansiColor(4) {
+		t.Errorf("expected blue background, got %v", c)
+	}
+	// The vertical line spans the whole height of the cell.
+	for y := cellHeight; y < 2*cellHeight; y++ {
+		if c := img.At(2, y); c != (color.RGBA{0xd0, 0xd0, 0xd0, 0xff}) {
+			t.Errorf("%d: expected line, got %v", y, c)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00")).Border(lipgloss.RoundedBorder())
+	if err := Render(&buf, style, "hi", WithScale(3)); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 4*cellWidth*3 || b.Dy() != 3*cellHeight*3 {
+		t.Fatalf("unexpected size: %v", b)
+	}
+	// The top of the h in the middle line.
+	r, g, b, _ := img.At((cellWidth+0)*3, (cellHeight+1)*3).RGBA()
+	if r != 0 || g != 0xffff || b != 0 {
+		t.Errorf("expected green, got %v %v %v", r, g, b)
+	}
+}
+
+func TestRenderRestoresProfile(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	defer lipgloss.SetColorProfile(profile)
+	lipgloss.SetColorProfile(termenv.ANSI)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00")), "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if p := lipgloss.ColorProfile(); p != termenv.ANSI {
+		t.Errorf("expected the ANSI profile to be restored, got %v", p)
+	}
+}
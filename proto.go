@@ -0,0 +1,352 @@
+package lipglossc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// This file implements the protobuf encoding of styles described by
+// proto/lipglossc.proto. The encoding is implemented by hand to avoid
+// a dependency on a protobuf runtime; services can exchange the
+// resulting bytes with any code generated from the schema.
+
+// MarshalProto encodes a style as a lipglossc.Style protobuf message.
+func MarshalProto(s S) []byte {
+	var b []byte
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		if isDefault(val) {
+			continue
+		}
+		b = appendMessage(b, 1, marshalProperty(g.name, val.Interface()))
+	}
+	return b
+}
+
+// MarshalProtoMap encodes a set of named styles as a
+// lipglossc.StyleMap protobuf message.
+func MarshalProtoMap(styles map[string]S) []byte {
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b []byte
+	for _, name := range names {
+		var entry []byte
+		entry = appendString(entry, 1, name)
+		entry = appendMessage(entry, 2, MarshalProto(styles[name]))
+		b = appendMessage(b, 1, entry)
+	}
+	return b
+}
+
+// UnmarshalProto decodes a lipglossc.Style protobuf message.
+func UnmarshalProto(b []byte) (S, error) {
+	s := lipgloss.NewStyle()
+	err := forEachField(b, func(num int, _ uint64, data []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var err error
+		s, err = unmarshalProperty(s, data)
+		return err
+	})
+	return s, err
+}
+
+// UnmarshalProtoMap decodes a lipglossc.StyleMap protobuf message.
+func UnmarshalProtoMap(b []byte) (map[string]S, error) {
+	styles := map[string]S{}
+	err := forEachField(b, func(num int, _ uint64, data []byte) error {
+		if num != 1 {
+			return nil
+		}
+		var name string
+		s := lipgloss.NewStyle()
+		if err := forEachField(data, func(num int, _ uint64, data []byte) error {
+			var err error
+			switch num {
+			case 1:
+				name = string(data)
+			case 2:
+				s, err = UnmarshalProto(data)
+			}
+			return err
+		}); err != nil {
+			return err
+		}
+		styles[name] = s
+		return nil
+	})
+	return styles, err
+}
+
+func marshalProperty(name string, val interface{}) []byte {
+	b := appendString(nil, 1, name)
+	switch val := val.(type) {
+	case bool:
+		b = appendVarint(b, 2, boolToUint(val))
+	case int:
+		b = appendVarint(b, 3, uint64(val))
+	case lipgloss.Position:
+		b = appendTag(b, 4, wireFixed64)
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(float64(val)))
+		b = append(b, buf[:]...)
+	case lipgloss.TerminalColor:
+		b = appendMessage(b, 5, marshalColor(val))
+	case lipgloss.Border:
+		var bb []byte
+		for i, s := range []string{
+			val.Top, val.Bottom, val.Left, val.Right,
+			val.TopLeft, val.TopRight, val.BottomRight, val.BottomLeft,
+		} {
+			bb = appendString(bb, i+1, s)
+		}
+		b = appendMessage(b, 6, bb)
+	}
+	return b
+}
+
+func marshalColor(c lipgloss.TerminalColor) []byte {
+	switch c := c.(type) {
+	case lipgloss.Color:
+		return appendString(nil, 2, string(c))
+	case lipgloss.AdaptiveColor:
+		var b []byte
+		b = appendString(b, 1, c.Light)
+		b = appendString(b, 2, c.Dark)
+		return appendMessage(nil, 3, b)
+	case lipgloss.CompleteColor:
+		return appendMessage(nil, 4, marshalCompleteColor(c))
+	case lipgloss.CompleteAdaptiveColor:
+		var b []byte
+		b = appendMessage(b, 1, marshalCompleteColor(c.Light))
+		b = appendMessage(b, 2, marshalCompleteColor(c.Dark))
+		return appendMessage(nil, 5, b)
+	}
+	return appendVarint(nil, 1, 1)
+}
+
+func marshalCompleteColor(c lipgloss.CompleteColor) []byte {
+	var b []byte
+	b = appendString(b, 1, c.TrueColor)
+	b = appendString(b, 2, c.ANSI256)
+	b = appendString(b, 3, c.ANSI)
+	return b
+}
+
+func unmarshalProperty(s S, b []byte) (S, error) {
+	var name string
+	var val reflect.Value
+	err := forEachField(b, func(num int, x uint64, data []byte) error {
+		switch num {
+		case 1:
+			name = string(data)
+		case 2:
+			val = reflect.ValueOf(x != 0)
+		case 3:
+			val = reflect.ValueOf(int(int64(x)))
+		case 4:
+			val = reflect.ValueOf(lipgloss.Position(math.Float64frombits(x)))
+		case 5:
+			c, err := unmarshalColor(data)
+			if err != nil {
+				return err
+			}
+			val = reflect.ValueOf(c)
+		case 6:
+			var bd lipgloss.Border
+			fields := []*string{
+				&bd.Top, &bd.Bottom, &bd.Left, &bd.Right,
+				&bd.TopLeft, &bd.TopRight, &bd.BottomRight, &bd.BottomLeft,
+			}
+			if err := forEachField(data, func(num int, _ uint64, data []byte) error {
+				if num >= 1 && num <= len(fields) {
+					*fields[num-1] = string(data)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			val = reflect.ValueOf(bd)
+		}
+		return nil
+	})
+	if err != nil {
+		return s, err
+	}
+	for _, g := range styleGetters {
+		if g.name != name {
+			continue
+		}
+		if !val.IsValid() || !val.Type().AssignableTo(g.setFn.Type().In(1)) {
+			return s, fmt.Errorf("%s: invalid value", name)
+		}
+		return g.setFn.Call([]reflect.Value{reflect.ValueOf(s), val})[0].Interface().(S), nil
+	}
+	return s, fmt.Errorf("property not supported: %q", name)
+}
+
+func unmarshalColor(b []byte) (lipgloss.TerminalColor, error) {
+	var c lipgloss.TerminalColor = lipgloss.NoColor{}
+	err := forEachField(b, func(num int, _ uint64, data []byte) error {
+		switch num {
+		case 2:
+			c = lipgloss.Color(data)
+		case 3:
+			var ac lipgloss.AdaptiveColor
+			if err := forEachField(data, func(num int, _ uint64, data []byte) error {
+				switch num {
+				case 1:
+					ac.Light = string(data)
+				case 2:
+					ac.Dark = string(data)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			c = ac
+		case 4:
+			cc, err := unmarshalCompleteColor(data)
+			if err != nil {
+				return err
+			}
+			c = cc
+		case 5:
+			var cac lipgloss.CompleteAdaptiveColor
+			if err := forEachField(data, func(num int, _ uint64, data []byte) error {
+				var err error
+				switch num {
+				case 1:
+					cac.Light, err = unmarshalCompleteColor(data)
+				case 2:
+					cac.Dark, err = unmarshalCompleteColor(data)
+				}
+				return err
+			}); err != nil {
+				return err
+			}
+			c = cac
+		}
+		return nil
+	})
+	return c, err
+}
+
+func unmarshalCompleteColor(b []byte) (lipgloss.CompleteColor, error) {
+	var cc lipgloss.CompleteColor
+	err := forEachField(b, func(num int, _ uint64, data []byte) error {
+		switch num {
+		case 1:
+			cc.TrueColor = string(data)
+		case 2:
+			cc.ANSI256 = string(data)
+		case 3:
+			cc.ANSI = string(data)
+		}
+		return nil
+	})
+	return cc, err
+}
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+func appendTag(b []byte, num int, wireType int) []byte {
+	return appendUvarint(b, uint64(num)<<3|uint64(wireType))
+}
+
+func appendVarint(b []byte, num int, v uint64) []byte {
+	b = appendTag(b, num, wireVarint)
+	return appendUvarint(b, v)
+}
+
+func appendString(b []byte, num int, s string) []byte {
+	return appendMessage(b, num, []byte(s))
+}
+
+func appendMessage(b []byte, num int, data []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = appendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func boolToUint(v bool) uint64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+var errTruncated = errors.New("truncated protobuf message")
+
+// forEachField calls fn for every field in the encoded message. For
+// varint and fixed fields, the value is passed as x; for
+// length-delimited fields, the payload is passed as data.
+func forEachField(b []byte, fn func(num int, x uint64, data []byte) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errTruncated
+		}
+		b = b[n:]
+		num := int(tag >> 3)
+		var x uint64
+		var data []byte
+		switch tag & 7 {
+		case wireVarint:
+			x, n = binary.Uvarint(b)
+			if n <= 0 {
+				return errTruncated
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+			x = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errTruncated
+			}
+			data = b[n : n+int(l)]
+			b = b[n+int(l):]
+		case wireFixed32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+			x = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %d", tag&7)
+		}
+		if err := fn(num, x, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
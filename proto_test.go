@@ -0,0 +1,55 @@
+package lipglossc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestProto(t *testing.T) {
+	b := MarshalProto(lipgloss.NewStyle().Bold(true))
+	// Style{properties: [{name: "bold", bool_value: true}]}
+	exp := []byte{0x0a, 0x08, 0x0a, 0x04, 'b', 'o', 'l', 'd', 0x10, 0x01}
+	if !bytes.Equal(b, exp) {
+		t.Errorf("expected %x, got %x", exp, b)
+	}
+
+	s, err := Import(lipgloss.NewStyle(), `bold: true; padding: 1 2; align: center bottom;
+foreground: adaptive(#fff,12); background: complete(#123456,21,4);
+border-top-foreground: adaptive(complete(#111,233,0),complete(#eee,255,15));
+border-left-background: none; border-style: rounded; width: 20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = s.BorderLeftBackground(lipgloss.Color("1"))
+	s2, err := UnmarshalProto(MarshalProto(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, Export(s, WithExportDefaults()), Export(s2, WithExportDefaults()))
+
+	styles := map[string]S{"title": s, "footer": lipgloss.NewStyle().Faint(true)}
+	styles2, err := UnmarshalProtoMap(MarshalProtoMap(styles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(styles2) != 2 {
+		t.Fatalf("expected 2 styles, got %v", styles2)
+	}
+	for name := range styles {
+		checkOutput(t, Export(styles[name]), Export(styles2[name]))
+	}
+
+	if _, err := UnmarshalProto(exp[:5]); err == nil || err.Error() != "truncated protobuf message" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	bad := appendMessage(nil, 1, appendVarint(appendString(nil, 1, "foo"), 2, 1))
+	if _, err := UnmarshalProto(bad); err == nil || err.Error() != `property not supported: "foo"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	bad = appendMessage(nil, 1, appendVarint(appendString(nil, 1, "width"), 2, 1))
+	if _, err := UnmarshalProto(bad); err == nil || err.Error() != `width: invalid value` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
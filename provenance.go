@@ -0,0 +1,46 @@
+package lipglossc
+
+import "fmt"
+
+// Provenance describes where the final value of a property comes
+// from, as reported by Resolved.Why.
+type Provenance struct {
+	// Layer is the name of the layer that determined the value. It is
+	// empty if the property is not set.
+	Layer string
+	// Pos is the position of the directive responsible for the value,
+	// if the layer has a source map for the style.
+	Pos SourcePos
+	// Directive is the property and its final value, as printed by
+	// Export, e.g. "foreground: #00ff00".
+	Directive string
+}
+
+// String implements fmt.Stringer.
+func (p Provenance) String() string {
+	if p.Layer == "" {
+		return "not set"
+	}
+	if p.Pos.File != "" || p.Pos.Line > 0 {
+		return fmt.Sprintf("%s (layer %s, %s)", p.Directive, p.Layer, p.Pos)
+	}
+	return fmt.Sprintf("%s (layer %s)", p.Directive, p.Layer)
+}
+
+// Why reports which layer, and which directive in it if the layer has
+// source maps, determined the final value of a property in a style.
+// The property name is that reported by Export, e.g.
+// "padding-left" and not "padding". This supports tooling that
+// answers questions like "why is my status bar green?".
+func (r Resolved) Why(style, prop string) Provenance {
+	layer, ok := r.Origin(style, prop)
+	if !ok {
+		return Provenance{}
+	}
+	p := Provenance{Layer: layer}
+	p.Pos = r.sources[layer][style][prop]
+	if value, ok := ExportMap(r.Styles[style])[prop]; ok {
+		p.Directive = prop + ": " + value
+	}
+	return p
+}
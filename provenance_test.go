@@ -0,0 +1,45 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWhy(t *testing.T) {
+	base, err := Import(lipgloss.NewStyle(), `bold: true; foreground: 12`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	theme, sm, err := ImportWithSourceMap(lipgloss.NewStyle(), "faint: true;\nforeground: #00ff00;\n", "theme.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Resolve(
+		Layer{Name: "defaults", Styles: map[string]S{"status": base}},
+		Layer{Name: "theme", Styles: map[string]S{"status": theme}, Sources: map[string]SourceMap{"status": sm}},
+	)
+
+	td := []struct {
+		prop string
+		exp  Provenance
+		str  string
+	}{
+		{"foreground", Provenance{Layer: "theme", Pos: SourcePos{File: "theme.gloss", Line: 2}, Directive: "foreground: #00ff00"},
+			"foreground: #00ff00 (layer theme, theme.gloss:2)"},
+		{"bold", Provenance{Layer: "defaults", Directive: "bold: true"}, "bold: true (layer defaults)"},
+		{"italic", Provenance{}, "not set"},
+	}
+	for _, tc := range td {
+		p := r.Why("status", tc.prop)
+		if p != tc.exp {
+			t.Errorf("%s: expected %+v, got %+v", tc.prop, tc.exp, p)
+		}
+		if p.String() != tc.str {
+			t.Errorf("%s: expected %q, got %q", tc.prop, tc.str, p.String())
+		}
+	}
+	if p := r.Why("footer", "bold"); p.Layer != "" {
+		t.Errorf("unexpected provenance: %+v", p)
+	}
+}
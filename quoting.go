@@ -0,0 +1,50 @@
+package lipglossc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// unquoteValue removes the double quotes around a property value
+// wrapped in quotes as a whole, e.g. "\"#7D56F4\"", so that values can
+// contain ":" or ";" unambiguously. Other values are returned as-is.
+func unquoteValue(value string) string {
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+	if s, err := strconv.Unquote(value); err == nil {
+		return s
+	}
+	return value
+}
+
+// quoteValue wraps a property value in double quotes for Export if it
+// could not be read back as-is: when it contains separators, braces or
+// comment markers outside of double-quoted strings, an unterminated
+// string, or is a quoted string as a whole.
+func quoteValue(value string) string {
+	if needsQuoting(value) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func needsQuoting(value string) bool {
+	if unquoteValue(value) != value {
+		return true
+	}
+	inString := false
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case strings.IndexByte(";:{}\r\n", c) >= 0,
+			strings.HasPrefix(value[i:], "//"), strings.HasPrefix(value[i:], "/*"):
+			return true
+		}
+	}
+	return inString
+}
@@ -0,0 +1,73 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestQuotedValues(t *testing.T) {
+	td := []struct {
+		in  string
+		exp string
+	}{
+		{`foreground: "#7D56F4"`, `foreground: #7D56F4;`},
+		{`border-style: "rounded"; border-top: "true"`, `border-style: border("─","─","│","│","╭","╮","╯","╰"); border-top: true;`},
+		{`$c: "12"; foreground: $c`, `foreground: 12;`},
+		// Separators within strings do not count.
+		{`border-style: border(";",":","{","}","a","b","c","d"); bold: true`, `bold: true; border-style: border(";",":","{","}","a","b","c","d");`},
+		{`border-style: "border(\";\",\";\",\"|\",\"|\",\"+\",\"+\",\"+\",\"+\")"`, `border-style: border(";",";","|","|","+","+","+","+");`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			if err := Validate(tc.in); err != nil {
+				t.Fatal(err)
+			}
+			s, err := Import(lipgloss.NewStyle(), tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := Export(s)
+			checkOutput(t, tc.exp, res)
+			// The result can be read back.
+			s2, err := Import(lipgloss.NewStyle(), res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkOutput(t, res, Export(s2))
+		})
+	}
+}
+
+func TestExportQuoting(t *testing.T) {
+	td := []struct {
+		value string
+		exp   string
+	}{
+		{`#7D56F4`, `#7D56F4`},
+		{`adaptive(1,2)`, `adaptive(1,2)`},
+		{`border(";","}","a","b","c","d","e","f")`, `border(";","}","a","b","c","d","e","f")`},
+		{`a;b`, `"a;b"`},
+		{`a:b`, `"a:b"`},
+		{`{a}`, `"{a}"`},
+		{`a//b`, `"a//b"`},
+		{"a\nb", `"a\nb"`},
+		{`"a"`, `"\"a\""`},
+		{`a"b`, `"a\"b"`},
+	}
+	for _, tc := range td {
+		res := quoteValue(tc.value)
+		checkOutput(t, tc.exp, res)
+		if back := unquoteValue(res); back != tc.value {
+			t.Errorf("%q: read back as %q", tc.value, back)
+		}
+	}
+
+	s := lipgloss.NewStyle().Foreground(lipgloss.Color("a;b")).Bold(true)
+	res := Export(s)
+	checkOutput(t, `bold: true; foreground: "a;b";`, res)
+	if _, err := Import(lipgloss.NewStyle(), res); err == nil ||
+		err.Error() != `in "foreground: \"a;b\"": color not recognized; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package lipglossc
+
+import "errors"
+
+// RangePolicy determines how Import handles out-of-range values:
+// negative sizes, positions outside of [0,1] and color indexes greater
+// than 255.
+type RangePolicy int
+
+const (
+	// RangeError reports out-of-range values as errors. This is the
+	// default.
+	RangeError RangePolicy = iota
+	// RangeClamp replaces out-of-range values by the nearest valid
+	// value, and reports a warning (see WithWarnings).
+	RangeClamp
+	// RangeIgnore passes out-of-range values to lipgloss untouched.
+	RangeIgnore
+)
+
+// WithRangePolicy sets the handling of out-of-range values.
+func WithRangePolicy(p RangePolicy) ImportOption {
+	return func(o *importOptions) {
+		o.ranges.policy = p
+	}
+}
+
+// rangeCheck applies the range policy while parsing values. A nil
+// *rangeCheck reports out-of-range values as errors.
+type rangeCheck struct {
+	policy RangePolicy
+	// clamped lists the values clamped in the current directive.
+	clamped []Warning
+}
+
+// outOfRange is called by the parsers for out-of-range values, with
+// the description of the problem and the nearest valid value. It
+// returns an error if the value is not acceptable, and otherwise
+// whether the parser should use the valid value instead.
+func (rc *rangeCheck) outOfRange(msg, bound string) (clamp bool, err error) {
+	if rc == nil || rc.policy == RangeError {
+		return false, rangeError{errors.New(msg)}
+	}
+	if rc.policy == RangeClamp {
+		rc.clamped = append(rc.clamped, Warning{Message: msg, Hint: "clamped to " + bound})
+		return true, nil
+	}
+	return false, nil
+}
+
+// reportClamped reports the values clamped in the last directive as
+// warnings.
+func (opt *importOptions) reportClamped(prop string) {
+	if opt.warn == nil {
+		return
+	}
+	for _, w := range opt.ranges.clamped {
+		w.Prop = prop
+		opt.warn(w)
+	}
+}
+
+// rangeError is the error for out-of-range values. Unlike syntax
+// errors, it is not followed by a syntax hint.
+type rangeError struct{ error }
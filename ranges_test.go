@@ -0,0 +1,45 @@
+package lipglossc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRangePolicy(t *testing.T) {
+	const input = `padding: -1 2; align: 1.5; foreground: adaptive(300,12)`
+
+	_, err := Import(lipgloss.NewStyle(), input)
+	if err == nil || err.Error() != `in "padding: -1 2": negative value not allowed: -1` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate(input, WithRangePolicy(RangeClamp)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var warnings []string
+	s, err := Import(lipgloss.NewStyle(), input, WithRangePolicy(RangeClamp),
+		WithWarnings(func(w Warning) { warnings = append(warnings, w.String()) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `align-horizontal: 1; foreground: adaptive(255,12); padding-left: 2; padding-right: 2;`, Export(s))
+	exp := []string{
+		`padding: negative value not allowed: -1 (clamped to 0)`,
+		`align: position out of range [0,1]: 1.5 (clamped to 1)`,
+		`foreground: color index out of range [0,255]: 300 (clamped to 255)`,
+	}
+	if fmt.Sprint(warnings) != fmt.Sprint(exp) {
+		t.Errorf("expected:\n%q\ngot:\n%q", exp, warnings)
+	}
+
+	s, err = Import(lipgloss.NewStyle(), input, WithRangePolicy(RangeIgnore))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// lipgloss itself ignores negative sizes.
+	if s.GetAlignHorizontal() != 1.5 || s.GetForeground() != (lipgloss.AdaptiveColor{Light: "300", Dark: "12"}) {
+		t.Errorf("unexpected values: %v, %v", s.GetAlignHorizontal(), s.GetForeground())
+	}
+}
@@ -0,0 +1,73 @@
+package lipglossc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// ImportReader is like Import, but reads the style specification from
+// r. The input is parsed incrementally, one directive at a time, so
+// that large theme files and network sources do not need to be
+// buffered entirely in memory.
+//
+// The directives read before an error are applied to the returned
+// style. Errors from r are returned as-is, except io.EOF which marks
+// the end of the input.
+func ImportReader(dst S, r io.Reader, opts ...ImportOption) (S, error) {
+	opt := makeImportOptions(opts)
+	sep := []byte(opt.sep)
+	br := bufio.NewReader(r)
+
+	// The directive is accumulated in buf until a separator outside of
+	// strings, comments and nested blocks.
+	var buf []byte
+	inString, inLineComment, inBlockComment := false, false, false
+	commentStart := 0
+	depth := 0
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return dst, err
+		}
+		buf = append(buf, c)
+		switch {
+		case inLineComment:
+			inLineComment = c != '\n'
+			continue
+		case inBlockComment:
+			inBlockComment = !(c == '/' && len(buf)-commentStart > 3 && buf[len(buf)-2] == '*')
+			continue
+		case inString:
+			if c == '\\' {
+				if c, err = br.ReadByte(); err == nil {
+					buf = append(buf, c)
+				}
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		case c == '"':
+			inString = true
+		case c == '/' && bytes.HasSuffix(buf, []byte("//")):
+			inLineComment = true
+		case c == '*' && bytes.HasSuffix(buf, []byte("/*")):
+			inBlockComment = true
+			commentStart = len(buf) - 2
+		case c == '{':
+			depth++
+		case c == '}' && depth > 0:
+			depth--
+		}
+		if depth == 0 && bytes.HasSuffix(buf, sep) {
+			if dst, err = importStyle(dst, string(buf), &opt); err != nil {
+				return dst, err
+			}
+			buf = buf[:0]
+		}
+	}
+	return importStyle(dst, string(buf), &opt)
+}
@@ -0,0 +1,68 @@
+package lipglossc
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestImportReader(t *testing.T) {
+	const spec = `$accent: #7D56F4;
+// The title; emphasized.
+bold: true; foreground: $accent /* ; */;
+border { style: rounded; top: true }
+border-top-foreground: adaptive(#fafafa, #111);
+`
+	s, err := ImportReader(lipgloss.NewStyle(), iotest.OneByteReader(strings.NewReader(spec)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, err := Import(lipgloss.NewStyle(), spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, Export(exp), Export(s))
+
+	s, err = ImportReader(lipgloss.NewStyle(), strings.NewReader("bold: true\nfaint: true\n"), WithImportSeparator("\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; faint: true;`, Export(s))
+}
+
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		err = f.err
+	}
+	return n, err
+}
+
+func TestImportReaderErrors(t *testing.T) {
+	// The directives before the error are applied.
+	s, err := ImportReader(lipgloss.NewStyle(), strings.NewReader("bold: true; italic: maybe; faint: true"))
+	if err == nil || err.Error() != `in "italic: maybe": no value found; italic expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	checkOutput(t, `bold: true;`, Export(s))
+
+	readErr := errors.New("connection reset")
+	_, err = ImportReader(lipgloss.NewStyle(), &failingReader{r: strings.NewReader("bold: true; faint"), err: readErr})
+	if err != readErr {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = ImportReader(lipgloss.NewStyle(), strings.NewReader("bold: true; /* faint: true;"))
+	if err == nil || err.Error() != `invalid syntax: unterminated comment` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package lipglossc
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Scale multiplies the paddings, margins, width and height of a style,
+// including the maximum width and height, by the given factor, so that
+// a theme can be adapted to compact or spacious layouts. The other
+// properties are unchanged.
+//
+// The results are rounded to the nearest integer, halves away from
+// zero, except that a non-zero value is never scaled down to zero by a
+// positive factor: scaling a padding of 1 by 0.4 yields 1, not 0. A
+// negative factor is treated as zero.
+func Scale(s S, factor float64) S {
+	factor = math.Max(0, factor)
+	result := s.Copy()
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		if !isScaled(g.name) {
+			continue
+		}
+		n := g.getFn.Call([]reflect.Value{v})[0].Interface().(int)
+		if n == 0 {
+			continue
+		}
+		scaled := int(math.Round(float64(n) * factor))
+		if scaled == 0 && factor > 0 {
+			scaled = 1
+		}
+		result = g.setFn.Call([]reflect.Value{reflect.ValueOf(result), reflect.ValueOf(scaled)})[0].Interface().(S)
+	}
+	return result
+}
+
+// isScaled reports whether the property is scaled by Scale.
+func isScaled(prop string) bool {
+	switch prop {
+	case "width", "height", "max-width", "max-height":
+		return true
+	}
+	return strings.HasPrefix(prop, "padding-") || strings.HasPrefix(prop, "margin-")
+}
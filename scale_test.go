@@ -0,0 +1,33 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestScale(t *testing.T) {
+	s, err := Import(lipgloss.NewStyle(), `bold: true; padding: 1 2 3 4; margin-left: 5; width: 40; max-height: 9`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := Export(s)
+
+	td := []struct {
+		factor float64
+		exp    string
+	}{
+		{1, `bold: true; margin-left: 5; max-height: 9; padding-bottom: 3; padding-left: 4; padding-right: 2; padding-top: 1; width: 40;`},
+		{2, `bold: true; margin-left: 10; max-height: 18; padding-bottom: 6; padding-left: 8; padding-right: 4; padding-top: 2; width: 80;`},
+		// 0.5 rounds away from zero; non-zero values remain non-zero.
+		{0.5, `bold: true; margin-left: 3; max-height: 5; padding-bottom: 2; padding-left: 2; padding-right: 1; padding-top: 1; width: 20;`},
+		{0.1, `bold: true; margin-left: 1; max-height: 1; padding-bottom: 1; padding-left: 1; padding-right: 1; padding-top: 1; width: 4;`},
+		{0, `bold: true;`},
+		{-1, `bold: true;`},
+	}
+	for _, tc := range td {
+		checkOutput(t, tc.exp, Export(Scale(s, tc.factor)))
+	}
+	// The argument is not modified.
+	checkOutput(t, before, Export(s))
+}
@@ -0,0 +1,291 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ScheduleMode selects how a ThemeScheduler chooses between its day
+// and night themes.
+type ScheduleMode int
+
+const (
+	// ScheduleAuto selects the theme by local time of day. This is the
+	// default.
+	ScheduleAuto ScheduleMode = iota
+	// ScheduleDay always selects the day theme.
+	ScheduleDay
+	// ScheduleNight always selects the night theme.
+	ScheduleNight
+)
+
+// ThemeScheduler selects between a day theme and a night theme, by
+// local time of day or explicitly, so that applications can switch
+// themes automatically. The adaptive colors of the active theme are
+// resolved for a light background during the day and a dark background
+// at night.
+//
+// It is safe for concurrent use.
+type ThemeScheduler struct {
+	mu         sync.Mutex
+	themes     map[string]Theme
+	day, night string
+	// dayStart and nightStart are the times of day, as offsets from
+	// midnight, at which the themes switch in ScheduleAuto mode.
+	dayStart, nightStart time.Duration
+	mode                 ScheduleMode
+	// now is the clock, replaced in tests.
+	now  func() time.Time
+	subs []func(name string, ss StyleSheet)
+	// active and dark identify the active theme and its resolution;
+	// sheet is the resolved sheet.
+	active string
+	dark   bool
+	sheet  StyleSheet
+	// wake interrupts Run when the schedule changes.
+	wake chan struct{}
+}
+
+// SchedulerOption configures a ThemeScheduler.
+type SchedulerOption func(*ThemeScheduler)
+
+// WithDaytime sets the times of day, as offsets from midnight, at
+// which the day theme and the night theme start. The default is from
+// 7:00 to 19:00. If dayStart is after nightStart, the day spans
+// midnight.
+func WithDaytime(dayStart, nightStart time.Duration) SchedulerOption {
+	return func(s *ThemeScheduler) {
+		s.dayStart, s.nightStart = dayStart, nightStart
+	}
+}
+
+// WithScheduleMode sets the initial mode of the scheduler.
+func WithScheduleMode(m ScheduleMode) SchedulerOption {
+	return func(s *ThemeScheduler) {
+		s.mode = m
+	}
+}
+
+// withClock replaces the clock of the scheduler, for tests.
+func withClock(now func() time.Time) SchedulerOption {
+	return func(s *ThemeScheduler) {
+		s.now = now
+	}
+}
+
+// NewThemeScheduler creates a ThemeScheduler selecting between the
+// themes with the given names. The day and night themes can be the
+// same, so that only the resolution of adaptive colors changes. The
+// active theme is selected immediately.
+func NewThemeScheduler(themes map[string]Theme, day, night string, opts ...SchedulerOption) (*ThemeScheduler, error) {
+	for _, name := range []string{day, night} {
+		if _, ok := themes[name]; !ok {
+			return nil, fmt.Errorf("unknown theme: %q", name)
+		}
+	}
+	s := &ThemeScheduler{
+		themes:     make(map[string]Theme, len(themes)),
+		day:        day,
+		night:      night,
+		dayStart:   7 * time.Hour,
+		nightStart: 19 * time.Hour,
+		now:        time.Now,
+		wake:       make(chan struct{}, 1),
+	}
+	for name, t := range themes {
+		s.themes[name] = t
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.dayStart == s.nightStart {
+		return nil, fmt.Errorf("day and night cannot start at the same time: %v", s.dayStart)
+	}
+	s.active, s.dark = s.selection()
+	s.sheet = resolveSheetBackground(s.themes[s.active].Sheet, s.dark)
+	return s, nil
+}
+
+// Subscribe registers a function called every time the active theme
+// or its resolution changes, with the name of the theme and the
+// resolved sheet.
+func (s *ThemeScheduler) Subscribe(fn func(name string, ss StyleSheet)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = append(s.subs, fn)
+}
+
+// Active returns the name of the active theme and its sheet, where the
+// adaptive colors are resolved for the current period. The sheet is a
+// copy and can be modified freely.
+func (s *ThemeScheduler) Active() (string, StyleSheet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active, s.sheet.Copy()
+}
+
+// IsNight reports whether the night theme is active.
+func (s *ThemeScheduler) IsNight() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dark
+}
+
+// SetMode changes the mode of the scheduler, e.g. to let users force
+// the night theme, and updates the active theme.
+func (s *ThemeScheduler) SetMode(m ScheduleMode) {
+	s.mu.Lock()
+	s.mode = m
+	s.mu.Unlock()
+	s.Update()
+	// Let Run reconsider the time of the next switch.
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Update re-evaluates the schedule and notifies the subscribers if the
+// active theme changes. It returns true in that case. Run calls it at
+// every switch; applications with their own event loop can call it
+// instead, e.g. upon a tick.
+func (s *ThemeScheduler) Update() bool {
+	s.mu.Lock()
+	changed := s.activate()
+	name, sheet, subs := s.active, s.sheet, s.subs
+	s.mu.Unlock()
+	if changed {
+		for _, fn := range subs {
+			fn(name, sheet.Copy())
+		}
+	}
+	return changed
+}
+
+// selection returns the theme that should be active, and whether it
+// is the night theme. The caller must hold the lock.
+func (s *ThemeScheduler) selection() (name string, dark bool) {
+	dark = s.mode == ScheduleNight || (s.mode == ScheduleAuto && !s.isDaytime(s.now()))
+	if dark {
+		return s.night, true
+	}
+	return s.day, false
+}
+
+// activate updates the active theme. It returns true if it changed.
+// The caller must hold the lock.
+func (s *ThemeScheduler) activate() bool {
+	name, dark := s.selection()
+	if name == s.active && dark == s.dark {
+		return false
+	}
+	s.active, s.dark = name, dark
+	s.sheet = resolveSheetBackground(s.themes[name].Sheet, dark)
+	return true
+}
+
+// isDaytime reports whether the day theme applies at the given time.
+func (s *ThemeScheduler) isDaytime(t time.Time) bool {
+	afterDay := !t.Before(timeOfDay(t, 0, s.dayStart))
+	beforeNight := t.Before(timeOfDay(t, 0, s.nightStart))
+	if s.dayStart < s.nightStart {
+		return afterDay && beforeNight
+	}
+	return afterDay || beforeNight
+}
+
+// Next returns the time of the next switch between the day and night
+// themes in ScheduleAuto mode, or the zero time in the other modes.
+func (s *ThemeScheduler) Next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mode != ScheduleAuto {
+		return time.Time{}
+	}
+	now := s.now()
+	var next time.Time
+	for day := 0; day <= 1; day++ {
+		for _, offset := range []time.Duration{s.dayStart, s.nightStart} {
+			if t := timeOfDay(now, day, offset); t.After(now) && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
+		}
+	}
+	return next
+}
+
+// Run updates the active theme at every switch until stop is closed.
+func (s *ThemeScheduler) Run(stop <-chan struct{}) {
+	for {
+		s.Update()
+		var t *time.Timer
+		var timer <-chan time.Time
+		if next := s.Next(); !next.IsZero() {
+			t = time.NewTimer(next.Sub(s.now()))
+			timer = t.C
+		}
+		select {
+		case <-stop:
+		case <-timer:
+		case <-s.wake:
+		}
+		if t != nil {
+			t.Stop()
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+// timeOfDay returns the time at the given offset from midnight on the
+// day of t, shifted by the given number of days, as read on a clock in
+// the location of t. Unlike adding the offset to midnight, this gives
+// the expected time on the days when daylight saving time starts or
+// ends.
+func timeOfDay(t time.Time, days int, offset time.Duration) time.Time {
+	y, m, d := t.Date()
+	h, min, sec := int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second)
+	return time.Date(y, m, d+days, h, min, sec, 0, t.Location())
+}
+
+// resolveSheetBackground returns a copy of the sheet where the
+// adaptive colors of the styles, of the root style and of the palette
+// are replaced by their branch for the given background.
+func resolveSheetBackground(ss StyleSheet, dark bool) StyleSheet {
+	res := ss.Copy()
+	opt := options{dark: dark}
+	for name, s := range res.styles {
+		res.styles[name] = resolveStyleBackground(s, &opt)
+	}
+	if res.root != nil {
+		// Assign the root directly, to keep its important properties.
+		root := resolveStyleBackground(*res.root, &opt)
+		res.root = &root
+	}
+	for name, tc := range res.palette {
+		res.palette[name] = opt.resolveBackground(tc)
+	}
+	return res
+}
+
+// resolveStyleBackground replaces the adaptive colors of the style by
+// their branch for the background selected in opt.
+func resolveStyleBackground(s S, opt *options) S {
+	v := reflect.ValueOf(s)
+	for _, g := range styleGetters {
+		val := g.getFn.Call([]reflect.Value{v})[0]
+		if val.Type().Name() != "TerminalColor" || isDefault(val) {
+			continue
+		}
+		tc := opt.resolveBackground(val.Interface().(lipgloss.TerminalColor))
+		s = g.setFn.Call([]reflect.Value{reflect.ValueOf(s), reflect.ValueOf(tc)})[0].Interface().(S)
+	}
+	return s
+}
@@ -0,0 +1,164 @@
+package lipglossc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestThemeScheduler(t *testing.T) {
+	day, err := ImportSheet(StyleSheet{}, `title { foreground: adaptive(#111,#eee); bold: true; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	night, err := ImportSheet(StyleSheet{}, `title { foreground: adaptive(#222,#ddd); }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	themes := map[string]Theme{"day": {Sheet: day}, "night": {Sheet: night}}
+
+	now := time.Date(2022, 9, 7, 12, 0, 0, 0, time.UTC)
+	s, err := NewThemeScheduler(themes, "day", "night", withClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []string
+	s.Subscribe(func(name string, ss StyleSheet) {
+		st, _ := ss.Get("title")
+		events = append(events, name+": "+Export(st))
+	})
+	check := func(expName, expTitle string) {
+		t.Helper()
+		name, ss := s.Active()
+		st, _ := ss.Get("title")
+		if name != expName || Export(st) != expTitle {
+			t.Errorf("expected %s: %s, got %s: %s", expName, expTitle, name, Export(st))
+		}
+	}
+	check("day", `bold: true; foreground: #111;`)
+	if next := s.Next(); !next.Equal(time.Date(2022, 9, 7, 19, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next switch: %v", next)
+	}
+
+	if s.Update() {
+		t.Error("unexpected change")
+	}
+	now = now.Add(8 * time.Hour)
+	if !s.Update() || !s.IsNight() {
+		t.Error("expected switch to night")
+	}
+	check("night", `foreground: #ddd;`)
+	if next := s.Next(); !next.Equal(time.Date(2022, 9, 8, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next switch: %v", next)
+	}
+
+	// The mode can be forced.
+	s.SetMode(ScheduleDay)
+	check("day", `bold: true; foreground: #111;`)
+	if next := s.Next(); !next.IsZero() {
+		t.Errorf("unexpected next switch: %v", next)
+	}
+	s.SetMode(ScheduleAuto)
+	check("night", `foreground: #ddd;`)
+
+	exp := []string{"night: foreground: #ddd;", "day: bold: true; foreground: #111;", "night: foreground: #ddd;"}
+	if fmt.Sprint(events) != fmt.Sprint(exp) {
+		t.Errorf("expected events %q, got %q", exp, events)
+	}
+
+	// The same theme can be used day and night, with the adaptive
+	// colors resolved differently; the day can span midnight.
+	s, err = NewThemeScheduler(themes, "day", "day", withClock(func() time.Time { return now }),
+		WithDaytime(18*time.Hour, 6*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("day", `bold: true; foreground: #111;`)
+	now = now.Add(12 * time.Hour)
+	s.Update()
+	check("day", `bold: true; foreground: #eee;`)
+
+	if _, err := NewThemeScheduler(themes, "day", "dusk"); err == nil || err.Error() != `unknown theme: "dusk"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewThemeScheduler(themes, "day", "night", WithDaytime(time.Hour, time.Hour)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestThemeSchedulerRootAndPalette(t *testing.T) {
+	sheet, err := ImportSheet(StyleSheet{}, `
+@palette { text: adaptive(#111,#eee); }
+background: adaptive(#fff,#000) !important;
+title { foreground: text; }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2022, 9, 7, 22, 0, 0, 0, time.UTC)
+	s, err := NewThemeScheduler(map[string]Theme{"t": {Sheet: sheet}}, "t", "t", withClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ss := s.Active()
+	checkOutput(t, `@palette { text: #eee; }
+background: #000 !important;
+
+title { foreground: text; }
+`, ss.Export())
+}
+
+func TestThemeSchedulerDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	themes := map[string]Theme{"day": {}, "night": {}}
+	// Daylight saving time starts at 2:00 on March 8th, 2026.
+	now := time.Date(2026, 3, 8, 1, 0, 0, 0, loc)
+	s, err := NewThemeScheduler(themes, "day", "night", withClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(); !next.Equal(time.Date(2026, 3, 8, 7, 0, 0, 0, loc)) {
+		t.Errorf("unexpected next switch: %v", next)
+	}
+	now = time.Date(2026, 3, 8, 7, 30, 0, 0, loc)
+	if !s.Update() || s.IsNight() {
+		t.Error("expected switch to day")
+	}
+	// Daylight saving time ends at 2:00 on November 1st, 2026.
+	now = time.Date(2026, 11, 1, 18, 30, 0, 0, loc)
+	if next := s.Next(); !next.Equal(time.Date(2026, 11, 1, 19, 0, 0, 0, loc)) {
+		t.Errorf("unexpected next switch: %v", next)
+	}
+	if s.Update() {
+		t.Error("unexpected switch to night")
+	}
+}
+
+func TestThemeSchedulerRun(t *testing.T) {
+	themes := map[string]Theme{"day": {}, "night": {}}
+	s, err := NewThemeScheduler(themes, "day", "night", WithScheduleMode(ScheduleNight))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := make(chan string, 1)
+	s.Subscribe(func(name string, _ StyleSheet) { changed <- name })
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		s.Run(stop)
+		close(done)
+	}()
+	s.SetMode(ScheduleDay)
+	if name := <-changed; name != "day" {
+		t.Errorf("unexpected theme: %s", name)
+	}
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run did not stop")
+	}
+}
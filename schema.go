@@ -0,0 +1,74 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schema describes the styles that an application expects from a
+// theme, so that it can check that a loaded theme covers every
+// component it renders.
+type Schema map[string]StyleSchema
+
+// StyleSchema describes the expectations for one named style.
+type StyleSchema struct {
+	// Required, when set, reports a problem if the style is missing.
+	Required bool
+	// Allowed, when non-empty, lists the properties that the style may
+	// set. A name also allows the properties it prefixes, e.g.
+	// "padding" allows "padding-left" and "border" all the border
+	// properties.
+	Allowed []string
+	// Constraints checks the values of properties, keyed by property
+	// name as reported by Export, e.g. "padding-left". The functions
+	// receive the value as printed by Export.
+	Constraints map[string]func(value string) error
+}
+
+// Check reports the ways in which the given named styles do not
+// conform to the schema, in a stable order. Styles not mentioned in
+// the schema are not checked.
+func (sc Schema) Check(styles map[string]S) []string {
+	names := make([]string, 0, len(sc))
+	for name := range sc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		ss := sc[name]
+		s, ok := styles[name]
+		if !ok {
+			if ss.Required {
+				problems = append(problems, fmt.Sprintf("style %q: missing", name))
+			}
+			continue
+		}
+		for _, pv := range exportProps(s, &options{}) {
+			if !ss.allows(pv.name) {
+				problems = append(problems, fmt.Sprintf("style %q: property %q not allowed", name, pv.name))
+				continue
+			}
+			if fn := ss.Constraints[pv.name]; fn != nil {
+				if err := fn(pv.value); err != nil {
+					problems = append(problems, fmt.Sprintf("style %q: in %q: %v", name, pv.name+": "+pv.value, err))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func (ss StyleSchema) allows(prop string) bool {
+	if len(ss.Allowed) == 0 {
+		return true
+	}
+	for _, a := range ss.Allowed {
+		if prop == a || strings.HasPrefix(prop, a+"-") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSchema(t *testing.T) {
+	maxPadding := func(value string) error {
+		if n, _ := strconv.Atoi(value); n > 2 {
+			return fmt.Errorf("padding too large")
+		}
+		return nil
+	}
+	sc := Schema{
+		"title": {
+			Required:    true,
+			Allowed:     []string{"bold", "foreground", "padding"},
+			Constraints: map[string]func(string) error{"padding-left": maxPadding},
+		},
+		"footer":  {Required: true},
+		"tooltip": {},
+	}
+
+	title, err := Import(lipgloss.NewStyle(), `bold: true; padding: 1 3; margin-left: 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	styles := map[string]S{"title": title, "extra": lipgloss.NewStyle().Italic(true)}
+	exp := []string{
+		`style "footer": missing`,
+		`style "title": property "margin-left" not allowed`,
+		`style "title": in "padding-left: 3": padding too large`,
+	}
+	if problems := sc.Check(styles); fmt.Sprint(problems) != fmt.Sprint(exp) {
+		t.Errorf("expected %q, got %q", exp, problems)
+	}
+
+	styles = map[string]S{"title": lipgloss.NewStyle().Bold(true), "footer": lipgloss.NewStyle()}
+	if problems := sc.Check(styles); len(problems) != 0 {
+		t.Errorf("unexpected problems: %q", problems)
+	}
+}
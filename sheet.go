@@ -0,0 +1,969 @@
+package lipglossc
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StyleSheet is a set of named styles, as read from a stylesheet
+// document by ImportSheet.
+//
+// The zero value is an empty sheet ready to use.
+type StyleSheet struct {
+	styles map[string]S
+	// root is the style defined by the directives outside of any
+	// block, if any. See Root.
+	root *S
+	// mixins are the mixins defined with @mixin, available to the
+	// later documents imported into the sheet.
+	mixins map[string]mixin
+	// vars are the variables defined at the top level of documents,
+	// without "$".
+	vars map[string]string
+	// palette are the colors defined with @palette.
+	palette map[string]lipgloss.TerminalColor
+	// borders are the borders defined with @define-border.
+	borders map[string]lipgloss.Border
+	// important maps style names to the properties marked !important
+	// in the styles. The empty name designates the root style.
+	important map[string]map[string]bool
+}
+
+// mixin is a reusable block defined with @mixin.
+type mixin struct {
+	// params are the names of the parameters, without "$".
+	params []string
+	body   string
+}
+
+// Get retrieves the named style. The result is a copy and can be
+// modified freely.
+//
+// With states, Get retrieves the variant of the style for these
+// states, e.g. Get("button", "focused") retrieves the style defined by
+// a "button:focused" block. If the variant is not defined, Get falls
+// back to the variant for fewer states, dropping the last ones first,
+// and ultimately to the style itself.
+func (ss StyleSheet) Get(name string, states ...string) (S, bool) {
+	for i := len(states); i >= 0; i-- {
+		s, ok := ss.styles[variantName(name, states[:i])]
+		if ok {
+			return s.Copy(), true
+		}
+	}
+	return S{}, false
+}
+
+// variantName returns the name of the variant of a style for the
+// given states, e.g. "button:focused".
+func variantName(name string, states []string) string {
+	if len(states) == 0 {
+		return name
+	}
+	return name + ":" + strings.Join(states, ":")
+}
+
+// Set defines or replaces the named style. The style is copied, so the
+// caller can continue to use the argument. None of its properties are
+// marked !important.
+func (ss *StyleSheet) Set(name string, s S) {
+	if ss.styles == nil {
+		ss.styles = map[string]S{}
+	}
+	ss.styles[name] = s.Copy()
+	delete(ss.important, name)
+}
+
+// Important returns the names of the properties marked !important in
+// the named style, in sorted order; the empty name designates the root
+// style. The later blocks of the sheet, the sheets merged over it with
+// Merge, and the later layers given to ComposeLayers or Resolve do not
+// override these properties unless they also mark them important.
+func (ss StyleSheet) Important(name string) []string {
+	return importantList(ss.important[name])
+}
+
+// importantOf returns a copy of the set of properties marked
+// !important in the named style.
+func (ss StyleSheet) importantOf(name string) map[string]bool {
+	res := make(map[string]bool, len(ss.important[name]))
+	for prop := range ss.important[name] {
+		res[prop] = true
+	}
+	return res
+}
+
+// setImportant records the set of properties marked !important in
+// the named style.
+func (ss *StyleSheet) setImportant(name string, important map[string]bool) {
+	if len(important) == 0 {
+		delete(ss.important, name)
+		return
+	}
+	if ss.important == nil {
+		ss.important = map[string]map[string]bool{}
+	}
+	ss.important[name] = important
+}
+
+// Names returns the names of the styles in the sheet, in sorted order.
+func (ss StyleSheet) Names() []string {
+	names := make([]string, 0, len(ss.styles))
+	for name := range ss.styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Len returns the number of styles in the sheet.
+func (ss StyleSheet) Len() int {
+	return len(ss.styles)
+}
+
+// Styles returns a copy of the styles in the sheet, for use with the
+// functions that operate on maps of named styles, e.g. Resolve or
+// GenerateGo.
+func (ss StyleSheet) Styles() map[string]S {
+	res := make(map[string]S, len(ss.styles))
+	for name, s := range ss.styles {
+		res[name] = s.Copy()
+	}
+	return res
+}
+
+// Variables returns a copy of the variables defined at the top level
+// of the documents imported into the sheet, keyed by name without
+// "$". They can be passed to WithVariables to preserve them in
+// exports.
+func (ss StyleSheet) Variables() map[string]string {
+	res := make(map[string]string, len(ss.vars))
+	for name, v := range ss.vars {
+		res[name] = v
+	}
+	return res
+}
+
+// Copy returns a deep copy of the sheet.
+func (ss StyleSheet) Copy() StyleSheet {
+	res := StyleSheet{styles: ss.Styles(), mixins: make(map[string]mixin, len(ss.mixins)), vars: ss.Variables()}
+	if ss.root != nil {
+		res.SetRoot(*ss.root)
+	}
+	for name := range ss.important {
+		res.setImportant(name, ss.importantOf(name))
+	}
+	for name, m := range ss.mixins {
+		res.mixins[name] = m
+	}
+	if ss.palette != nil {
+		res.palette = make(map[string]lipgloss.TerminalColor, len(ss.palette))
+		for name, tc := range ss.palette {
+			res.palette[name] = tc
+		}
+	}
+	if ss.borders != nil {
+		res.borders = make(map[string]lipgloss.Border, len(ss.borders))
+		for name, b := range ss.borders {
+			res.borders[name] = b
+		}
+	}
+	return res
+}
+
+// ImportSheet reads a stylesheet document containing named style
+// blocks, for example:
+//
+//	title { bold: true; foreground: #fafafa; }
+//	footer { faint: true; }
+//
+// and applies each block to the style with that name in dst, as per
+// Import. The other styles in dst remain unchanged. If a name appears
+// in multiple blocks, the blocks are applied in order; the values
+// marked !important in a block survive the later blocks, unless they
+// also mark them important, see Important.
+//
+// A block can apply to multiple styles, separated by commas:
+//
+//	title, footer { padding: 0 1; }
+//
+// A name containing wildcards, e.g. "*.title" or "list.*", applies the
+// block to the styles already defined with a matching name, as per
+// ApplyMatching.
+//
+// A style name can be followed by states, to define a variant of the
+// style for these states, as retrieved by Get:
+//
+//	button { padding: 0 1; }
+//	button:focused { extends: button; bold: true; }
+//	button:disabled { extends: button; faint: true; }
+//
+// Like any other style, a variant only has the properties of its
+// base style if it extends it.
+//
+// A block can start from the properties of other styles defined
+// earlier, with an extends directive:
+//
+//	base { foreground: #fafafa; padding: 0 1; }
+//	title { extends: base; bold: true; }
+//
+// The properties of the extended styles are applied, in order and as
+// per Compose, before the other directives of the block wherever the
+// extends directive appears in it.
+// In contrast, the apply directive composes the styles at the point
+// where it appears, over the previous directives; see WithStyles.
+//
+// Reusable groups of directives can be defined with @mixin, and
+// included in a block with @include. Mixins can have parameters,
+// which are substituted in their body:
+//
+//	@mixin emphasized { bold: true; foreground: #f00; }
+//	@mixin bordered($color) { border: rounded; border-foreground: $color; }
+//	title { @include emphasized; @include bordered(#fafafa); }
+//
+// The directives of a mixin are applied where it is included. Mixins
+// remain defined in the resulting sheet for later calls to
+// ImportSheet.
+//
+// A value can refer to a property of another style defined earlier,
+// with styleref(<style>.<property>), or its short form
+// styles.<style>.<property>:
+//
+//	help { foreground: styleref(header.foreground); }
+//	footer { foreground: styles.header.foreground; }
+//
+// The reference is replaced by the value of the property at that
+// point, including its default value if the property is not set.
+//
+// Sections introduced by @dark or @light contain blocks that are
+// applied only on a terminal with a dark, respectively light,
+// background; see WithBackgroundMode. Sections introduced by @profile
+// apply only for the listed color profiles; see WithImportProfile.
+// The same sections can also be used within blocks, as per Import:
+//
+//	@dark { title { foreground: #fafafa; } }
+//	@profile 16 { title { bold: true; } }
+//	footer { @light { bold: true; } }
+//
+// A "@remove <name>, ...;" directive removes the named styles, or
+// those matching a name with wildcards, from the sheet.
+//
+// Variables defined at the top level, e.g. "$accent: #7D56F4;", can be
+// used in all the blocks that follow, and remain defined in the
+// resulting sheet. Variables defined in a block are local to it.
+//
+// Named colors can be defined in a @palette section, and used as
+// values of the color properties in all the blocks that follow:
+//
+//	@palette { accent: #7D56F4; danger: #ff5555; }
+//	title { foreground: accent; }
+//
+// Like variables, the palette remains defined in the resulting sheet.
+//
+// Likewise, custom borders can be defined once with @define-border,
+// with the same strings as border(...) values, and used by name in
+// the border properties:
+//
+//	@define-border fancy("═","═","║","║","╔","╗","╝","╚");
+//	dialog { border-style: fancy; }
+//
+// A document can declare its version with @version, and contain
+// sections for other versions, e.g. to keep the blocks of previous
+// versions of a theme while its styles are renamed:
+//
+//	@version "2";
+//	@version "1" { title { foreground: #f00; } }
+//	@version "2" { heading { foreground: #f00; } }
+//
+// Only the sections of the selected version are applied, by default
+// the declared version; see WithThemeVersion.
+//
+// Directives outside of any block define the root style of the sheet,
+// which the styles defined afterwards start from; see Root. This way,
+// a single document can define a base style and named overrides:
+//
+//	foreground: #fafafa;
+//	title { bold: true; }
+//
+// The result is a new sheet; dst is not modified.
+func ImportSheet(dst StyleSheet, input string, opts ...ImportOption) (StyleSheet, error) {
+	ss := dst.Copy()
+	p := sheetParser{input: input, line: 1}
+	if err := p.parseDocument(&ss, opts); err != nil {
+		return dst, err
+	}
+	return ss, nil
+}
+
+// parseDocument removes the comments from the document and replaces
+// the references to environment variables, then reads it into the
+// sheet.
+func (p *sheetParser) parseDocument(ss *StyleSheet, opts []ImportOption) error {
+	input, unterminated := blankComments(p.input)
+	if unterminated >= 0 {
+		return p.errorf(p.line+strings.Count(p.input[:unterminated], "\n"), "unterminated comment")
+	}
+	opt := makeImportOptions(opts)
+	input, offset, err := opt.interpolateEnv(input)
+	if err != nil {
+		return p.errorf(p.line+strings.Count(p.input[:offset], "\n"), "%v", err)
+	}
+	p.input = input
+	p.versions = &sheetVersions{known: map[string]bool{}}
+	if err := p.parse(ss, opts); err != nil {
+		return err
+	}
+	return p.checkVersion(opts)
+}
+
+// parse reads the document into the sheet.
+func (p *sheetParser) parse(ss *StyleSheet, opts []ImportOption) error {
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil
+		}
+		start := p.pos
+		var err error
+		switch rest := p.input[p.pos:]; {
+		case strings.HasPrefix(rest, "@mixin"):
+			err = p.mixin(ss)
+		case strings.HasPrefix(rest, "@remove"):
+			err = p.remove(ss)
+		case strings.HasPrefix(rest, "@palette"):
+			err = p.palette(ss, opts)
+		case strings.HasPrefix(rest, "@define-border"):
+			err = p.defineBorder(ss)
+		case strings.HasPrefix(rest, "@theme"):
+			err = p.themeHeader(opts)
+		case strings.HasPrefix(rest, "@version"):
+			err = p.version(ss, opts)
+		case strings.HasPrefix(rest, "@import"):
+			err = p.importFile(ss, opts)
+		case strings.HasPrefix(rest, "@dark") || strings.HasPrefix(rest, "@light") || strings.HasPrefix(rest, "@profile"):
+			err = p.conditional(ss, opts)
+		case rest[0] == '$':
+			err = p.variable(ss)
+		case isRootDirective(rest):
+			err = p.rootDirective(ss, opts)
+		default:
+			err = p.styleBlock(ss, opts)
+		}
+		if err != nil {
+			if err := p.report(err, start); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// styleBlock reads a block and applies it to the styles named by its
+// selectors.
+func (p *sheetParser) styleBlock(ss *StyleSheet, opts []ImportOption) error {
+	start, line := p.pos, p.line
+	names, err := p.selectors()
+	if err != nil {
+		if p.errs != nil && p.pos > start {
+			// Skip the block, to validate the rest of the document.
+			p.report(err, start)
+			_, err = p.block([]string{strings.TrimSpace(p.input[start : p.pos-1])})
+		}
+		return err
+	}
+	bodyStart := p.pos
+	body, err := p.block(names)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if p.only != nil && !p.only[name] {
+			continue
+		}
+		if p.errs != nil {
+			p.validateBlock(ss, name, body, bodyStart, opts)
+			continue
+		}
+		if err := ss.applyBlock(name, body, opts); err != nil {
+			err.Pos = SourcePos{File: p.file, Line: line}
+			return err
+		}
+	}
+	return nil
+}
+
+// applyBlock applies the body of a block to the named style, or to the
+// matching styles if the name contains wildcards. The empty name
+// designates the root style. A style not defined yet starts from the
+// root style.
+//
+// The error, if any, identifies the style but not the position of the
+// block.
+func (ss *StyleSheet) applyBlock(name, body string, opts []ImportOption) *SheetError {
+	if name == "" {
+		s, _ := ss.Root()
+		important := ss.importantOf("")
+		s, err := ss.importBlock(s, body, important, opts)
+		if err != nil {
+			return &SheetError{Err: err}
+		}
+		ss.SetRoot(s)
+		ss.setImportant("", important)
+		return nil
+	}
+	if !strings.ContainsAny(name, "*?[") {
+		s, ok := ss.styles[name]
+		important := ss.importantOf(name)
+		if !ok {
+			s, _ = ss.Root()
+			important = ss.importantOf("")
+		}
+		s, err := ss.importBlock(s, body, important, opts)
+		if err != nil {
+			return &SheetError{Style: name, Err: err}
+		}
+		ss.Set(name, s)
+		ss.setImportant(name, important)
+		return nil
+	}
+	for _, other := range ss.Names() {
+		if !matchName(name, other) {
+			continue
+		}
+		important := ss.importantOf(other)
+		s, err := ss.importBlock(ss.styles[other], body, important, opts)
+		if err != nil {
+			return &SheetError{Style: other, Err: err}
+		}
+		ss.styles[other] = s
+		ss.setImportant(other, important)
+	}
+	return nil
+}
+
+// importBlock applies the extends directives of a block, then the
+// other directives. The properties marked !important in the style so
+// far are in important, which receives those marked by the block.
+func (ss *StyleSheet) importBlock(dst S, body string, important map[string]bool, opts []ImportOption) (S, error) {
+	sep := makeImportOptions(opts).sep
+	directives, err := ss.expandIncludes(body, sep, nil)
+	if err != nil {
+		return dst, err
+	}
+	var rest []string
+	for _, a := range directives {
+		propName, args, ok := splitAssignment(a)
+		if !ok || propName != "extends" {
+			a, err := ss.expandStyleRefs(a)
+			if err != nil {
+				return dst, err
+			}
+			rest = append(rest, a)
+			continue
+		}
+		for _, base := range strings.Fields(args) {
+			s, ok := ss.styles[base]
+			if !ok {
+				return dst, fmt.Errorf("in %q: unknown style %q", a, base)
+			}
+			dst = overlay(dst, s, important, ss.important[base], nil)
+		}
+	}
+	opts = append(opts[:len(opts):len(opts)], WithImportVariables(ss.vars), withPalette(ss.Palette()), withBorders(ss.Borders()), WithStyles(ss.styles), withImportant(important))
+	return Import(dst, strings.Join(rest, sep), opts...)
+}
+
+// reStyleRef matches styleref(<style>.<property>) values.
+var reStyleRef = regexp.MustCompile(`styleref\(\s*([^()\s]*)\s*\)`)
+
+// reStylesRef matches styles.<style>.<property> values, the short
+// form of styleref(), preceded by the start of the value, a space, a
+// comma or a parenthesis.
+var reStylesRef = regexp.MustCompile(`(^|[\s(,])styles\.([^\s(),;"]+)`)
+
+// expandStyleRefs replaces the styleref() and styles.xxx values in a
+// directive by the value of the referenced property.
+func (ss *StyleSheet) expandStyleRefs(a string) (string, error) {
+	if !strings.Contains(a, "styleref(") && !strings.Contains(a, "styles.") {
+		return a, nil
+	}
+	var err error
+	resolve := func(ref, target string) string {
+		i := strings.LastIndexByte(target, '.')
+		if i < 0 {
+			err = fmt.Errorf("in %q: invalid reference: %s", a, ref)
+			return ref
+		}
+		name, propName := target[:i], target[i+1:]
+		s, ok := ss.styles[name]
+		if !ok {
+			err = fmt.Errorf("in %q: unknown style %q", a, name)
+			return ref
+		}
+		for _, pv := range exportProps(s, &options{includeDefaults: true}) {
+			if pv.name == propName {
+				return pv.value
+			}
+		}
+		err = fmt.Errorf("in %q: unknown property %q", a, propName)
+		return ref
+	}
+	res := reStyleRef.ReplaceAllStringFunc(a, func(ref string) string {
+		return resolve(ref, reStyleRef.FindStringSubmatch(ref)[1])
+	})
+	res = reStylesRef.ReplaceAllStringFunc(res, func(ref string) string {
+		m := reStylesRef.FindStringSubmatch(ref)
+		return m[1] + resolve(ref[len(m[1]):], m[2])
+	})
+	return res, err
+}
+
+// reInclude matches @include directives.
+var reInclude = regexp.MustCompile(`^@include\s+([\w-]+)\s*(?:\((.*)\))?$`)
+
+// reParam matches references to mixin parameters.
+var reParam = regexp.MustCompile(`\$[\w-]+`)
+
+// expandIncludes splits the body of a block into directives, replacing
+// the @include directives by the directives of the mixins. The stack
+// lists the mixins being expanded, to detect cycles.
+func (ss *StyleSheet) expandIncludes(body, sep string, stack []string) ([]string, error) {
+	var res []string
+	for _, a := range splitAssignments(body, sep) {
+		if !strings.HasPrefix(a, "@include") {
+			res = append(res, a)
+			continue
+		}
+		m := reInclude.FindStringSubmatch(a)
+		if m == nil {
+			return nil, fmt.Errorf("invalid syntax: %q", a)
+		}
+		name := m[1]
+		mx, ok := ss.mixins[name]
+		if !ok {
+			return nil, fmt.Errorf("in %q: unknown mixin %q", a, name)
+		}
+		for _, other := range stack {
+			if other == name {
+				return nil, fmt.Errorf("in %q: mixin %q includes itself", a, name)
+			}
+		}
+		var args []string
+		if strings.TrimSpace(m[2]) != "" {
+			args = splitArgs(m[2])
+		}
+		if len(args) != len(mx.params) {
+			return nil, fmt.Errorf("in %q: mixin %q expects %d arguments, got %d", a, name, len(mx.params), len(args))
+		}
+		mbody := reParam.ReplaceAllStringFunc(mx.body, func(ref string) string {
+			for i, p := range mx.params {
+				if ref == "$"+p {
+					return args[i]
+				}
+			}
+			return ref
+		})
+		expanded, err := ss.expandIncludes(mbody, sep, append(stack, name))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, expanded...)
+	}
+	return res, nil
+}
+
+// splitArgs splits comma-separated arguments, ignoring the commas
+// within parentheses and double-quoted strings.
+func splitArgs(s string) []string {
+	var args []string
+	depth, start, inString := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(args, strings.TrimSpace(s[start:]))
+}
+
+// reMixin matches the header of @mixin definitions.
+var reMixin = regexp.MustCompile(`^@mixin\s+([\w-]+)\s*(?:\(([^)]*)\))?\s*$`)
+
+// reStyleName matches the names of styles in sheets, possibly with
+// wildcards, and possibly followed by states, e.g. "button:focused".
+var reStyleName = regexp.MustCompile(`^[\w*?\[\]-]+(\.[\w*?\[\]-]+)*(:[\w-]+)*$`)
+
+// sheetParser splits a stylesheet document into blocks.
+type sheetParser struct {
+	input string
+	pos   int
+	// line is the 1-based line number at pos.
+	line int
+	// file is the name of the document, if known.
+	file string
+	// open, if set, reads the documents included with @import.
+	open func(name string) (string, error)
+	// stack lists the documents being imported, to detect cycles.
+	stack []string
+	// theme, if set, receives the metadata of the @theme sections.
+	theme *Theme
+	// errs, if set, enables the validation mode: the errors are
+	// recorded there and parsing continues, see ValidateSheet.
+	errs *[]*SheetError
+	// only, if set, restricts the style blocks applied to those of the
+	// listed styles, see importIncremental.
+	only map[string]bool
+	// versions tracks the @version directives at the top level of the
+	// document.
+	versions *sheetVersions
+}
+
+// errorf reports an error at the given line of the document.
+func (p *sheetParser) errorf(line int, format string, args ...interface{}) error {
+	return &SheetError{Pos: SourcePos{File: p.file, Line: line}, Err: fmt.Errorf(format, args...)}
+}
+
+// importFile processes an @import directive: the named document,
+// relative to the current one, is read into the sheet at this point.
+func (p *sheetParser) importFile(ss *StyleSheet, opts []ImportOption) error {
+	line := p.line
+	i := indexOutsideStrings(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != ';' {
+		return p.errorf(line, "expected \";\" after @import")
+	}
+	a := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	p.advance(i + 1)
+	target, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(a, "@import")))
+	if err != nil || target == "" {
+		return p.errorf(line, "invalid syntax: %q", a)
+	}
+	if p.open == nil {
+		return p.errorf(line, "@import is only supported by ImportSheetFS")
+	}
+	name := path.Join(path.Dir(p.file), target)
+	for _, other := range p.stack {
+		if other == name {
+			return p.errorf(line, "import cycle: %s", strings.Join(append(p.stack, name), " -> "))
+		}
+	}
+	input, err := p.open(name)
+	if err != nil {
+		return p.errorf(line, "%v", err)
+	}
+	sub := sheetParser{
+		input: input,
+		line:  1,
+		file:  name,
+		open:  p.open,
+		stack: append(p.stack[:len(p.stack):len(p.stack)], name),
+		errs:  p.errs,
+	}
+	return sub.parseDocument(ss, opts)
+}
+
+// remove processes a @remove directive, which removes the named
+// styles, or those matching a name with wildcards, from the sheet.
+func (p *sheetParser) remove(ss *StyleSheet) error {
+	line := p.line
+	i := strings.IndexAny(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != ';' {
+		return p.errorf(line, "expected \";\" after @remove")
+	}
+	a := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	p.advance(i + 1)
+	for _, name := range strings.Split(strings.TrimPrefix(a, "@remove"), ",") {
+		name = strings.TrimSpace(name)
+		if !reStyleName.MatchString(name) {
+			return p.errorf(line, "invalid style name: %q", name)
+		}
+		if !strings.ContainsAny(name, "*?[") {
+			delete(ss.styles, name)
+			delete(ss.important, name)
+			continue
+		}
+		for _, other := range ss.Names() {
+			if matchName(name, other) {
+				delete(ss.styles, other)
+				delete(ss.important, other)
+			}
+		}
+	}
+	return nil
+}
+
+// conditional processes a @dark, @light or @profile section: its
+// contents are read into the sheet only if its condition holds.
+func (p *sheetParser) conditional(ss *StyleSheet, opts []ImportOption) error {
+	line := p.line
+	i := strings.IndexAny(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != '{' {
+		return p.errorf(line, "expected \"{\" after %s", strings.Fields(p.input[p.pos:])[0])
+	}
+	name := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	c, err := parseCondition(name)
+	if err != nil {
+		return p.errorf(line, "%v", err)
+	}
+	p.advance(i + 1)
+	start, startLine := p.pos, p.line
+	if _, err := p.block([]string{name}); err != nil {
+		return err
+	}
+	if opt := makeImportOptions(opts); !opt.holds(c) {
+		return nil
+	}
+	sub := sheetParser{
+		input: p.input[:p.pos-1],
+		pos:   start,
+		line:  startLine,
+		file:  p.file,
+		open:  p.open,
+		stack: p.stack,
+		errs:  p.errs,
+	}
+	return sub.parse(ss, opts)
+}
+
+func (p *sheetParser) skipSpace() {
+	for p.pos < len(p.input) && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+		p.advance(1)
+	}
+}
+
+func (p *sheetParser) advance(n int) {
+	p.line += strings.Count(p.input[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// selectors reads the comma-separated style names before a block,
+// up to and including the opening brace.
+func (p *sheetParser) selectors() ([]string, error) {
+	line := p.line
+	i := strings.IndexAny(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != '{' {
+		return nil, p.errorf(line, "expected style name followed by \"{\"")
+	}
+	text := p.input[p.pos : p.pos+i]
+	p.advance(i + 1)
+	var names []string
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		if !reStyleName.MatchString(name) {
+			return nil, p.errorf(line, "invalid style name: %q", name)
+		}
+		if _, err := path.Match(name, ""); err != nil {
+			return nil, p.errorf(line, "invalid pattern %q: %v", name, err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// variable reads a top-level variable definition into the sheet.
+func (p *sheetParser) variable(ss *StyleSheet) error {
+	line := p.line
+	i := indexOutsideStrings(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != ';' {
+		return p.errorf(line, "expected \";\" after variable definition")
+	}
+	a := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	p.advance(i + 1)
+	name, value, ok := splitAssignment(a)
+	if !ok {
+		return p.errorf(line, "invalid syntax: %q", a)
+	}
+	opt := makeImportOptions([]ImportOption{WithImportVariables(ss.vars)})
+	if err := opt.defineVariable(name, value); err != nil {
+		return p.errorf(line, "in %q: %v", a, err)
+	}
+	ss.vars = opt.vars
+	return nil
+}
+
+// mixin reads a @mixin definition into the sheet.
+func (p *sheetParser) mixin(ss *StyleSheet) error {
+	line := p.line
+	i := strings.IndexAny(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != '{' {
+		return p.errorf(line, "expected mixin name followed by \"{\"")
+	}
+	header := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	m := reMixin.FindStringSubmatch(header)
+	if m == nil {
+		return p.errorf(line, "invalid mixin definition: %q", header)
+	}
+	p.advance(i + 1)
+	var params []string
+	if strings.TrimSpace(m[2]) != "" {
+		for _, param := range strings.Split(m[2], ",") {
+			param = strings.TrimSpace(param)
+			if !reParam.MatchString(param) || reParam.FindString(param) != param {
+				return p.errorf(line, "invalid mixin parameter: %q", param)
+			}
+			params = append(params, strings.TrimPrefix(param, "$"))
+		}
+	}
+	body, err := p.block([]string{"@mixin " + m[1]})
+	if err != nil {
+		return err
+	}
+	if ss.mixins == nil {
+		ss.mixins = map[string]mixin{}
+	}
+	ss.mixins[m[1]] = mixin{params: params, body: body}
+	return nil
+}
+
+// block reads the body of a block, up to and including the closing
+// brace. The body can contain nested property blocks. Braces within
+// double-quoted strings do not count.
+func (p *sheetParser) block(names []string) (string, error) {
+	start, line := p.pos, p.line
+	inString := false
+	depth := 0
+	for i := p.pos; i < len(p.input); i++ {
+		switch c := p.input[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && c == '{':
+			depth++
+		case !inString && c == '}' && depth > 0:
+			depth--
+		case !inString && c == '}':
+			p.advance(i + 1 - p.pos)
+			return p.input[start:i], nil
+		}
+	}
+	// Nothing can be read after an unterminated block.
+	p.advance(len(p.input) - p.pos)
+	return "", p.errorf(line, "unterminated block for %q", strings.Join(names, ", "))
+}
+
+// Export emits the named styles of the sheet as a document suitable
+// for ImportSheet, with the styles in alphabetical order. The options
+// are those of Export and apply to every style; WithShellQuoting is
+// ignored.
+//
+// The palette of the sheet is defined at the start of the document,
+// and the colors identical to its entries are replaced by their name.
+// The borders defined with @define-border follow, and are likewise
+// referenced by name. The variables of the sheet, and those given
+// with WithVariables, are defined next, and the values identical to
+// theirs are replaced by references. When the separator contains a
+// newline, each block spans multiple lines with the directives
+// indented, otherwise each block is printed on a single line:
+//
+//	@palette { danger: #ff5555; }
+//	$accent: #7D56F4;
+//
+//	footer { faint: true; }
+//	title { bold: true; foreground: $accent; }
+//	warning { foreground: danger; }
+//
+// Like Export, it is safe to call concurrently, as long as the sheet
+// is not modified at the same time.
+func (ss StyleSheet) Export(opts ...ExportOption) string {
+	opt := makeExportOptions(opts)
+	opt.shellQuote = false
+	vars := make(map[string]string, len(ss.vars)+len(opt.vars))
+	for name, value := range ss.vars {
+		vars[name] = value
+	}
+	for name, value := range opt.vars {
+		vars[name] = value
+	}
+	opt.vars = vars
+	defs := exportVariables(&opt)
+	multiline := strings.Contains(opt.sep, "\n")
+	if multiline {
+		opt.sep += "  "
+	}
+	printBlock := func(buf *strings.Builder, name, props string) {
+		switch {
+		case props == "":
+			fmt.Fprintf(buf, "%s {}\n", name)
+		case multiline:
+			fmt.Fprintf(buf, "%s {\n  %s\n}\n", name, props)
+		default:
+			fmt.Fprintf(buf, "%s { %s }\n", name, props)
+		}
+	}
+
+	var buf strings.Builder
+	if palette := ss.exportPalette(&opt); len(palette) > 0 {
+		printBlock(&buf, "@palette", printDirectives(nil, palette, &opt))
+	}
+	for _, d := range ss.exportBorders(&opt) {
+		fmt.Fprintf(&buf, "%s\n", d)
+	}
+	for _, d := range defs {
+		fmt.Fprintf(&buf, "%s: %s;\n", d.name, quoteValue(d.value))
+	}
+	if ss.root != nil {
+		rootOpt := opt
+		rootOpt.sep = strings.TrimSuffix(opt.sep, "  ")
+		rootOpt.important = ss.important[""]
+		if props := ss.exportStyle("", &rootOpt); len(props) > 0 {
+			fmt.Fprintf(&buf, "%s\n", printDirectives(defs, props, &rootOpt))
+		}
+	}
+	preamble := buf.Len() > 0
+	for i, name := range ss.Names() {
+		if (multiline && i > 0) || (i == 0 && preamble) {
+			buf.WriteByte('\n')
+		}
+		styleOpt := opt
+		styleOpt.important = ss.important[name]
+		printBlock(&buf, name, printDirectives(defs, ss.exportStyle(name, &styleOpt), &styleOpt))
+	}
+	return buf.String()
+}
+
+// exportStyle returns the properties of the named style for Export, or
+// of the root style for the empty name: those that differ from the
+// root style, or from the defaults if the sheet has no root style, and
+// those marked !important in opt.important that the root style does
+// not mark, whatever their value.
+func (ss StyleSheet) exportStyle(name string, opt *options) []propValue {
+	s, rootImportant := ss.styles[name], ss.important[""]
+	if name == "" {
+		s, rootImportant = *ss.root, nil
+	}
+	props := exportProps(s, opt)
+	if ss.root != nil && name != "" {
+		props = exportOverrides(*ss.root, s, opt)
+	}
+	if len(opt.important) == 0 {
+		return props
+	}
+	keep := make(map[string]bool, len(props))
+	for _, pv := range props {
+		keep[pv.name] = true
+	}
+	all := *opt
+	all.includeDefaults = true
+	var res []propValue
+	for _, pv := range exportProps(s, &all) {
+		if keep[pv.name] || (opt.important[pv.name] && !rootImportant[pv.name]) {
+			res = append(res, pv)
+		}
+	}
+	return res
+}
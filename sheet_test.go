@@ -0,0 +1,374 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestImportSheet(t *testing.T) {
+	const input = `
+title { bold: true; foreground: #fafafa; }
+footer {
+  faint: true;
+}
+list.title, dialog.title { padding: 0 1 }
+*.title { italic: true; }
+title { underline: true }
+status-bar { border-style: border("{","}","|","|","+","+","+","+") }
+`
+	var base StyleSheet
+	base.Set("list.item", lipgloss.NewStyle().Faint(true))
+	ss, err := ImportSheet(base, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Len() != 1 {
+		t.Errorf("dst was modified")
+	}
+	if fmt.Sprint(ss.Names()) != "[dialog.title footer list.item list.title status-bar title]" {
+		t.Errorf("unexpected names: %v", ss.Names())
+	}
+	for name, exp := range map[string]string{
+		"title":        `bold: true; foreground: #fafafa; underline: true;`,
+		"footer":       `faint: true;`,
+		"list.item":    `faint: true;`,
+		"list.title":   `italic: true; padding-left: 1; padding-right: 1;`,
+		"dialog.title": `italic: true; padding-left: 1; padding-right: 1;`,
+		"status-bar":   `border-style: border("{","}","|","|","+","+","+","+");`,
+	} {
+		s, ok := ss.Get(name)
+		if !ok {
+			t.Errorf("%s: not found", name)
+			continue
+		}
+		if res := Export(s); res != exp {
+			t.Errorf("%s: expected %q, got %q", name, exp, res)
+		}
+	}
+
+	// Get and Styles return copies.
+	s, _ := ss.Get("footer")
+	s.Bold(true)
+	ss.Styles()["footer"].Italic(true)
+	s, _ = ss.Get("footer")
+	checkOutput(t, `faint: true;`, Export(s))
+}
+
+func TestImportSheetErrors(t *testing.T) {
+	td := []struct {
+		in     string
+		expErr string
+	}{
+		{`bold: maybe`, `line 1: in "bold: maybe": no value found; bold expects true or false`},
+		{"title { bold: true }\n\nfooter;", `line 3: expected style name followed by "{"`},
+		{"title { bold: true }\n\nfooter", `line 3: expected style name followed by "{"`},
+		{"title {\n bold: true", `line 1: unterminated block for "title"`},
+		{"a, b {\n bold: true; { }", `line 1: unterminated block for "a, b"`},
+		{"a, b {\n bold: true; { } }", `line 1: style "a": invalid syntax: "{"`},
+		{`my title { bold: true }`, `line 1: invalid style name: "my title"`},
+		{`a,,b { bold: true }`, `line 1: invalid style name: ""`},
+		{`[a { bold: true }`, `line 1: invalid pattern "[a": syntax error in pattern`},
+		{"title { bold: true }\nfooter {\n bold: maybe }", `line 2: style "footer": in "bold: maybe": no value found; bold expects true or false`},
+		{"title { extends: base; bold: true }", `line 1: style "title": in "extends: base": unknown style "base"`},
+		{`button: { bold: true }`, `line 1: invalid style name: "button:"`},
+		{`button:foc*sed { bold: true }`, `line 1: invalid style name: "button:foc*sed"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			_, err := ImportSheet(StyleSheet{}, tc.in)
+			if err == nil || err.Error() != tc.expErr {
+				t.Errorf("expected %q, got %v", tc.expErr, err)
+			}
+		})
+	}
+}
+
+func TestImportSheetExtends(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+base { foreground: #fafafa; padding: 0 1; }
+accent { foreground: #f00; italic: true }
+title { bold: true; extends: base accent; padding-left: 2 }
+*.item { extends: base }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("title")
+	checkOutput(t, `bold: true; foreground: #f00; italic: true; padding-left: 2; padding-right: 1;`, Export(s))
+}
+
+func TestImportSheetVariants(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+button { padding: 0 1; }
+button:focused { extends: button; bold: true; }
+button:focused:disabled { faint: true; }
+button:disabled { extends: button; faint: true; }
+label { foreground: styles.button:focused.padding-left; }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	td := []struct {
+		states []string
+		exp    string
+	}{
+		{nil, `padding-left: 1; padding-right: 1;`},
+		{[]string{"focused"}, `bold: true; padding-left: 1; padding-right: 1;`},
+		{[]string{"disabled"}, `faint: true; padding-left: 1; padding-right: 1;`},
+		{[]string{"focused", "disabled"}, `faint: true;`},
+		// Undefined variants fall back to fewer states.
+		{[]string{"hovered"}, `padding-left: 1; padding-right: 1;`},
+		{[]string{"focused", "hovered"}, `bold: true; padding-left: 1; padding-right: 1;`},
+	}
+	for _, tc := range td {
+		s, ok := ss.Get("button", tc.states...)
+		if !ok {
+			t.Fatalf("%v: not found", tc.states)
+		}
+		checkOutput(t, tc.exp, Export(s))
+	}
+	if _, ok := ss.Get("link", "focused"); ok {
+		t.Errorf("unexpected variant")
+	}
+	s, _ := ss.Get("label")
+	checkOutput(t, `foreground: 1;`, Export(s))
+}
+
+func TestImportSheetMixins(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+@mixin emphasized { bold: true; foreground: #f00; }
+@mixin bordered($color, $fg) {
+  border: rounded;
+  border-foreground: $color;
+  @include emphasized;
+  foreground: $fg;
+}
+title { italic: true; @include bordered(adaptive(#fff,#000), 12); }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("title")
+	checkOutput(t, `bold: true; border-bottom: true; border-bottom-foreground: adaptive(#fff,#000); border-left: true; border-left-foreground: adaptive(#fff,#000); border-right: true; border-right-foreground: adaptive(#fff,#000); border-style: border("─","─","│","│","╭","╮","╯","╰"); border-top: true; border-top-foreground: adaptive(#fff,#000); foreground: 12; italic: true;`, Export(s))
+
+	// Mixins remain available to later documents.
+	ss, err = ImportSheet(ss, `footer { @include emphasized }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ = ss.Get("footer")
+	checkOutput(t, `bold: true; foreground: #f00;`, Export(s))
+
+	td := []struct {
+		in     string
+		expErr string
+	}{
+		{`a { @include nope }`, `line 1: style "a": in "@include nope": unknown mixin "nope"`},
+		{`a { @include emphasized(1) }`, `line 1: style "a": in "@include emphasized(1)": mixin "emphasized" expects 0 arguments, got 1`},
+		{`a { @include }`, `line 1: style "a": invalid syntax: "@include"`},
+		{"@mixin x { @include y }\n@mixin y { @include x }\na { @include x }", `line 3: style "a": in "@include x": mixin "x" includes itself`},
+		{`@mixin 1 2 { }`, `line 1: invalid mixin definition: "@mixin 1 2"`},
+		{`@mixin m(color) { }`, `line 1: invalid mixin parameter: "color"`},
+		{`@mixin m { bold: true`, `line 1: unterminated block for "@mixin m"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			_, err := ImportSheet(ss, tc.in)
+			if err == nil || err.Error() != tc.expErr {
+				t.Errorf("expected %q, got %v", tc.expErr, err)
+			}
+		})
+	}
+}
+
+func TestImportSheetVariables(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+$accent: #7D56F4;
+$muted: 8;
+title { $local: 1; foreground: $accent; padding-left: $local }
+footer { foreground: $muted }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("title")
+	checkOutput(t, `foreground: #7D56F4; padding-left: 1;`, Export(s))
+	if vars := ss.Variables(); len(vars) != 2 || vars["accent"] != "#7D56F4" {
+		t.Errorf("unexpected variables: %v", vars)
+	}
+
+	// Variables remain defined for later documents, but block-local
+	// variables do not.
+	if _, err := ImportSheet(ss, `help { foreground: $accent }`); err != nil {
+		t.Error(err)
+	}
+	if _, err := ImportSheet(ss, `help { padding: $local }`); err == nil || err.Error() != `line 1: style "help": in "padding: $local": undefined variable: $local` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ImportSheet(ss, "\n$x: 1 }"); err == nil || err.Error() != `line 2: expected ";" after variable definition` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ImportSheet(ss, `$x: $y;`); err == nil || err.Error() != `line 1: in "$x: $y": undefined variable: $y` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImportSheetStyleRefs(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+header { foreground: adaptive(#fff,#000); padding: 1 2 }
+list.title { bold: true }
+help { foreground: styleref(header.foreground); padding: styleref(header.padding-top) styleref(header.padding-left) }
+footer { italic: styleref(list.title.bold); underline: styleref(help.underline) }
+status { foreground: styles.help.foreground; padding: 0 styles.header.padding-left; border-style: border("styles.a.b","","","","","","","") }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("help")
+	checkOutput(t, `foreground: adaptive(#fff,#000); padding-bottom: 1; padding-left: 2; padding-right: 2; padding-top: 1;`, Export(s))
+	s, _ = ss.Get("footer")
+	checkOutput(t, `italic: true;`, Export(s))
+	s, _ = ss.Get("status")
+	checkOutput(t, `border-style: border("styles.a.b","","","","","","",""); foreground: adaptive(#fff,#000); padding-left: 2; padding-right: 2;`, Export(s))
+
+	for _, tc := range []struct {
+		in, expErr string
+	}{
+		{`a { bold: styleref(nope.bold) }`, `line 1: style "a": in "bold: styleref(nope.bold)": unknown style "nope"`},
+		{`a { bold: styleref(header.padding) }`, `line 1: style "a": in "bold: styleref(header.padding)": unknown property "padding"`},
+		{`a { bold: styleref(header) }`, `line 1: style "a": in "bold: styleref(header)": invalid reference: styleref(header)`},
+		{`a { bold: styles.nope.bold }`, `line 1: style "a": in "bold: styles.nope.bold": unknown style "nope"`},
+		{`a { bold: styles.header }`, `line 1: style "a": in "bold: styles.header": invalid reference: styles.header`},
+	} {
+		if _, err := ImportSheet(ss, tc.in); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+}
+
+func TestStyleSheetExport(t *testing.T) {
+	const doc = `$accent: #7D56F4;
+title { bold: true; foreground: $accent; }
+footer { faint: true; }
+list.item { padding: 0 1; foreground: #ABC }
+empty {}
+`
+	ss, err := ImportSheet(StyleSheet{}, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `$accent: #7D56F4;
+
+empty {}
+footer { faint: true; }
+list.item { foreground: #ABC; padding-left: 1; padding-right: 1; }
+title { bold: true; foreground: $accent; }
+`, ss.Export())
+
+	checkOutput(t, `$accent: #7D56F4;
+$muted: #abc;
+
+empty {}
+
+footer {
+  faint: true;
+}
+
+list.item {
+  foreground: $muted;
+  padding-left: 1;
+  padding-right: 1;
+}
+
+title {
+  bold: true;
+  foreground: #7d56f4;
+}
+`, ss.Export(WithSeparator("\n"), WithHexCase(HexLower), WithVariables(map[string]string{"muted": "#abc"})))
+
+	// The document can be imported back.
+	res, err := ImportSheet(StyleSheet{}, ss.Export(WithSeparator("\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, ss.Export(), res.Export())
+	checkOutput(t, ``, StyleSheet{}.Export())
+
+	// The variables can contain separators within strings.
+	border := `border(";",";","|","|","+","+","+","+")`
+	ss, err = ImportSheet(StyleSheet{}, "$semi: "+border+";\nbox { border-style: $semi; }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := ss.Export(WithVariables(map[string]string{"other": border}))
+	checkOutput(t, `$other: `+border+`;
+$semi: `+border+`;
+
+box { border-style: $other; }
+`, exp)
+	res, err = ImportSheet(StyleSheet{}, exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, res.Export(WithVariables(map[string]string{"other": border})))
+
+	// The variables whose values contain separators or quotes outside
+	// of strings are quoted.
+	ss, err = ImportSheet(StyleSheet{}, `$semi: "a;b"; $quote: "\"x\""; $accent: "#7D56F4"; title { foreground: $accent; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp = `$accent: #7D56F4;
+$quote: "\"x\"";
+$semi: "a;b";
+
+title { foreground: $accent; }
+`
+	checkOutput(t, exp, ss.Export())
+	res, err = ImportSheet(StyleSheet{}, exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, res.Export())
+	checkOutput(t, `a;b`, res.Variables()["semi"])
+}
+
+func TestImportSheetImportant(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+bold: true !important;
+t { foreground: #f00 !important; padding-left: 0 !important; }
+t { foreground: #00f; padding-left: 2; bold: false; }
+u { bold: false !important; }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("t")
+	checkOutput(t, `bold: true; foreground: #f00;`, Export(s))
+	checkOutput(t, `bold foreground padding-left`, strings.Join(ss.Important("t"), " "))
+	checkOutput(t, `bold`, strings.Join(ss.Important(""), " "))
+
+	// A later block marking a property important overrides it.
+	ss2, err := ImportSheet(ss, `t { foreground: #00f !important; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ = ss2.Get("t")
+	checkOutput(t, `bold: true; foreground: #00f;`, Export(s))
+
+	// Export preserves the markers, including on default values.
+	exp := `bold: true !important;
+
+t { foreground: #f00 !important; padding-left: 0 !important; }
+u { bold: false !important; }
+`
+	checkOutput(t, exp, ss.Export())
+	res, err := ImportSheet(StyleSheet{}, ss.Export())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, res.Export())
+}
@@ -0,0 +1,146 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Borders returns the borders defined with @define-border in the
+// documents imported into the sheet, keyed by name, with their value
+// as printed by Export, e.g. `border("═","═","║","║","╔","╗","╝","╚")`.
+func (ss StyleSheet) Borders() map[string]string {
+	res := make(map[string]string, len(ss.borders))
+	for name, b := range ss.borders {
+		res[name] = borderLiteral(b)
+	}
+	return res
+}
+
+// borderLiteral formats a border as a border(...) value.
+func borderLiteral(b lipgloss.Border) string {
+	return fmt.Sprintf("border(%q,%q,%q,%q,%q,%q,%q,%q)",
+		b.Top, b.Bottom, b.Left, b.Right,
+		b.TopLeft, b.TopRight, b.BottomRight, b.BottomLeft,
+	)
+}
+
+// withBorders makes the names of the borders defined in a sheet usable
+// in the values of the border properties.
+func withBorders(borders map[string]string) ImportOption {
+	return func(o *importOptions) {
+		o.borders = borders
+	}
+}
+
+// hasBorder reports whether the property accepts borders.
+func (p prop) hasBorder() bool {
+	for _, arg := range p.args {
+		if _, ok := arg.(bordertype); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// defineBorder reads a @define-border directive into the sheet, for
+// example:
+//
+//	@define-border fancy("═","═","║","║","╔","╗","╝","╚");
+//
+// The strings are in the same order as in border(...) values.
+func (p *sheetParser) defineBorder(ss *StyleSheet) error {
+	line := p.line
+	i := indexOutsideStrings(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != ';' {
+		return p.errorf(line, "expected \";\" after @define-border")
+	}
+	a := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	p.advance(i + 1)
+	def := strings.TrimSpace(strings.TrimPrefix(a, "@define-border"))
+	j := strings.IndexByte(def, '(')
+	if j < 0 || !strings.HasPrefix(a, "@define-border ") {
+		return p.errorf(line, "invalid syntax: %q", a)
+	}
+	name := strings.TrimSpace(def[:j])
+	if !reConstName.MatchString(name) {
+		return p.errorf(line, "invalid border name: %q", name)
+	}
+	for _, kw := range (bordertype{}).keywords() {
+		if name == kw {
+			return p.errorf(line, "cannot redefine the predefined border %q", name)
+		}
+	}
+	vals, err := prop{args: []argtype{bordertype{}}}.parseArgs("border"+def[j:], nil)
+	if err != nil {
+		return p.errorf(line, "in %q: %v", a, err)
+	}
+	if ss.borders == nil {
+		ss.borders = map[string]lipgloss.Border{}
+	}
+	ss.borders[name] = vals[0].Interface().(lipgloss.Border)
+	return nil
+}
+
+// indexOutsideStrings is like strings.IndexAny, but ignores the
+// characters within double-quoted strings.
+func indexOutsideStrings(s, chars string) int {
+	inString := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && strings.IndexByte(chars, c) >= 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// exportBorders returns the @define-border directives for Export, in
+// alphabetical order, and configures opt to replace the borders
+// defined in the sheet by references.
+func (ss StyleSheet) exportBorders(opt *options) []string {
+	literals := ss.Borders()
+	names := make([]string, 0, len(literals))
+	for name := range literals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	defs := make([]string, len(names))
+	opt.borderRefs = make(map[string]string, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		// The first name in alphabetical order wins.
+		defs[i] = defineBorderDirective(names[i], literals[names[i]])
+		opt.borderRefs[literals[names[i]]] = names[i]
+	}
+	return defs
+}
+
+// defineBorderDirective formats a @define-border directive.
+func defineBorderDirective(name, literal string) string {
+	return "@define-border " + name + strings.TrimPrefix(literal, "border") + ";"
+}
+
+// bordersDiff returns the @define-border directives for the borders
+// added or modified in b.
+func bordersDiff(a, b StyleSheet) string {
+	al, bl := a.Borders(), b.Borders()
+	var names []string
+	for name, value := range bl {
+		if old, ok := al[name]; !ok || old != value {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var buf strings.Builder
+	for _, name := range names {
+		buf.WriteString(defineBorderDirective(name, bl[name]))
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
@@ -0,0 +1,67 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSheetBorders(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+@define-border fancy("═","═","║","║","╔","╗","╝","╚");
+@define-border braces("{","}",";",";","+","+","+","+");
+dialog { border-style: fancy; }
+status { border: braces true false; }
+quoted { border-style: border("fancy","","","","","","",""); }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("dialog")
+	checkOutput(t, `border-style: border("═","═","║","║","╔","╗","╝","╚");`, Export(s))
+	s, _ = ss.Get("status")
+	checkOutput(t, `border-bottom: true; border-style: border("{","}",";",";","+","+","+","+"); border-top: true;`, Export(s))
+	s, _ = ss.Get("quoted")
+	checkOutput(t, `border-style: border("fancy","","","","","","","");`, Export(s))
+	checkOutput(t, `border("═","═","║","║","╔","╗","╝","╚")`, ss.Borders()["fancy"])
+
+	// Export defines the borders and refers to them.
+	exp := `@define-border braces("{","}",";",";","+","+","+","+");
+@define-border fancy("═","═","║","║","╔","╗","╝","╚");
+
+dialog { border-style: fancy; }
+quoted { border-style: border("fancy","","","","","","",""); }
+status { border-bottom: true; border-style: braces; border-top: true; }
+`
+	checkOutput(t, exp, ss.Export())
+	rt, err := ImportSheet(StyleSheet{}, ss.Export())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, rt.Export())
+
+	// The borders are copied, merged and diffed.
+	other, err := ImportSheet(StyleSheet{}, `@define-border fancy("-","-","|","|","+","+","+","+");`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `border("-","-","|","|","+","+","+","+")`, ss.Merge(other).Borders()["fancy"])
+	checkOutput(t, `border("═","═","║","║","╔","╗","╝","╚")`, ss.Copy().Borders()["fancy"])
+	if d := StyleSheetDiff(ss, ss.Merge(other)); !strings.HasPrefix(d, `@define-border fancy("-","-","|","|","+","+","+","+");`+"\n") {
+		t.Errorf("unexpected diff: %q", d)
+	}
+
+	for _, tc := range []struct {
+		in, expErr string
+	}{
+		{`@define-border fancy("a","b")`, `line 1: expected ";" after @define-border`},
+		{`@define-border fancy;`, `line 1: invalid syntax: "@define-border fancy"`},
+		{`@define-border 1x("a","a","a","a","a","a","a","a");`, `line 1: invalid border name: "1x"`},
+		{`@define-border rounded("a","a","a","a","a","a","a","a");`, `line 1: cannot redefine the predefined border "rounded"`},
+		{`@define-border x("a","b");`, `line 1: in "@define-border x(\"a\",\"b\")": no valid border value found`},
+		{`a { border-style: nope; }`, `line 1: style "a": in "border-style: nope": no valid border value found; border-style expects a preset name or border("t","b","l","r","tl","tr","br","bl")`},
+	} {
+		if _, err := ImportSheet(StyleSheet{}, tc.in); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StyleSheetDiff computes a stylesheet document that transforms sheet
+// a into sheet b when applied to it with ImportSheet. The document
+// only contains the styles and properties that differ: a @remove
+// directive for the styles missing from b, then a block for each
+// style added or modified in b, with the changes computed as per
+// Diff. The palette entries, borders and variables added or modified
+// in b are defined at the start of the document, followed by the
+// changes to the root style as directives outside of any block.
+//
+// This makes it possible to ship small theme overlays instead of full
+// copies of a theme.
+func StyleSheetDiff(a, b StyleSheet) string {
+	var buf strings.Builder
+	buf.WriteString(paletteDiff(a, b))
+	buf.WriteString(bordersDiff(a, b))
+
+	var vars []string
+	for name, value := range b.vars {
+		if old, ok := a.vars[name]; !ok || old != value {
+			vars = append(vars, name)
+		}
+	}
+	sort.Strings(vars)
+	for _, name := range vars {
+		fmt.Fprintf(&buf, "$%s: %s;\n", name, b.vars[name])
+	}
+
+	if b.root != nil {
+		root, _ := a.Root()
+		if p := Diff(root, *b.root); len(p) > 0 {
+			fmt.Fprintf(&buf, "%s\n", p)
+		}
+	}
+
+	var removed []string
+	for _, name := range a.Names() {
+		if _, ok := b.styles[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if len(removed) > 0 {
+		fmt.Fprintf(&buf, "@remove %s;\n", strings.Join(removed, ", "))
+	}
+
+	for _, name := range b.Names() {
+		s, ok := a.styles[name]
+		if !ok {
+			// The added styles start from the root style.
+			s, _ = b.Root()
+		}
+		p := Diff(s, b.styles[name])
+		switch {
+		case len(p) > 0:
+			fmt.Fprintf(&buf, "%s { %s }\n", name, p)
+		case !ok:
+			// The style is added, with no properties.
+			fmt.Fprintf(&buf, "%s {}\n", name)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,74 @@
+package lipglossc
+
+import "testing"
+
+func TestStyleSheetDiff(t *testing.T) {
+	a, err := ImportSheet(StyleSheet{}, `
+$accent: #7D56F4;
+title { bold: true; foreground: $accent; padding: 0 1 }
+footer { faint: true }
+legacy.banner { reverse: true }
+legacy.note { italic: true }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ImportSheet(StyleSheet{}, `
+$accent: #ff00ff;
+$muted: #888;
+title { foreground: $accent; padding: 0 1 }
+footer { faint: true }
+help { foreground: $muted }
+empty {}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := StyleSheetDiff(a, b)
+	checkOutput(t, `$accent: #ff00ff;
+$muted: #888;
+@remove legacy.banner, legacy.note;
+empty {}
+help { foreground: #888; }
+title { bold: unset; foreground: #ff00ff; }
+`, diff)
+
+	// Applying the diff to a yields b.
+	res, err := ImportSheet(a, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, b.Export(), res.Export())
+
+	checkOutput(t, ``, StyleSheetDiff(b, b))
+
+	// The variables can contain separators within strings.
+	c, err := ImportSheet(StyleSheet{}, `$semi: border(";",";","|","|","+","+","+","+"); box { border-style: $semi; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = ImportSheet(StyleSheet{}, StyleSheetDiff(StyleSheet{}, c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, c.Export(), res.Export())
+}
+
+func TestImportSheetRemove(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `a { bold: true } list.x { bold: true } list.y { bold: true } @remove a, list.*; b { faint: true }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := ss.Names(); len(names) != 1 || names[0] != "b" {
+		t.Errorf("unexpected styles: %v", names)
+	}
+	for in, expErr := range map[string]string{
+		`@remove a`:      `line 1: expected ";" after @remove`,
+		`@remove a b;`:   `line 1: invalid style name: "a b"`,
+		`@remove a,, b;`: `line 1: invalid style name: ""`,
+	} {
+		if _, err := ImportSheet(StyleSheet{}, in); err == nil || err.Error() != expErr {
+			t.Errorf("%s: expected %q, got %v", in, expErr, err)
+		}
+	}
+}
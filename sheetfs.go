@@ -0,0 +1,38 @@
+//go:build go1.16
+// +build go1.16
+
+package lipglossc
+
+import "io/fs"
+
+// ImportSheetFS is like ImportSheet, but reads the document from the
+// named file in fsys, e.g. an embed.FS or os.DirFS. Documents can then
+// include other documents with @import:
+//
+//	@import "colors.gloss";
+//
+// The imported file name is relative to the importing file. The
+// imported document is processed where the @import directive appears,
+// so that later definitions override earlier ones deterministically.
+// Errors are reported with the name of the file where they occur.
+func ImportSheetFS(dst StyleSheet, fsys fs.FS, name string, opts ...ImportOption) (StyleSheet, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return dst, err
+	}
+	ss := dst.Copy()
+	p := sheetParser{
+		input: string(data),
+		line:  1,
+		file:  name,
+		open: func(name string) (string, error) {
+			data, err := fs.ReadFile(fsys, name)
+			return string(data), err
+		},
+		stack: []string{name},
+	}
+	if err := p.parseDocument(&ss, opts); err != nil {
+		return dst, err
+	}
+	return ss, nil
+}
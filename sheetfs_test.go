@@ -0,0 +1,55 @@
+//go:build go1.16
+// +build go1.16
+
+package lipglossc
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestImportSheetFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"theme.gloss": {Data: []byte(`
+@import "base/colors.gloss";
+title { foreground: $accent; bold: true }
+`)},
+		"base/colors.gloss": {Data: []byte(`
+$accent: #7D56F4;
+@import "common.gloss";
+title { italic: true; bold: false }
+`)},
+		"base/common.gloss": {Data: []byte(`footer { faint: true }`)},
+		"bad.gloss":         {Data: []byte("@import \"base/broken.gloss\";\n")},
+		"base/broken.gloss": {Data: []byte("\nfooter { bold: maybe }")},
+		"cycle.gloss":       {Data: []byte(`@import "cycle2.gloss";`)},
+		"cycle2.gloss":      {Data: []byte(`@import "cycle.gloss";`)},
+		"missing.gloss":     {Data: []byte(`@import "nope.gloss";`)},
+	}
+	ss, err := ImportSheetFS(StyleSheet{}, fsys, "theme.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ss.Get("title")
+	checkOutput(t, `bold: true; foreground: #7D56F4; italic: true;`, Export(s))
+	s, _ = ss.Get("footer")
+	checkOutput(t, `faint: true;`, Export(s))
+
+	for file, expErr := range map[string]string{
+		"bad.gloss":     `base/broken.gloss:2: style "footer": in "bold: maybe": no value found; bold expects true or false`,
+		"cycle.gloss":   `cycle2.gloss:1: import cycle: cycle.gloss -> cycle2.gloss -> cycle.gloss`,
+		"missing.gloss": `missing.gloss:1: open nope.gloss: file does not exist`,
+		"nope.gloss":    `open nope.gloss: file does not exist`,
+	} {
+		if _, err := ImportSheetFS(StyleSheet{}, fsys, file); err == nil || err.Error() != expErr {
+			t.Errorf("%s: expected %q, got %v", file, expErr, err)
+		}
+	}
+
+	if _, err := ImportSheet(StyleSheet{}, `@import "a.gloss";`); err == nil || err.Error() != `line 1: @import is only supported by ImportSheetFS` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ImportSheet(StyleSheet{}, `@import a.gloss;`); err == nil || err.Error() != `line 1: invalid syntax: "@import a.gloss"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
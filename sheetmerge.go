@@ -0,0 +1,111 @@
+package lipglossc
+
+import "github.com/charmbracelet/lipgloss"
+
+// MergePolicy determines how StyleSheet.Merge combines the styles
+// defined in both sheets.
+type MergePolicy int
+
+const (
+	// MergeCascade applies the properties set in the other sheet over
+	// those of the receiver, as per Compose: the later sheet wins, but
+	// only for the properties it sets. This is the default.
+	MergeCascade MergePolicy = iota
+	// MergeReplace replaces the styles of the receiver by those of the
+	// other sheet with the same name.
+	MergeReplace
+	// MergeKeep gives priority to the receiver: the other sheet only
+	// contributes the styles and properties missing from it.
+	MergeKeep
+)
+
+// MergeOption configures StyleSheet.Merge.
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	policy MergePolicy
+}
+
+// WithMergePolicy sets the precedence of StyleSheet.Merge.
+func WithMergePolicy(p MergePolicy) MergeOption {
+	return func(o *mergeOptions) {
+		o.policy = p
+	}
+}
+
+// Merge returns a new sheet containing the styles of both sheets, for
+// example to layer a user theme over the default theme of an
+// application. The styles defined in only one of the sheets are kept
+// as-is; those defined in both are combined according to the merge
+// policy, by default MergeCascade. As with Compose, a style sets a
+// property when the property was assigned in it, even to its default
+// value, e.g. with "bold: false". The root styles are combined
+// likewise. The variables, palette entries, borders and mixins are
+// merged with the same precedence, by name.
+//
+// As with ComposeLayers, the properties marked !important in a style
+// are kept unless the other style also marks them important, except
+// with MergeReplace.
+func (ss StyleSheet) Merge(other StyleSheet, opts ...MergeOption) StyleSheet {
+	var opt mergeOptions
+	for _, o := range opts {
+		o(&opt)
+	}
+	res := ss.Copy()
+	otherWins := opt.policy != MergeKeep
+	for name, s := range other.styles {
+		base, ok := res.styles[name]
+		s, important := res.mergeStyle(name, base, ok, s, other.importantOf(name), opt.policy)
+		res.Set(name, s)
+		res.setImportant(name, important)
+	}
+	if other.root != nil {
+		base, ok := res.Root()
+		s, important := res.mergeStyle("", base, ok, *other.root, other.importantOf(""), opt.policy)
+		res.SetRoot(s)
+		res.setImportant("", important)
+	}
+	for name, value := range other.vars {
+		if _, ok := res.vars[name]; !ok || otherWins {
+			res.vars[name] = value
+		}
+	}
+	for name, tc := range other.palette {
+		if _, ok := res.palette[name]; !ok || otherWins {
+			if res.palette == nil {
+				res.palette = map[string]lipgloss.TerminalColor{}
+			}
+			res.palette[name] = tc
+		}
+	}
+	for name, b := range other.borders {
+		if _, ok := res.borders[name]; !ok || otherWins {
+			if res.borders == nil {
+				res.borders = map[string]lipgloss.Border{}
+			}
+			res.borders[name] = b
+		}
+	}
+	for name, m := range other.mixins {
+		if _, ok := res.mixins[name]; !ok || otherWins {
+			res.mixins[name] = m
+		}
+	}
+	return res
+}
+
+// mergeStyle combines the style s of another sheet, whose properties
+// marked !important are in important, with the named style base of
+// the sheet, if any, as per the policy. It returns the resulting style
+// and its important properties.
+func (ss StyleSheet) mergeStyle(name string, base S, ok bool, s S, important map[string]bool, policy MergePolicy) (S, map[string]bool) {
+	switch {
+	case !ok || policy == MergeReplace:
+		return s, important
+	case policy == MergeKeep:
+		return overlay(s.Copy(), base, important, ss.important[name], nil), important
+	default:
+		baseImportant := ss.importantOf(name)
+		return overlay(base, s, baseImportant, important, nil), baseImportant
+	}
+}
@@ -0,0 +1,108 @@
+package lipglossc
+
+import "testing"
+
+func TestStyleSheetMerge(t *testing.T) {
+	base, err := ImportSheet(StyleSheet{}, `
+$accent: #7D56F4;
+@mixin emphasized { bold: true; }
+title { bold: true; foreground: $accent; padding: 0 1 }
+footer { faint: true }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := ImportSheet(StyleSheet{}, `
+$accent: #ff00ff;
+@mixin emphasized { italic: true; }
+title { foreground: $accent; underline: true }
+help { faint: true }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	td := []struct {
+		policy MergePolicy
+		exp    string
+	}{
+		{MergeCascade, `$accent: #ff00ff;
+
+footer { faint: true; }
+help { faint: true; }
+title { bold: true; foreground: $accent; padding-left: 1; padding-right: 1; underline: true; }
+`},
+		{MergeReplace, `$accent: #ff00ff;
+
+footer { faint: true; }
+help { faint: true; }
+title { foreground: $accent; underline: true; }
+`},
+		{MergeKeep, `$accent: #7D56F4;
+
+footer { faint: true; }
+help { faint: true; }
+title { bold: true; foreground: $accent; padding-left: 1; padding-right: 1; underline: true; }
+`},
+	}
+	for _, tc := range td {
+		res := base.Merge(user, WithMergePolicy(tc.policy))
+		checkOutput(t, tc.exp, res.Export())
+	}
+
+	// The mixins are merged too.
+	res, err := ImportSheet(base.Merge(user), `x { @include emphasized; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := res.Get("x")
+	checkOutput(t, `italic: true;`, Export(s))
+
+	// The sheets are not modified.
+	s, _ = base.Get("title")
+	checkOutput(t, `bold: true; foreground: #7D56F4; padding-left: 1; padding-right: 1;`, Export(s))
+
+	// The zero sheet can be merged into.
+	checkOutput(t, user.Export(), StyleSheet{}.Merge(user).Export())
+}
+
+func TestStyleSheetMergeImportant(t *testing.T) {
+	base, err := ImportSheet(StyleSheet{}, `title { foreground: #f00 !important; bold: true; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := ImportSheet(StyleSheet{}, `title { foreground: #00f; bold: false; italic: true !important; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	td := []struct {
+		policy MergePolicy
+		exp    string
+	}{
+		{MergeCascade, "title { foreground: #f00 !important; italic: true !important; }\n"},
+		{MergeReplace, "title { foreground: #00f; italic: true !important; }\n"},
+		{MergeKeep, "title { bold: true; foreground: #f00 !important; italic: true !important; }\n"},
+	}
+	for _, tc := range td {
+		res := base.Merge(user, WithMergePolicy(tc.policy))
+		checkOutput(t, tc.exp, res.Export())
+	}
+}
+
+func TestStyleSheetMergeResetToDefault(t *testing.T) {
+	base, err := ImportSheet(StyleSheet{}, `title { bold: true; padding-left: 2; italic: true; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := ImportSheet(StyleSheet{}, `title { bold: false; padding-left: 0; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "title { italic: true; }\n", base.Merge(user).Export())
+	checkOutput(t, "title { bold: true; italic: true; padding-left: 2; }\n", user.Merge(base).Export())
+	// With MergeKeep, the receiver wins, including for the properties it
+	// resets.
+	checkOutput(t, "title { bold: true; italic: true; padding-left: 2; }\n", base.Merge(user, WithMergePolicy(MergeKeep)).Export())
+	checkOutput(t, "title { italic: true; }\n", user.Merge(base, WithMergePolicy(MergeKeep)).Export())
+}
@@ -0,0 +1,128 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Palette returns the colors defined with @palette in the documents
+// imported into the sheet, keyed by name, with their value as printed
+// by Export.
+func (ss StyleSheet) Palette() map[string]string {
+	return ss.paletteWords(&options{})
+}
+
+// paletteWords prints the colors of the palette with the given export
+// options.
+func (ss StyleSheet) paletteWords(opt *options) map[string]string {
+	res := make(map[string]string, len(ss.palette))
+	for name, tc := range ss.palette {
+		var buf strings.Builder
+		printColor(&buf, tc, opt)
+		res[name] = buf.String()
+	}
+	return res
+}
+
+// withPalette makes the names of the palette entries usable in the
+// values of the color properties.
+func withPalette(palette map[string]string) ImportOption {
+	return func(o *importOptions) {
+		o.palette = palette
+	}
+}
+
+// hasColor reports whether the property accepts colors.
+func (p prop) hasColor() bool {
+	for _, arg := range p.args {
+		if _, ok := arg.(colortype); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// palette reads a @palette section into the sheet. Each directive
+// defines a named color, which can refer to the variables and to the
+// palette entries defined earlier.
+func (p *sheetParser) palette(ss *StyleSheet, opts []ImportOption) error {
+	line := p.line
+	i := strings.IndexAny(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != '{' || strings.TrimSpace(p.input[p.pos:p.pos+i]) != "@palette" {
+		return p.errorf(line, "expected \"{\" after @palette")
+	}
+	p.advance(i + 1)
+	body, err := p.block([]string{"@palette"})
+	if err != nil {
+		return err
+	}
+	if ss.palette == nil {
+		ss.palette = map[string]lipgloss.TerminalColor{}
+	}
+	for _, a := range splitAssignments(body, makeImportOptions(opts).sep) {
+		name, value, ok := splitAssignment(a)
+		if !ok {
+			return p.errorf(line, "invalid syntax: %q", a)
+		}
+		if !reConstName.MatchString(name) {
+			return p.errorf(line, "invalid palette name: %q", name)
+		}
+		value, err := expandVariables(value, ss.vars)
+		if err != nil {
+			return p.errorf(line, "in %q: %v", a, err)
+		}
+		value = expandWords(unquoteValue(value), ss.Palette())
+		vals, err := prop{args: []argtype{colortype{}}}.parseArgs(value, nil)
+		if err != nil {
+			return p.errorf(line, "in %q: %v", a, err)
+		}
+		ss.palette[name] = vals[0].Interface().(lipgloss.TerminalColor)
+	}
+	return nil
+}
+
+// exportPalette returns the palette entries for Export, in
+// alphabetical order, and configures opt to replace the colors of the
+// palette by references.
+func (ss StyleSheet) exportPalette(opt *options) []propValue {
+	words := ss.paletteWords(opt)
+	names := make([]string, 0, len(words))
+	for name := range words {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	defs := make([]propValue, len(names))
+	opt.paletteRefs = make(map[string]string, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		// The first name in alphabetical order wins.
+		defs[i] = propValue{name: names[i], value: words[names[i]]}
+		opt.paletteRefs[words[names[i]]] = names[i]
+	}
+	return defs
+}
+
+// paletteDiff returns the @palette section defining the entries added
+// or modified in b, if any.
+func paletteDiff(a, b StyleSheet) string {
+	aw, bw := a.Palette(), b.Palette()
+	var names []string
+	for name, value := range bw {
+		if old, ok := aw[name]; !ok || old != value {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	var buf strings.Builder
+	buf.WriteString("@palette {")
+	for _, name := range names {
+		fmt.Fprintf(&buf, " %s: %s;", name, bw[name])
+	}
+	buf.WriteString(" }\n")
+	return buf.String()
+}
@@ -0,0 +1,83 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSheetPalette(t *testing.T) {
+	ss, err := ImportSheet(StyleSheet{}, `
+$base: #7D56F4;
+@palette { accent: $base; danger: #ff5555; border-color: adaptive(accent, #000); }
+title { foreground: accent; border-foreground: danger border-color; }
+warning { foreground: danger; background: #7D56F4; }
+plain { foreground: #000; }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := fmt.Sprint(ss.Palette()); p != `map[accent:#7D56F4 border-color:adaptive(#7D56F4,#000) danger:#ff5555]` {
+		t.Errorf("unexpected palette: %s", p)
+	}
+	// The styles contain the colors of the palette.
+	s, _ := ss.Get("title")
+	checkOutput(t, `border-bottom-foreground: #ff5555; border-left-foreground: adaptive(#7D56F4,#000); `+
+		`border-right-foreground: adaptive(#7D56F4,#000); border-top-foreground: #ff5555; foreground: #7D56F4;`, Export(s))
+
+	// Export keeps the references to the palette.
+	exp := `@palette { accent: #7D56F4; border-color: adaptive(#7D56F4,#000); danger: #ff5555; }
+$base: #7D56F4;
+
+plain { foreground: #000; }
+title { border-bottom-foreground: danger; border-left-foreground: border-color; border-right-foreground: border-color; border-top-foreground: danger; foreground: accent; }
+warning { background: accent; foreground: danger; }
+`
+	checkOutput(t, exp, ss.Export())
+
+	// The export can be imported back.
+	ss2, err := ImportSheet(StyleSheet{}, exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, ss2.Export())
+
+	// The palette remains available in later documents, and is
+	// exported with the options of Export.
+	ss, err = ImportSheet(ss, `footer { foreground: danger; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := ss.Export(WithSeparator("\n"), WithHexCase(HexLower))
+	if exp := "@palette {\n  accent: #7d56f4;\n  border-color: adaptive(#7d56f4,#000);\n  danger: #ff5555;\n}\n"; !strings.HasPrefix(res, exp) {
+		t.Errorf("expected prefix:\n%s\ngot:\n%s", exp, res)
+	}
+	if !strings.Contains(res, "footer {\n  foreground: danger;\n}") {
+		t.Errorf("unexpected export:\n%s", res)
+	}
+
+	// The palette is diffed and merged.
+	other, err := ImportSheet(StyleSheet{}, `@palette { danger: #f00; info: 12; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "@palette { danger: #f00; info: 12; }\n@remove footer, plain, title, warning;\n", StyleSheetDiff(ss, other))
+	if p := fmt.Sprint(ss.Merge(other).Palette()); p != `map[accent:#7D56F4 border-color:adaptive(#7D56F4,#000) danger:#f00 info:12]` {
+		t.Errorf("unexpected palette: %s", p)
+	}
+
+	for _, tc := range []struct {
+		in     string
+		expErr string
+	}{
+		{`@palette { 1x: red; }`, `line 1: invalid palette name: "1x"`},
+		{"\n@palette { accent: nocolor; }", `line 2: in "accent: nocolor": color not recognized: "nocolor"`},
+		{`@palette { accent }`, `line 1: invalid syntax: "accent"`},
+		{`@palette accent: red;`, `line 1: expected "{" after @palette`},
+		{`title { bold: accent; }`, `line 1: style "title": in "bold: accent": no value found; bold expects true or false`},
+	} {
+		if _, err := ImportSheet(StyleSheet{}, tc.in); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package lipglossc
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Root retrieves the root style of the sheet, defined by the
+// directives outside of any block. The result is a copy and can be
+// modified freely. If the sheet has no root style, Root returns a new
+// style and false.
+func (ss StyleSheet) Root() (S, bool) {
+	if ss.root == nil {
+		return lipgloss.NewStyle(), false
+	}
+	return ss.root.Copy(), true
+}
+
+// SetRoot defines or replaces the root style of the sheet. The style
+// is copied, so the caller can continue to use the argument. None of
+// its properties are marked !important.
+func (ss *StyleSheet) SetRoot(s S) {
+	s = s.Copy()
+	ss.root = &s
+	delete(ss.important, "")
+}
+
+// isRootDirective reports whether the input starts with a directive
+// outside of any block: an assignment or an @include, not followed by
+// a block.
+func isRootDirective(input string) bool {
+	i := indexOutsideStrings(input, "{};")
+	if i < 0 {
+		i = len(input)
+	} else if input[i] != ';' {
+		return false
+	}
+	return strings.HasPrefix(input, "@include") || strings.Contains(input[:i], ":")
+}
+
+// rootDirective reads a directive outside of any block into the root
+// style of the sheet.
+func (p *sheetParser) rootDirective(ss *StyleSheet, opts []ImportOption) error {
+	start, line := p.pos, p.line
+	i := indexOutsideStrings(p.input[p.pos:], ";")
+	if i < 0 {
+		i = len(p.input) - p.pos
+	}
+	body := p.input[p.pos : p.pos+i]
+	p.advance(i)
+	if p.pos < len(p.input) {
+		p.advance(1)
+	}
+	if p.errs != nil {
+		p.validateBlock(ss, "", body, start, opts)
+		return nil
+	}
+	if err := ss.applyBlock("", strings.TrimSpace(body), opts); err != nil {
+		err.Pos = SourcePos{File: p.file, Line: line}
+		return err
+	}
+	return nil
+}
+
+// exportOverrides returns the properties of the style that differ from
+// the root style, for Export: since the styles start from the root
+// style, this includes the properties reset to their default value.
+func exportOverrides(root, s S, opt *options) []propValue {
+	all := *opt
+	all.includeDefaults = true
+	rootProps := exportProps(root, &all)
+	var res []propValue
+	for i, pv := range exportProps(s, &all) {
+		if pv.value != rootProps[i].value {
+			res = append(res, pv)
+		}
+	}
+	return res
+}
@@ -0,0 +1,83 @@
+package lipglossc
+
+import "testing"
+
+func TestSheetRoot(t *testing.T) {
+	const input = `$accent: #7D56F4;
+@mixin loud { underline: true; }
+foreground: $accent;
+padding: 0 1;
+title { bold: true; }
+@include loud;
+footer { padding: 0; }
+`
+	ss, err := ImportSheet(StyleSheet{}, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, ok := ss.Root()
+	if !ok {
+		t.Fatal("expected root style")
+	}
+	checkOutput(t, `foreground: #7D56F4; padding-left: 1; padding-right: 1; underline: true;`, Export(root))
+
+	// The styles start from the root style as defined at that point.
+	s, _ := ss.Get("title")
+	checkOutput(t, `bold: true; foreground: #7D56F4; padding-left: 1; padding-right: 1;`, Export(s))
+	s, _ = ss.Get("footer")
+	checkOutput(t, `foreground: #7D56F4; underline: true;`, Export(s))
+	if ss.Len() != 2 {
+		t.Errorf("expected 2 styles, got %v", ss.Names())
+	}
+
+	// Round trip. The styles are exported as overrides of the root
+	// style.
+	exp := ss.Export()
+	checkOutput(t, `$accent: #7D56F4;
+foreground: $accent; padding-left: 1; padding-right: 1; underline: true;
+
+footer { padding-left: 0; padding-right: 0; }
+title { bold: true; underline: false; }
+`, exp)
+	ss2, err := ImportSheet(StyleSheet{}, exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, ss2.Export())
+	ss2, err = ImportSheet(StyleSheet{}, ss.Export(WithSeparator("\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, ss2.Export())
+
+	// Root returns a copy.
+	root.Bold(true)
+	root, _ = ss.Root()
+	if root.GetBold() {
+		t.Errorf("root style modified")
+	}
+	if _, ok := (StyleSheet{}).Root(); ok {
+		t.Errorf("unexpected root style")
+	}
+
+	// Merging and diffs.
+	other, err := ImportSheet(StyleSheet{}, "faint: true;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, _ = ss.Merge(other).Root()
+	checkOutput(t, `faint: true; foreground: #7D56F4; padding-left: 1; padding-right: 1; underline: true;`, Export(root))
+	diff := StyleSheetDiff(other, ss)
+	ss3, err := ImportSheet(other, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, ss.Export(), ss3.Export())
+
+	// Errors in root directives.
+	errs := ValidateSheet("title { bold: true; }\n  bold: maybe;")
+	if len(errs) != 1 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	checkOutput(t, `line 2, column 3: in "bold: maybe": no value found; bold expects true or false`, errs[0].Error())
+}
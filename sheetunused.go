@@ -0,0 +1,126 @@
+package lipglossc
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// reVarDefinition matches the definitions of variables, at the
+	// start of a directive.
+	reVarDefinition = regexp.MustCompile(`(?:^|[;{}])\s*(\$[a-zA-Z_][-a-zA-Z0-9_]*)\s*:`)
+	// reVarReference matches the references to variables.
+	reVarReference = regexp.MustCompile(`\$[a-zA-Z_][-a-zA-Z0-9_]*`)
+	// reMixinDefinition matches the headers of mixins.
+	reMixinDefinition = regexp.MustCompile(`@mixin\s+([\w-]+)`)
+	// rePaletteHeader matches the start of @palette sections.
+	rePaletteHeader = regexp.MustCompile(`@palette\s*\{`)
+	// reValue matches the values of directives.
+	reValue = regexp.MustCompile(`:[^;{}\n]*`)
+	// reValueWord matches the names in values.
+	reValueWord = regexp.MustCompile(`[a-zA-Z_][-a-zA-Z0-9_]*`)
+)
+
+// UnusedDefinitions reports the variables, palette entries and mixins
+// defined in a stylesheet document but never referenced in it, so that
+// theme authors can remove them. The warnings indicate the line and
+// column of each definition, and are in document order.
+//
+// The document is not evaluated: the definitions used only by the
+// files that import it are reported too.
+func UnusedDefinitions(input string) []Warning {
+	input, _ = blankComments(input)
+	type definition struct {
+		offset int
+		w      Warning
+	}
+	var defs []definition
+	add := func(offset int, prop, msg string) {
+		defs = append(defs, definition{offset, Warning{Prop: prop, Message: msg}})
+	}
+
+	// Variables: a definition is used if the name appears anywhere
+	// else, including in the parameters of mixins.
+	isDefinition := map[int]bool{}
+	var vars [][]int
+	for _, m := range reVarDefinition.FindAllStringSubmatchIndex(input, -1) {
+		isDefinition[m[2]] = true
+		vars = append(vars, m[2:4])
+	}
+	varUsed := map[string]bool{}
+	for _, m := range reVarReference.FindAllStringIndex(input, -1) {
+		if !isDefinition[m[0]] {
+			varUsed[input[m[0]:m[1]]] = true
+		}
+	}
+	for _, m := range vars {
+		if name := input[m[0]:m[1]]; !varUsed[name] {
+			add(m[0], name, "unused variable")
+		}
+	}
+
+	// Mixins.
+	for _, m := range reMixinDefinition.FindAllStringSubmatchIndex(input, -1) {
+		name := input[m[2]:m[3]]
+		re := regexp.MustCompile(`@include\s+` + regexp.QuoteMeta(name) + `(?:[^\w-]|$)`)
+		if !re.MatchString(input) {
+			add(m[2], name, "unused mixin")
+		}
+	}
+
+	// Palette entries: an entry is used if its name appears as a word
+	// in a value, other than its own.
+	type entry struct {
+		name        string
+		offset, end int
+	}
+	var entries []entry
+	for _, m := range rePaletteHeader.FindAllStringIndex(input, -1) {
+		body := input[m[1]:]
+		if end := indexOutsideStrings(body, "}"); end >= 0 {
+			body = body[:end]
+		}
+		for _, d := range splitDirectives(body, ";") {
+			a := body[d[0]:d[1]]
+			if name, _, ok := splitAssignment(a); ok && reConstName.MatchString(name) {
+				start := m[1] + d[0]
+				entries = append(entries, entry{name, start, start + len(a)})
+			}
+		}
+	}
+	if len(entries) > 0 {
+		uses := map[string][]int{}
+		for _, v := range reValue.FindAllStringIndex(input, -1) {
+			for _, w := range reValueWord.FindAllStringIndex(input[v[0]:v[1]], -1) {
+				word := input[v[0]+w[0] : v[0]+w[1]]
+				uses[word] = append(uses[word], v[0]+w[0])
+			}
+		}
+		for _, e := range entries {
+			used := false
+			for _, offset := range uses[e.name] {
+				if offset < e.offset || offset >= e.end {
+					used = true
+					break
+				}
+			}
+			if !used {
+				add(e.offset, e.name, "unused palette entry")
+			}
+		}
+	}
+
+	sort.SliceStable(defs, func(i, j int) bool { return defs[i].offset < defs[j].offset })
+	var res []Warning
+	for _, d := range defs {
+		lineStart := strings.LastIndexByte(input[:d.offset], '\n') + 1
+		d.w.Pos = SourcePos{
+			Line:   strings.Count(input[:d.offset], "\n") + 1,
+			Column: utf8.RuneCountInString(input[lineStart:d.offset]) + 1,
+		}
+		res = append(res, d.w)
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package lipglossc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnusedDefinitions(t *testing.T) {
+	const input = `$accent: #7D56F4;
+$unused: 3; // $unused in a comment does not count
+@palette {
+  danger: #ff5555;
+  warning: #ffaa00;
+  alert: danger;
+}
+@mixin emphasized { bold: true; }
+@mixin bordered($color) { border-foreground: $color; }
+@mixin spare { faint: true; }
+title { @include emphasized; @include bordered($accent); }
+footer { $pad: 2; foreground: alert; }
+`
+	var res []string
+	for _, w := range UnusedDefinitions(input) {
+		res = append(res, fmt.Sprintf("%d:%d: %s", w.Pos.Line, w.Pos.Column, w))
+	}
+	checkOutput(t, `2:1: $unused: unused variable
+5:3: warning: unused palette entry
+10:8: spare: unused mixin
+12:10: $pad: unused variable`, strings.Join(res, "\n"))
+
+	if ws := UnusedDefinitions("$a: 1; title { padding: $a; }"); ws != nil {
+		t.Errorf("unexpected warnings: %v", ws)
+	}
+}
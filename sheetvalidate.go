@@ -0,0 +1,161 @@
+package lipglossc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SheetError is the type of the errors reported for stylesheet
+// documents by ImportSheet and ValidateSheet.
+type SheetError struct {
+	// Pos is the position of the error. The column is only known for
+	// the errors reported by ValidateSheet for directives in blocks.
+	Pos SourcePos
+	// Style is the name of the style the invalid directive applies to,
+	// if any.
+	Style string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *SheetError) Error() string {
+	pos := e.Pos.String()
+	if e.Pos.File == "" {
+		pos = fmt.Sprintf("line %d", e.Pos.Line)
+		if e.Pos.Column > 0 {
+			pos += fmt.Sprintf(", column %d", e.Pos.Column)
+		}
+	}
+	if e.Style != "" {
+		return fmt.Sprintf("%s: style %q: %v", pos, e.Style, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", pos, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SheetError) Unwrap() error { return e.Err }
+
+// ValidateSheet checks a stylesheet document as per ImportSheet, but
+// reports all the errors at once instead of stopping at the first
+// one, so that theme authors can fix them together. The directives of
+// each block are checked one by one, and their errors indicate the
+// style, line and column of the directive. Parsing resumes after the
+// construct that failed; only an unterminated comment or block stops
+// it. The options are those of ImportSheet.
+//
+// The errors are in document order, and nil if the document is valid.
+func ValidateSheet(input string, opts ...ImportOption) []*SheetError {
+	var errs []*SheetError
+	p := sheetParser{input: input, line: 1, errs: &errs}
+	var ss StyleSheet
+	if err := p.parseDocument(&ss, opts); err != nil {
+		errs = append(errs, asSheetError(err))
+	}
+	return errs
+}
+
+// report handles an error found while parsing the construct at the
+// given offset. In validation mode, the error is recorded and parsing
+// resumes after the construct, or after the next ";" or "}" if the
+// parser did not move past it; nil is returned unless parsing cannot
+// resume. Otherwise, the error is returned as-is.
+func (p *sheetParser) report(err error, start int) error {
+	if p.errs == nil {
+		return err
+	}
+	if p.pos == start {
+		i := strings.IndexAny(p.input[p.pos:], ";}")
+		if i < 0 {
+			return err
+		}
+		p.advance(i + 1)
+	}
+	*p.errs = append(*p.errs, asSheetError(err))
+	return nil
+}
+
+// validateBlock applies the directives of a block to the named style
+// one at a time, recording the error of each invalid directive at its
+// position. The body starts at the given offset in the input.
+func (p *sheetParser) validateBlock(ss *StyleSheet, name, body string, offset int, opts []ImportOption) {
+	sep := makeImportOptions(opts).sep
+	// The local variables carry over to the next directives, like the
+	// properties marked !important, which applyBlock records in the
+	// sheet.
+	var vars []string
+	for _, d := range splitDirectives(body, sep) {
+		a := strings.TrimSpace(body[d[0]:d[1]])
+		if err := ss.applyBlock(name, strings.Join(append(vars[:len(vars):len(vars)], a), sep), opts); err != nil {
+			err.Pos = p.position(offset + d[0])
+			*p.errs = append(*p.errs, err)
+			continue
+		}
+		if strings.HasPrefix(a, "$") {
+			vars = append(vars, a)
+		}
+	}
+}
+
+// position returns the position of the given offset in the input,
+// which must not be past the current position.
+func (p *sheetParser) position(offset int) SourcePos {
+	lineStart := strings.LastIndexByte(p.input[:offset], '\n') + 1
+	return SourcePos{
+		File:   p.file,
+		Line:   p.line - strings.Count(p.input[offset:p.pos], "\n"),
+		Column: utf8.RuneCountInString(p.input[lineStart:offset]) + 1,
+	}
+}
+
+// splitDirectives returns the start and end offsets of the directives
+// in the body of a block, without the surrounding spaces. Unlike
+// splitAssignments, a nested block counts as a single directive, and
+// the separators within double-quoted strings do not count.
+func splitDirectives(body, sep string) [][2]int {
+	var res [][2]int
+	add := func(start, end int) {
+		d := body[start:end]
+		trimmed := strings.TrimLeft(d, " \t\r\n")
+		start += len(d) - len(trimmed)
+		end = start + len(strings.TrimRight(trimmed, " \t\r\n"))
+		if end > start {
+			res = append(res, [2]int{start, end})
+		}
+	}
+	start, depth, inString := 0, 0, false
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				add(start, i+1)
+				start = i + 1
+			}
+		case depth == 0 && strings.HasPrefix(body[i:], sep):
+			add(start, i)
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+	add(start, len(body))
+	return res
+}
+
+// asSheetError converts the errors found while parsing a stylesheet
+// to a SheetError.
+func asSheetError(err error) *SheetError {
+	var se *SheetError
+	if errors.As(err, &se) {
+		return se
+	}
+	return &SheetError{Err: err}
+}
@@ -0,0 +1,71 @@
+package lipglossc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateSheet(t *testing.T) {
+	const input = `$accent: #7D56F4;
+title { bold: maybe; foreground: $accent; }
+footer {
+  $pad: 2;
+  padding: $pad;
+  faint: true; colour: red;
+  border { top: sometimes; }
+}
+my title { bold: true; }
+list.*, dialog { extends: base; italic: true; }
+@mixin m { bold: true; }
+body { @include missing; underline: 3; }
+`
+	errs := ValidateSheet(input)
+	var res []string
+	for _, err := range errs {
+		res = append(res, err.Error())
+	}
+	checkOutput(t, `line 2, column 9: style "title": in "bold: maybe": no value found; bold expects true or false
+line 6, column 16: style "footer": in "colour: red": property not supported: "colour"
+line 7, column 3: style "footer": in "border-top: sometimes": no value found; border-top expects true or false
+line 9: invalid style name: "my title"
+line 10, column 18: style "dialog": in "extends: base": unknown style "base"
+line 12, column 8: style "body": in "@include missing": unknown mixin "missing"
+line 12, column 26: style "body": in "underline: 3": no value found; underline expects true or false`, strings.Join(res, "\n"))
+
+	if errs[0].Style != "title" || errs[0].Pos.Line != 2 || errs[0].Pos.Column != 9 {
+		t.Errorf("unexpected error: %+v", errs[0])
+	}
+
+	if errs := ValidateSheet("title { bold: true; }\n@dark { footer { faint: true; } }"); errs != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	// Parsing resumes after the constructs that fail.
+	errs = ValidateSheet("bold: maybe;\n@palette;\n$x;\ntitle { bold: true }\nfooter {\n bold: 1")
+	res = nil
+	for _, err := range errs {
+		res = append(res, err.Error())
+	}
+	checkOutput(t, `line 1, column 1: in "bold: maybe": no value found; bold expects true or false
+line 2: expected "{" after @palette
+line 3: invalid syntax: "$x"
+line 5: unterminated block for "footer"`, strings.Join(res, "\n"))
+
+	// ImportSheet reports the same positions, without the column.
+	_, err := ImportSheet(StyleSheet{}, input)
+	var se *SheetError
+	if !errors.As(err, &se) || se.Style != "title" || se.Pos.Line != 2 || se.Pos.Column != 0 {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSplitDirectives(t *testing.T) {
+	const body = ` bold: true;  border { top: true; left: true }
+ foreground: "a;b"; ; faint: true`
+	var res []string
+	for _, d := range splitDirectives(body, ";") {
+		res = append(res, body[d[0]:d[1]])
+	}
+	checkOutput(t, `bold: true|border { top: true; left: true }|foreground: "a;b"|faint: true`, strings.Join(res, "|"))
+}
@@ -0,0 +1,101 @@
+package lipglossc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WithThemeVersion selects the version of the stylesheet documents read
+// by ImportSheet: the @version sections for the other versions are
+// skipped. By default, the version declared by the document is
+// selected. An error is reported if the document uses @version but
+// knows nothing of the selected version.
+func WithThemeVersion(version string) ImportOption {
+	return func(o *importOptions) {
+		o.version = version
+	}
+}
+
+// sheetVersions tracks the @version directives of a document.
+type sheetVersions struct {
+	// current is the version declared by the document, if any.
+	current string
+	// known lists the versions declared or with a section.
+	known map[string]bool
+	// sections is set once a section has been read.
+	sections bool
+}
+
+// version processes a @version directive, which is either the
+// declaration of the version of the document:
+//
+//	@version "2";
+//
+// or a section containing the blocks of a given version, which are
+// applied only if this version is selected:
+//
+//	@version "1" { title { foreground: #f00; } }
+func (p *sheetParser) version(ss *StyleSheet, opts []ImportOption) error {
+	line := p.line
+	if p.versions == nil {
+		return p.errorf(line, "@version is only supported at the top level of documents")
+	}
+	i := indexOutsideStrings(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] == '}' {
+		return p.errorf(line, "expected \";\" or \"{\" after @version")
+	}
+	header := strings.TrimSpace(p.input[p.pos : p.pos+i])
+	name, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(header, "@version")))
+	if err != nil || name == "" {
+		return p.errorf(line, "invalid syntax: %q", header)
+	}
+	isSection := p.input[p.pos+i] == '{'
+	p.advance(i + 1)
+	v := p.versions
+	v.known[name] = true
+	if !isSection {
+		switch {
+		case v.current != "":
+			return p.errorf(line, "version already declared: %q", v.current)
+		case v.sections:
+			return p.errorf(line, "the version must be declared before the @version sections")
+		}
+		v.current = name
+		if p.theme != nil {
+			p.theme.Version = name
+		}
+		return nil
+	}
+	v.sections = true
+	start, startLine := p.pos, p.line
+	if _, err := p.block([]string{header}); err != nil {
+		return err
+	}
+	selected := makeImportOptions(opts).version
+	if selected == "" {
+		selected = v.current
+	}
+	if name != selected {
+		return nil
+	}
+	sub := sheetParser{
+		input: p.input[:p.pos-1],
+		pos:   start,
+		line:  startLine,
+		file:  p.file,
+		open:  p.open,
+		stack: p.stack,
+		errs:  p.errs,
+	}
+	return sub.parse(ss, opts)
+}
+
+// checkVersion reports an error if the document uses @version but
+// knows nothing of the version selected with WithThemeVersion.
+func (p *sheetParser) checkVersion(opts []ImportOption) error {
+	selected := makeImportOptions(opts).version
+	if selected == "" || len(p.versions.known) == 0 || p.versions.known[selected] {
+		return nil
+	}
+	return p.errorf(1, "unknown version: %q", selected)
+}
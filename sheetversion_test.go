@@ -0,0 +1,80 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSheetVersions(t *testing.T) {
+	const input = `@version "2";
+footer { faint: true; }
+@version "1" { title { foreground: #f00; } }
+@version "2" { heading { foreground: #f00; } }
+`
+	for _, tc := range []struct {
+		opts []ImportOption
+		exp  string
+	}{
+		{nil, "footer { faint: true; }\nheading { foreground: #f00; }\n"},
+		{[]ImportOption{WithThemeVersion("2")}, "footer { faint: true; }\nheading { foreground: #f00; }\n"},
+		{[]ImportOption{WithThemeVersion("1")}, "footer { faint: true; }\ntitle { foreground: #f00; }\n"},
+	} {
+		ss, err := ImportSheet(StyleSheet{}, input, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkOutput(t, tc.exp, ss.Export())
+	}
+
+	// Without a declared version, only the selected sections apply.
+	ss, err := ImportSheet(StyleSheet{}, `@version "1" { title { bold: true; } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ss.Len() != 0 {
+		t.Errorf("unexpected styles: %v", ss.Names())
+	}
+
+	// The version is part of the metadata of themes.
+	th, err := LoadTheme(strings.NewReader(input), WithThemeVersion("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Version != "2" {
+		t.Errorf("unexpected version: %q", th.Version)
+	}
+	var buf strings.Builder
+	if err := SaveTheme(&buf, th); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `@theme { mode: any; }
+@version "2";
+
+footer { faint: true; }
+title { foreground: #f00; }
+`, buf.String())
+
+	// The documents that do not use @version accept any version.
+	if _, err := ImportSheet(StyleSheet{}, `title { bold: true; }`, WithThemeVersion("3")); err != nil {
+		t.Error(err)
+	}
+
+	for _, tc := range []struct {
+		in     string
+		opts   []ImportOption
+		expErr string
+	}{
+		{input, []ImportOption{WithThemeVersion("3")}, `line 1: unknown version: "3"`},
+		{`@version 2;`, nil, `line 1: invalid syntax: "@version 2"`},
+		{`@version "2"`, nil, `line 1: expected ";" or "{" after @version`},
+		{"@version \"2\";\n@version \"3\";", nil, `line 2: version already declared: "2"`},
+		{"@version \"1\" { }\n@version \"2\";", nil, `line 2: the version must be declared before the @version sections`},
+		{`@version "1" { @version "2"; }`, []ImportOption{WithThemeVersion("1")}, `line 1: @version is only supported at the top level of documents`},
+		{`@dark { @version "2"; }`, []ImportOption{WithBackgroundMode(true)}, `line 1: @version is only supported at the top level of documents`},
+	} {
+		_, err := ImportSheet(StyleSheet{}, tc.in, tc.opts...)
+		if err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SourcePos identifies the position of a directive in an input.
+type SourcePos struct {
+	// File is the name of the input, as passed to ImportWithSourceMap.
+	File string
+	// Line is the 1-based line number of the directive.
+	Line int
+	// Column is the 1-based column of the directive, in characters,
+	// or 0 if unknown.
+	Column int
+}
+
+// String implements fmt.Stringer.
+func (p SourcePos) String() string {
+	if p.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// SourceError is the type of the errors returned by
+// ImportWithSourceMap, indicating the position of the invalid directive.
+type SourceError struct {
+	Pos SourcePos
+	Err error
+}
+
+// Error implements the error interface.
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Pos, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SourceError) Unwrap() error { return e.Err }
+
+// SourceMap records, for each property set in a style, the position
+// of the directive that last set it.
+type SourceMap map[string]SourcePos
+
+// ImportWithSourceMap is like Import, but also returns the position of
+// the directive responsible for each property set in the resulting
+// style. The file argument names the input in the positions and error
+// messages. Properties already set in dst before the call do not
+// appear in the source map.
+//
+// This makes it possible to answer "why is this bold?" questions by
+// pointing at the responsible line.
+//
+// The options are those of Import, except that the separator is always
+// ";". Warnings include the position of the directive.
+func ImportWithSourceMap(dst S, input, file string, opts ...ImportOption) (S, SourceMap, error) {
+	opt := makeImportOptions(opts)
+	warn := opt.warn
+	sm := SourceMap{}
+	important := map[string]bool{}
+	input, err := preprocess(input, ";", &opt)
+	if err != nil {
+		return dst, sm, err
+	}
+	line := 1
+	for _, a := range splitOutsideStrings(input, ";") {
+		// The directive starts at its first non-space character.
+		trimmed := strings.TrimLeft(a, " \t\r\n")
+		pos := SourcePos{File: file, Line: line + strings.Count(a[:len(a)-len(trimmed)], "\n")}
+		line += strings.Count(a, "\n")
+		if strings.TrimSpace(trimmed) == "" {
+			continue
+		}
+
+		before := propValues(dst)
+		var err error
+		dopts := append(opts[:len(opts):len(opts)], WithImportSeparator(";"), withImportant(important))
+		if warn != nil {
+			dopts = append(dopts, WithWarnings(func(w Warning) {
+				w.Pos = pos
+				warn(w)
+			}))
+		}
+		dst, err = Import(dst, a, dopts...)
+		if err != nil {
+			return dst, sm, &SourceError{Pos: pos, Err: err}
+		}
+		after := propValues(dst)
+
+		propName, args, _ := splitAssignment(strings.TrimSpace(a))
+		if opt.abbrev {
+			if full, err := ResolveProperty(propName); err == nil {
+				propName = full
+			}
+		}
+		// A directive does not set a property marked !important
+		// earlier, unless it is itself important.
+		_, isImportant := splitImportant(args)
+		named := propName != "" && (isImportant || !important[propName])
+		for i, g := range styleGetters {
+			if (named && g.name == propName) || !reflect.DeepEqual(before[i], after[i]) {
+				sm[g.name] = pos
+			}
+		}
+	}
+
+	// Forget about the properties that were set, then unset afterwards.
+	v := reflect.ValueOf(dst)
+	for _, g := range styleGetters {
+		if isDefault(g.getFn.Call([]reflect.Value{v})[0]) {
+			delete(sm, g.name)
+		}
+	}
+	return dst, sm, nil
+}
+
+// propValues retrieves the value of every getter in styleGetters.
+func propValues(s S) []interface{} {
+	v := reflect.ValueOf(s)
+	vals := make([]interface{}, len(styleGetters))
+	for i, g := range styleGetters {
+		vals[i] = g.getFn.Call([]reflect.Value{v})[0].Interface()
+	}
+	return vals
+}
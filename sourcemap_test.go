@@ -0,0 +1,65 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestImportWithSourceMap(t *testing.T) {
+	const input = `bold: true;
+foreground: 12; italic: true;
+
+padding: 1 2;
+  padding-left: 3;
+italic: unset;
+bold: true;
+`
+	s, sm, err := ImportWithSourceMap(lipgloss.NewStyle().Faint(true), input, "theme.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; faint: true; foreground: 12; padding-bottom: 1; padding-left: 3; padding-right: 2; padding-top: 1;`, Export(s))
+
+	exp := map[string]string{
+		"bold":           "theme.gloss:7",
+		"foreground":     "theme.gloss:2",
+		"padding-bottom": "theme.gloss:4",
+		"padding-left":   "theme.gloss:5",
+		"padding-right":  "theme.gloss:4",
+		"padding-top":    "theme.gloss:4",
+	}
+	if len(sm) != len(exp) {
+		t.Errorf("expected %v, got %v", exp, sm)
+	}
+	for prop, pos := range exp {
+		if sm[prop].String() != pos {
+			t.Errorf("%s: expected %s, got %s", prop, pos, sm[prop])
+		}
+	}
+
+	// The separators within strings do not count.
+	s, sm, err = ImportWithSourceMap(lipgloss.NewStyle(), "bold: true;\nborder-style: border(\";\",\";\",\"|\",\"|\",\"+\",\"+\",\"+\",\"+\");", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; border-style: border(";",";","|","|","+","+","+","+");`, Export(s))
+	if sm["border-style"].String() != "x:2" {
+		t.Errorf("unexpected position: %s", sm["border-style"])
+	}
+
+	_, _, err = ImportWithSourceMap(lipgloss.NewStyle(), "bold: true;\n\nbold: maybe", "x")
+	if err == nil || err.Error() != `x:3: in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImportWithSourceMapImportant(t *testing.T) {
+	_, sm, err := ImportWithSourceMap(lipgloss.NewStyle(), "bold: true !important;\nbold: true;\nitalic: true;\nitalic: true !important;", "theme.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sm["bold"].String() != "theme.gloss:1" || sm["italic"].String() != "theme.gloss:4" {
+		t.Errorf("unexpected source map: %v", sm)
+	}
+}
@@ -0,0 +1,62 @@
+package lipglossc
+
+import "sync/atomic"
+
+// StyleStore holds a set of named styles that can be read
+// concurrently without locking, and replaced atomically as a whole,
+// e.g. when a theme is reloaded. Readers never observe a partially
+// updated set of styles.
+//
+// The zero value is an empty store ready to use.
+type StyleStore struct {
+	styles atomic.Value // map[string]S
+}
+
+// NewStyleStore creates a StyleStore holding the given styles.
+func NewStyleStore(styles map[string]S) *StyleStore {
+	s := &StyleStore{}
+	s.Replace(styles)
+	return s
+}
+
+// Get retrieves the named style. The result is a copy and can be
+// modified freely.
+func (s *StyleStore) Get(name string) (S, bool) {
+	st, ok := s.load()[name]
+	if !ok {
+		return st, false
+	}
+	return st.Copy(), true
+}
+
+// Snapshot returns a copy of all the styles in the store. Use this
+// instead of multiple calls to Get to observe a consistent theme
+// while the store is being updated.
+func (s *StyleStore) Snapshot() map[string]S {
+	m := s.load()
+	res := make(map[string]S, len(m))
+	for name, st := range m {
+		res[name] = st.Copy()
+	}
+	return res
+}
+
+// Len returns the number of styles in the store.
+func (s *StyleStore) Len() int {
+	return len(s.load())
+}
+
+// Replace atomically replaces all the styles in the store. The styles
+// are copied, so the caller can continue to use the argument.
+func (s *StyleStore) Replace(styles map[string]S) {
+	m := make(map[string]S, len(styles))
+	for name, st := range styles {
+		m[name] = st.Copy()
+	}
+	s.styles.Store(m)
+}
+
+func (s *StyleStore) load() map[string]S {
+	m, _ := s.styles.Load().(map[string]S)
+	return m
+}
@@ -0,0 +1,55 @@
+package lipglossc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStyleStore(t *testing.T) {
+	var empty StyleStore
+	if _, ok := empty.Get("title"); ok || empty.Len() != 0 {
+		t.Errorf("expected empty store")
+	}
+
+	theme := map[string]S{"title": lipgloss.NewStyle().Bold(true)}
+	st := NewStyleStore(theme)
+	theme["title"].Italic(true) // does not affect the store
+	title, ok := st.Get("title")
+	if !ok {
+		t.Fatal("title not found")
+	}
+	checkOutput(t, `bold: true;`, Export(title))
+	title.Faint(true) // does not affect the store either
+	title, _ = st.Get("title")
+	checkOutput(t, `bold: true;`, Export(title))
+
+	// Concurrent readers observe either theme, never a mix.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				snap := st.Snapshot()
+				_, ok := snap["footer"]
+				if ok != (Export(snap["title"]) == `faint: true;`) {
+					t.Errorf("mixed themes: %v", snap)
+				}
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		if j%2 == 1 {
+			st.Replace(theme)
+			continue
+		}
+		st.Replace(map[string]S{"title": lipgloss.NewStyle().Faint(true), "footer": lipgloss.NewStyle()})
+	}
+	wg.Wait()
+	st.Replace(map[string]S{"title": lipgloss.NewStyle(), "footer": lipgloss.NewStyle()})
+	if st.Len() != 2 {
+		t.Errorf("expected 2 styles, got %d", st.Len())
+	}
+}
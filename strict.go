@@ -0,0 +1,34 @@
+package lipglossc
+
+import "fmt"
+
+// WithStrict makes Import reject the extensions to the core syntax of
+// style specifications, so that specs intended for other deployments
+// of the library, which may not share them, can be verified:
+//
+//   - the pseudo-properties that do not correspond to a lipgloss.Style
+//     method, e.g. apply or the whitespace-xxx properties of
+//     ImportSpec;
+//   - the constants registered with RegisterConstant and the macros
+//     registered with RegisterMacro;
+//   - the abbreviations of property names, even with
+//     WithAbbreviations.
+//
+// Validate, ImportSpec and ImportSheet accept the option too.
+func WithStrict() ImportOption {
+	return func(o *importOptions) {
+		o.strict = true
+	}
+}
+
+// checkStrict reports the use of registered constants in a property
+// value in strict mode.
+func (opt *importOptions) checkStrict(args string) error {
+	if !opt.strict {
+		return nil
+	}
+	if name, ok := usedConstant(args); ok {
+		return fmt.Errorf("registered constant %q not allowed in strict mode", name)
+	}
+	return nil
+}
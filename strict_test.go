@@ -0,0 +1,55 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStrict(t *testing.T) {
+	RegisterConstant("brand-accent", "#7D56F4")
+	defer func() {
+		constants.Lock()
+		defer constants.Unlock()
+		constants.values = map[string]string{}
+	}()
+
+	// The core syntax is accepted.
+	const core = `$pad: 2; bold: true !important; padding: 0 $pad; border { style: rounded; top: true; }
+border-style: border("brand-accent","b","c","d","e","f","g","h")`
+	s, err := Import(lipgloss.NewStyle(), core, WithStrict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; border-style: border("brand-accent","b","c","d","e","f","g","h"); border-top: true; padding-left: 2; padding-right: 2;`, Export(s))
+
+	td := []struct {
+		in     string
+		expErr string
+	}{
+		{`foreground: brand-accent`, `in "foreground: brand-accent": registered constant "brand-accent" not allowed in strict mode`},
+		{`$c: brand-accent; foreground: $c`, `in "foreground: $c": registered constant "brand-accent" not allowed in strict mode`},
+		{`fg: #fff`, `in "fg: #fff": property not supported: "fg"`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			if _, err := Import(lipgloss.NewStyle(), tc.in); err != nil {
+				if _, err := Import(lipgloss.NewStyle(), tc.in, WithAbbreviations()); err != nil {
+					t.Fatalf("expected success without strict mode, got %v", err)
+				}
+			}
+			_, err := Import(lipgloss.NewStyle(), tc.in, WithStrict(), WithAbbreviations())
+			if err == nil || err.Error() != tc.expErr {
+				t.Errorf("expected %q, got %v", tc.expErr, err)
+			}
+			if err := Validate(tc.in, WithStrict(), WithAbbreviations()); err == nil || err.Error() != tc.expErr {
+				t.Errorf("Validate: expected %q, got %v", tc.expErr, err)
+			}
+		})
+	}
+
+	if _, err := ImportSpec(StyleSpec{}, `bold: true; whitespace-chars: "."`, WithStrict()); err == nil ||
+		err.Error() != `in "whitespace-chars: \".\"": extension property "whitespace-chars" not allowed in strict mode` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StyleSpec extends a style with the whitespace settings used by
+// lipgloss.Place and its variants, which lipgloss does not store in
+// styles. This makes it possible to declare placement styling in the
+// same specifications as the style itself, with the pseudo-properties
+// whitespace-chars, whitespace-foreground and whitespace-background:
+//
+//	foreground: 12; whitespace-chars: "·"; whitespace-foreground: 8;
+type StyleSpec struct {
+	Style S
+	// WhitespaceChars are the characters used to fill the whitespace.
+	// An empty string means the default (spaces).
+	WhitespaceChars string
+	// WhitespaceForeground and WhitespaceBackground color the
+	// whitespace. Nil means no color.
+	WhitespaceForeground lipgloss.TerminalColor
+	WhitespaceBackground lipgloss.TerminalColor
+}
+
+// WhitespaceOptions returns the options to pass to lipgloss.Place, e.g.
+//
+//	lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, text, spec.WhitespaceOptions()...)
+func (sp StyleSpec) WhitespaceOptions() []lipgloss.WhitespaceOption {
+	var opts []lipgloss.WhitespaceOption
+	if sp.WhitespaceChars != "" {
+		opts = append(opts, lipgloss.WithWhitespaceChars(sp.WhitespaceChars))
+	}
+	if sp.WhitespaceForeground != nil {
+		opts = append(opts, lipgloss.WithWhitespaceForeground(sp.WhitespaceForeground))
+	}
+	if sp.WhitespaceBackground != nil {
+		opts = append(opts, lipgloss.WithWhitespaceBackground(sp.WhitespaceBackground))
+	}
+	return opts
+}
+
+// ImportSpec is like Import, but also accepts the whitespace
+// pseudo-properties. The value of whitespace-chars is either a
+// double-quoted string or a single word. The pseudo-properties can be
+// reset with "unset" and are also reset by "clear".
+func ImportSpec(dst StyleSpec, input string, opts ...ImportOption) (StyleSpec, error) {
+	opt := makeImportOptions(opts)
+	// Apply the directives one by one, keeping the !important markers
+	// and the variables across them.
+	opts = append(opts[:len(opts):len(opts)], withImportant(opt.important), withSharedVariables(opt.vars))
+	input, err := preprocess(input, opt.sep, &opt)
+	if err != nil {
+		return dst, err
+	}
+	for _, a := range splitAssignments(input, opt.sep) {
+		propName, args, ok := splitAssignment(a)
+		if !ok || !strings.HasPrefix(propName, "whitespace-") {
+			if a == "clear" {
+				dst = StyleSpec{Style: dst.Style}
+			}
+			var err error
+			dst.Style, err = Import(dst.Style, a, opts...)
+			if err != nil {
+				return dst, err
+			}
+			continue
+		}
+		if opt.strict {
+			return dst, fmt.Errorf("in %q: extension property %q not allowed in strict mode", a, propName)
+		}
+		args, err := expandVariables(args, opt.vars)
+		if err != nil {
+			return dst, fmt.Errorf("in %q: %v", a, err)
+		}
+		opt.ranges.clamped = opt.ranges.clamped[:0]
+		if err := dst.setWhitespace(propName, args, &opt.ranges); err != nil {
+			return dst, fmt.Errorf("in %q: %v", a, err)
+		}
+		opt.reportClamped(propName)
+	}
+	return dst, nil
+}
+
+func (sp *StyleSpec) setWhitespace(name, args string, rc *rangeCheck) error {
+	switch name {
+	case "whitespace-chars":
+		switch {
+		case args == "unset":
+			sp.WhitespaceChars = ""
+		case strings.HasPrefix(args, `"`):
+			s, err := strconv.Unquote(args)
+			if err != nil {
+				return fmt.Errorf("invalid string: %s", args)
+			}
+			sp.WhitespaceChars = s
+		case strings.ContainsAny(args, " \t"):
+			return fmt.Errorf("excess values at end: ...%s", args[strings.IndexAny(args, " \t"):])
+		default:
+			sp.WhitespaceChars = args
+		}
+	case "whitespace-foreground", "whitespace-background":
+		var c lipgloss.TerminalColor
+		if args != "unset" {
+			vals, err := prop{args: []argtype{colortype{}}}.parseArgs(args, rc)
+			if err != nil {
+				return err
+			}
+			c = vals[0].Interface().(lipgloss.TerminalColor)
+		}
+		if name == "whitespace-foreground" {
+			sp.WhitespaceForeground = c
+		} else {
+			sp.WhitespaceBackground = c
+		}
+	default:
+		return fmt.Errorf("property not supported: %q", name)
+	}
+	return nil
+}
+
+// ExportSpec is like Export, but also emits the whitespace
+// pseudo-properties after the properties of the style.
+func ExportSpec(sp StyleSpec, opts ...ExportOption) string {
+	opt := makeExportOptions(opts)
+	if opt.shellQuote {
+		opt.sep = " "
+	}
+	var extra []string
+	if sp.WhitespaceChars != "" {
+		extra = append(extra, "whitespace-chars: "+strconv.Quote(sp.WhitespaceChars)+";")
+	}
+	for _, c := range []struct {
+		name string
+		tc   lipgloss.TerminalColor
+	}{
+		{"whitespace-background", sp.WhitespaceBackground},
+		{"whitespace-foreground", sp.WhitespaceForeground},
+	} {
+		if c.tc == nil {
+			continue
+		}
+		var buf strings.Builder
+		printValue(&buf, c.name, reflect.ValueOf(&c.tc).Elem(), &opt)
+		extra = append(extra, c.name+": "+buf.String()+";")
+	}
+
+	// Export the style without quoting, to quote the whole result.
+	res := Export(sp.Style, append(opts[:len(opts):len(opts)], func(e *options) {
+		e.shellQuote = false
+		e.sep = opt.sep
+	})...)
+	for _, e := range extra {
+		if res != "" {
+			res += opt.sep
+		}
+		res += e
+	}
+	if opt.shellQuote {
+		return shellQuote(res)
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStyleSpec(t *testing.T) {
+	sp, err := ImportSpec(StyleSpec{Style: lipgloss.NewStyle()},
+		`bold: true !important; whitespace-chars: "·:"; whitespace-foreground: 8; whitespace-background: adaptive(#fff,#000); bold: false`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; whitespace-chars: "·:"; whitespace-background: adaptive(#fff,#000); whitespace-foreground: 8;`, ExportSpec(sp))
+	if n := len(sp.WhitespaceOptions()); n != 3 {
+		t.Errorf("expected 3 options, got %d", n)
+	}
+
+	sp, err = ImportSpec(sp, `whitespace-chars: -; whitespace-background: unset`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true;
+whitespace-chars: "-";
+whitespace-foreground: 8;`, ExportSpec(sp, WithSeparator("\n")))
+	checkOutput(t, `'bold: true; whitespace-chars: "-"; whitespace-foreground: 8;'`, ExportSpec(sp, WithShellQuoting()))
+
+	// The whitespace settings are used by Place.
+	res := lipgloss.Place(3, 1, lipgloss.Left, lipgloss.Top, "x", sp.WhitespaceOptions()...)
+	if lipgloss.Width(res) != 3 {
+		t.Errorf("unexpected placement: %q", res)
+	}
+
+	sp, err = ImportSpec(sp, `clear; italic: true`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `italic: true;`, ExportSpec(sp))
+
+	// The variables carry over to the next directives, including the
+	// whitespace pseudo-properties.
+	sp, err = ImportSpec(StyleSpec{Style: lipgloss.NewStyle()}, `$x: 12; $dots: "$"; foreground: $x; whitespace-foreground: $x; whitespace-chars: $dots`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `foreground: 12; whitespace-chars: "$"; whitespace-foreground: 12;`, ExportSpec(sp))
+
+	for _, tc := range []struct {
+		in, expErr string
+	}{
+		{`whitespace-chars: a b`, `in "whitespace-chars: a b": excess values at end: ... b`},
+		{`whitespace-chars: "a`, `in "whitespace-chars: \"a": invalid string: "a`},
+		{`whitespace-foreground: nope`, `in "whitespace-foreground: nope": color not recognized: "nope"`},
+		{`whitespace-width: 2`, `in "whitespace-width: 2": property not supported: "whitespace-width"`},
+		{`bold: maybe`, `in "bold: maybe": no value found; bold expects true or false`},
+		{`whitespace-foreground: $nope`, `in "whitespace-foreground: $nope": undefined variable: $nope`},
+	} {
+		if _, err := ImportSpec(StyleSpec{}, tc.in); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+}
+
+func TestStyleSpecZero(t *testing.T) {
+	sp, err := ImportSpec(StyleSpec{}, `bold: true; whitespace-chars: x`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; whitespace-chars: "x";`, ExportSpec(sp))
+}
@@ -0,0 +1,218 @@
+package lipglossc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Surface describes the properties supported with a given version of
+// lipgloss: it maps the name of each property to its default value as
+// printed by Export with WithExportDefaults, or to the empty string
+// for the properties without a default value of their own, e.g. the
+// shorthands like padding.
+//
+// A maintainer can save the surface with String before upgrading
+// lipgloss, and compare it with the new surface with DiffSurfaces
+// afterwards.
+type Surface map[string]string
+
+// CurrentSurface returns the surface of the version of lipgloss the
+// program is built with.
+func CurrentSurface() Surface {
+	res := Surface{}
+	for _, name := range Properties() {
+		res[name] = ""
+	}
+	for _, pv := range exportProps(lipgloss.NewStyle(), &options{includeDefaults: true}) {
+		res[pv.name] = pv.value
+	}
+	return res
+}
+
+// String formats the surface with one property per line, in sorted
+// order, followed by its default value if any, e.g. "bold: false". The
+// result can be read back with ParseSurface.
+func (s Surface) String() string {
+	var buf strings.Builder
+	for _, name := range s.names() {
+		buf.WriteString(name)
+		if v := s[name]; v != "" {
+			buf.WriteString(": ")
+			buf.WriteString(v)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func (s Surface) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseSurface reads a surface as formatted by Surface.String. The
+// property names are not checked against the current version of
+// lipgloss.
+func ParseSurface(text string) (Surface, error) {
+	res := Surface{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := splitAssignment(line)
+		if !ok {
+			name, value = line, ""
+		}
+		if !reConstName.MatchString(name) {
+			return nil, fmt.Errorf("line %d: invalid property name: %q", i+1, name)
+		}
+		res[name] = value
+	}
+	return res, nil
+}
+
+// SurfaceDiff lists the differences between two surfaces, as computed
+// by DiffSurfaces.
+type SurfaceDiff struct {
+	// Added lists the new properties, in sorted order.
+	Added []string
+	// Removed lists the properties that do not exist anymore, in
+	// sorted order.
+	Removed []string
+	// Renamed maps the old names of the renamed properties to their
+	// new name.
+	Renamed map[string]string
+	// Changed lists the properties whose default value changed, in
+	// sorted order.
+	Changed []string
+}
+
+// DiffSurfaces compares the surfaces before and after an upgrade of
+// lipgloss.
+//
+// A removed property is considered renamed to an added property when
+// they have the same default value and their names have the same
+// words, possibly in a different order, or differ by a single word
+// among several, e.g. color-whitespace and whitespace-color. Ambiguous renamings
+// are reported as removals and additions.
+func DiffSurfaces(before, after Surface) SurfaceDiff {
+	var d SurfaceDiff
+	var added, removed []string
+	for _, name := range after.names() {
+		if _, ok := before[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for _, name := range before.names() {
+		v, ok := after[name]
+		switch {
+		case !ok:
+			removed = append(removed, name)
+		case v != before[name]:
+			d.Changed = append(d.Changed, name)
+		}
+	}
+
+	// Find the renamings which are not ambiguous in either direction.
+	candidates := func(name string, value string, others []string, values Surface) []string {
+		var res []string
+		for _, other := range others {
+			if values[other] == value && similarNames(name, other) {
+				res = append(res, other)
+			}
+		}
+		return res
+	}
+	renamed := map[string]bool{}
+	for _, r := range removed {
+		c := candidates(r, before[r], added, after)
+		if len(c) != 1 || len(candidates(c[0], after[c[0]], removed, before)) != 1 {
+			continue
+		}
+		if d.Renamed == nil {
+			d.Renamed = map[string]string{}
+		}
+		d.Renamed[r] = c[0]
+		renamed[r], renamed[c[0]] = true, true
+	}
+	for _, name := range added {
+		if !renamed[name] {
+			d.Added = append(d.Added, name)
+		}
+	}
+	for _, name := range removed {
+		if !renamed[name] {
+			d.Removed = append(d.Removed, name)
+		}
+	}
+	return d
+}
+
+// similarNames reports whether two property names have the same
+// words, possibly in a different order, or differ by a single word
+// among several.
+func similarNames(a, b string) bool {
+	wa, wb := strings.Split(a, "-"), strings.Split(b, "-")
+	if len(wa) != len(wb) {
+		return false
+	}
+	count := map[string]int{}
+	for _, w := range wa {
+		count[w]++
+	}
+	for _, w := range wb {
+		count[w]--
+	}
+	diff := 0
+	for _, n := range count {
+		if n > 0 {
+			diff += n
+		}
+	}
+	// At least one word must remain in common.
+	return diff <= 1 && diff < len(wa)
+}
+
+// Empty reports whether the surfaces were identical.
+func (d SurfaceDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renamed) == 0 && len(d.Changed) == 0
+}
+
+// Migration returns the renamings of properties, for use with
+// MigrateSheet to update the sheets written for the old surface.
+func (d SurfaceDiff) Migration() Migration {
+	return Migration{Properties: d.Renamed}
+}
+
+// String formats the differences with one property per line: "+" for
+// additions, "-" for removals, "~" for renamings and "!" for changed
+// default values.
+func (d SurfaceDiff) String() string {
+	var buf strings.Builder
+	for _, name := range d.Added {
+		fmt.Fprintf(&buf, "+ %s\n", name)
+	}
+	for _, name := range d.Removed {
+		fmt.Fprintf(&buf, "- %s\n", name)
+	}
+	olds := make([]string, 0, len(d.Renamed))
+	for name := range d.Renamed {
+		olds = append(olds, name)
+	}
+	sort.Strings(olds)
+	for _, name := range olds {
+		fmt.Fprintf(&buf, "~ %s -> %s\n", name, d.Renamed[name])
+	}
+	for _, name := range d.Changed {
+		fmt.Fprintf(&buf, "! %s\n", name)
+	}
+	return buf.String()
+}
@@ -0,0 +1,84 @@
+package lipglossc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCurrentSurface(t *testing.T) {
+	s := CurrentSurface()
+	for name, exp := range map[string]string{"bold": "false", "width": "0", "padding": "", "foreground": "none"} {
+		if v, ok := s[name]; !ok || v != exp {
+			t.Errorf("%s: expected %q, got %q, %v", name, exp, v, ok)
+		}
+	}
+	for _, name := range Properties() {
+		if _, ok := s[name]; !ok {
+			t.Errorf("%s: missing", name)
+		}
+	}
+
+	text := s.String()
+	if !strings.Contains(text, "\nbold: false\n") || !strings.Contains(text, "\npadding\n") {
+		t.Errorf("unexpected surface:\n%s", text)
+	}
+	res, err := ParseSurface(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, s) {
+		t.Errorf("round trip mismatch:\n%s", res)
+	}
+	if d := DiffSurfaces(s, res); !d.Empty() {
+		t.Errorf("unexpected differences:\n%s", d)
+	}
+
+	if _, err := ParseSurface("bold: false\n\nnot a name\n"); err == nil || err.Error() != `line 3: invalid property name: "not a name"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDiffSurfaces(t *testing.T) {
+	before, err := ParseSurface(`bold: false
+color-whitespace: none
+blink: false
+margin-top: 0
+padding
+underline-spaces: false
+width: 0
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := ParseSurface(`bold: false
+whitespace-color: none
+margin-block-start: 0
+padding
+strikethrough: false
+underline-space: false
+width: 1
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := DiffSurfaces(before, after)
+	checkOutput(t, `+ margin-block-start
++ strikethrough
+- blink
+- margin-top
+~ color-whitespace -> whitespace-color
+~ underline-spaces -> underline-space
+! width
+`, d.String())
+
+	res, err := MigrateSheet("title { color-whitespace: 12; bold: true; }", d.Migration())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "title { whitespace-color: 12; bold: true; }", res)
+
+	// An ambiguous renaming is not reported as such.
+	d = DiffSurfaces(Surface{"border-top-color": "none"}, Surface{"top-color": "none", "border-top-fg": "none", "border-top-colour": "none"})
+	checkOutput(t, "+ border-top-colour\n+ border-top-fg\n+ top-color\n- border-top-color\n", d.String())
+}
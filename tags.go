@@ -0,0 +1,55 @@
+package lipglossc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ImportTags applies the style specifications found in the `style`
+// tags of the lipgloss.Style fields of the struct pointed to by v.
+// For example:
+//
+//	var theme struct {
+//		Title  lipgloss.Style `style:"bold: true; foreground: 212"`
+//		Footer lipgloss.Style `style:"faint: true"`
+//	}
+//	err := lipglossc.ImportTags(&theme)
+//
+// The specifications are applied over the current value of each
+// field. Nested struct fields are processed recursively.
+func ImportTags(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected pointer to struct, got %T", v)
+	}
+	return importTags(rv.Elem(), "")
+}
+
+func importTags(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := v.Field(i)
+		name := prefix + f.Name
+		spec, hasTag := f.Tag.Lookup("style")
+		switch {
+		case f.Type == styleType:
+			if !hasTag {
+				continue
+			}
+			if !fv.CanSet() {
+				return fmt.Errorf("%s: cannot set unexported field", name)
+			}
+			s, err := Import(fv.Interface().(S), spec)
+			if err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+			fv.Set(reflect.ValueOf(s))
+		case f.Type.Kind() == reflect.Struct && fv.CanSet():
+			if err := importTags(fv, name+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestImportTags(t *testing.T) {
+	var theme struct {
+		Title  lipgloss.Style `style:"bold: true; foreground: 212"`
+		Footer lipgloss.Style `style:"faint: true"`
+		Plain  lipgloss.Style
+		List   struct {
+			Item lipgloss.Style `style:"padding-left: 2"`
+		}
+		Other string `style:"ignored"`
+	}
+	theme.Footer = lipgloss.NewStyle().Italic(true)
+
+	if err := ImportTags(&theme); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `bold: true; foreground: 212;`, Export(theme.Title))
+	checkOutput(t, `faint: true; italic: true;`, Export(theme.Footer))
+	checkOutput(t, ``, Export(theme.Plain))
+	checkOutput(t, `padding-left: 2;`, Export(theme.List.Item))
+
+	var bad struct {
+		Nested struct {
+			Title lipgloss.Style `style:"bold: maybe"`
+		}
+	}
+	if err := ImportTags(&bad); err == nil || err.Error() != `Nested.Title: in "bold: maybe": no value found; bold expects true or false` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var unexported struct {
+		title lipgloss.Style `style:"bold: true"`
+	}
+	if err := ImportTags(&unexported); err == nil || err.Error() != `title: cannot set unexported field` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_ = unexported.title
+	if err := ImportTags(theme); err == nil {
+		t.Errorf("expected error for non-pointer")
+	}
+}
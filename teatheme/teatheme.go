@@ -0,0 +1,125 @@
+// Package teatheme provides runtime theme switching for Bubble Tea
+// programs using styles parsed by lipglossc.
+package teatheme
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ThemeChangedMsg is the message produced by the command returned by
+// Manager.Switch once the new theme is active. Bubble Tea models
+// should re-render upon receiving it.
+type ThemeChangedMsg struct {
+	// Name is the name of the theme that is now active.
+	Name string
+	// Styles are the styles of the new active theme.
+	Styles map[string]lipgloss.Style
+}
+
+// ThemeErrorMsg is produced by the command returned by Manager.Switch
+// when the requested theme does not exist.
+type ThemeErrorMsg struct {
+	Err error
+}
+
+// Manager holds a set of named themes and the active theme. It is
+// safe for concurrent use.
+type Manager struct {
+	mu     sync.RWMutex
+	themes map[string]map[string]lipgloss.Style
+	active string
+	subs   []func(ThemeChangedMsg)
+}
+
+// NewManager creates a Manager with the given themes, the initial
+// one being active.
+func NewManager(themes map[string]map[string]lipgloss.Style, initial string) (*Manager, error) {
+	if _, ok := themes[initial]; !ok {
+		return nil, fmt.Errorf("unknown theme: %q", initial)
+	}
+	m := &Manager{themes: make(map[string]map[string]lipgloss.Style, len(themes)), active: initial}
+	for name, t := range themes {
+		m.themes[name] = t
+	}
+	return m, nil
+}
+
+// Themes returns the names of the available themes, in sorted order.
+func (m *Manager) Themes() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.themes))
+	for name := range m.themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Active returns the name of the active theme.
+func (m *Manager) Active() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.active
+}
+
+// Style returns the named style in the active theme. An empty style
+// is returned if the active theme does not define it.
+func (m *Manager) Style(name string) lipgloss.Style {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.themes[m.active][name]
+}
+
+// SetTheme adds or replaces a theme. If it is the active theme, the
+// subscribers are notified.
+func (m *Manager) SetTheme(name string, t map[string]lipgloss.Style) {
+	m.mu.Lock()
+	m.themes[name] = t
+	active := m.active == name
+	m.mu.Unlock()
+	if active {
+		m.notify(ThemeChangedMsg{Name: name, Styles: t})
+	}
+}
+
+// Subscribe registers a function called every time the active theme
+// changes, for components that are not Bubble Tea models.
+func (m *Manager) Subscribe(fn func(ThemeChangedMsg)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subs = append(m.subs, fn)
+}
+
+// Switch returns a command that activates the named theme, notifies
+// the subscribers and produces a ThemeChangedMsg, or a ThemeErrorMsg
+// if the theme does not exist.
+func (m *Manager) Switch(name string) tea.Cmd {
+	return func() tea.Msg {
+		m.mu.Lock()
+		t, ok := m.themes[name]
+		if !ok {
+			m.mu.Unlock()
+			return ThemeErrorMsg{Err: fmt.Errorf("unknown theme: %q", name)}
+		}
+		m.active = name
+		m.mu.Unlock()
+		msg := ThemeChangedMsg{Name: name, Styles: t}
+		m.notify(msg)
+		return msg
+	}
+}
+
+func (m *Manager) notify(msg ThemeChangedMsg) {
+	m.mu.RLock()
+	subs := m.subs
+	m.mu.RUnlock()
+	for _, fn := range subs {
+		fn(msg)
+	}
+}
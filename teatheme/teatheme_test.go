@@ -0,0 +1,55 @@
+package teatheme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestManager(t *testing.T) {
+	dark := map[string]lipgloss.Style{"title": lipgloss.NewStyle().Bold(true)}
+	light := map[string]lipgloss.Style{"title": lipgloss.NewStyle().Italic(true)}
+
+	if _, err := NewManager(map[string]map[string]lipgloss.Style{"dark": dark}, "light"); err == nil {
+		t.Fatal("expected error for unknown initial theme")
+	}
+
+	m, err := NewManager(map[string]map[string]lipgloss.Style{"dark": dark, "light": light}, "dark")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := m.Themes(); len(names) != 2 || names[0] != "dark" || names[1] != "light" {
+		t.Errorf("unexpected themes: %v", names)
+	}
+	if !m.Style("title").GetBold() {
+		t.Errorf("expected bold title")
+	}
+
+	var notified []string
+	m.Subscribe(func(msg ThemeChangedMsg) { notified = append(notified, msg.Name) })
+
+	msg := m.Switch("light")()
+	changed, ok := msg.(ThemeChangedMsg)
+	if !ok || changed.Name != "light" || !changed.Styles["title"].GetItalic() {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if m.Active() != "light" || !m.Style("title").GetItalic() {
+		t.Errorf("expected light theme to be active")
+	}
+
+	if _, ok := m.Switch("unknown")().(ThemeErrorMsg); !ok {
+		t.Errorf("expected error message")
+	}
+	if m.Active() != "light" {
+		t.Errorf("failed switch must not change the active theme")
+	}
+
+	m.SetTheme("dark", light)
+	m.SetTheme("light", dark)
+	if !m.Style("title").GetBold() {
+		t.Errorf("expected updated theme")
+	}
+	if len(notified) != 2 || notified[0] != "light" || notified[1] != "light" {
+		t.Errorf("unexpected notifications: %v", notified)
+	}
+}
@@ -0,0 +1,153 @@
+package lipglossc
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// Theme bundles the styles of an application with their palette and
+// descriptive metadata, so that applications can pass a whole theme
+// around instead of ad-hoc maps of styles.
+type Theme struct {
+	// Name is the display name of the theme, e.g. "Solarized Light".
+	Name string
+	// Author credits the author of the theme.
+	Author string
+	// Mode indicates the terminal background the theme is designed for.
+	Mode ThemeMode
+	// Version is the version declared by the document with @version,
+	// if any.
+	Version string
+	// Sheet holds the styles of the theme, and its palette.
+	Sheet StyleSheet
+}
+
+// ThemeMode indicates the terminal background a theme is designed for.
+type ThemeMode int
+
+const (
+	// ThemeAnyMode is for themes that adapt to any background, for
+	// example with adaptive colors. This is the default.
+	ThemeAnyMode ThemeMode = iota
+	// ThemeDark is for themes designed for dark backgrounds.
+	ThemeDark
+	// ThemeLight is for themes designed for light backgrounds.
+	ThemeLight
+)
+
+// themeModes are the names of the modes in @theme sections.
+var themeModes = []string{"any", "dark", "light"}
+
+// String implements fmt.Stringer.
+func (m ThemeMode) String() string {
+	if m < 0 || int(m) >= len(themeModes) {
+		return fmt.Sprintf("ThemeMode(%d)", int(m))
+	}
+	return themeModes[m]
+}
+
+// Palette returns the palette of the theme, as per StyleSheet.Palette.
+func (t Theme) Palette() map[string]string {
+	return t.Sheet.Palette()
+}
+
+// LoadTheme reads a theme from a stylesheet document, as accepted by
+// ImportSheet, which can describe the theme in a @theme section:
+//
+//	@theme { name: "Dracula"; author: "Zeno Rocha"; mode: dark; }
+//	@palette { purple: #bd93f9; }
+//	title { foreground: purple; }
+//
+// The mode is one of any, dark or light. The name and author are
+// double-quoted strings, or single words. The options are those of
+// ImportSheet.
+func LoadTheme(r io.Reader, opts ...ImportOption) (Theme, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Theme{}, err
+	}
+	var t Theme
+	p := sheetParser{input: string(data), line: 1, theme: &t}
+	if err := p.parseDocument(&t.Sheet, opts); err != nil {
+		return Theme{}, err
+	}
+	return t, nil
+}
+
+// SaveTheme writes the theme as a document suitable for LoadTheme: a
+// @theme section with the metadata, followed by the sheet as per
+// StyleSheet.Export with the given options.
+func SaveTheme(w io.Writer, t Theme, opts ...ExportOption) error {
+	var buf strings.Builder
+	buf.WriteString("@theme {")
+	if t.Name != "" {
+		fmt.Fprintf(&buf, " name: %s;", strconv.Quote(t.Name))
+	}
+	if t.Author != "" {
+		fmt.Fprintf(&buf, " author: %s;", strconv.Quote(t.Author))
+	}
+	fmt.Fprintf(&buf, " mode: %s; }\n", t.Mode)
+	if t.Version != "" {
+		fmt.Fprintf(&buf, "@version %s;\n", strconv.Quote(t.Version))
+	}
+	if sheet := t.Sheet.Export(opts...); sheet != "" {
+		buf.WriteByte('\n')
+		buf.WriteString(sheet)
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// themeHeader reads a @theme section into the metadata of the theme.
+func (p *sheetParser) themeHeader(opts []ImportOption) error {
+	line := p.line
+	i := strings.IndexAny(p.input[p.pos:], "{};")
+	if i < 0 || p.input[p.pos+i] != '{' || strings.TrimSpace(p.input[p.pos:p.pos+i]) != "@theme" {
+		return p.errorf(line, "expected \"{\" after @theme")
+	}
+	if p.theme == nil {
+		return p.errorf(line, "@theme is only supported at the top level of documents read by LoadTheme")
+	}
+	p.advance(i + 1)
+	body, err := p.block([]string{"@theme"})
+	if err != nil {
+		return err
+	}
+	for _, a := range splitAssignments(body, makeImportOptions(opts).sep) {
+		key, value, ok := splitAssignment(a)
+		if !ok {
+			return p.errorf(line, "invalid syntax: %q", a)
+		}
+		if strings.HasPrefix(value, `"`) {
+			s, err := strconv.Unquote(value)
+			if err != nil {
+				return p.errorf(line, "in %q: invalid string: %s", a, value)
+			}
+			value = s
+		} else if strings.ContainsAny(value, " \t\r\n") {
+			return p.errorf(line, "in %q: expected a double-quoted string or a single word", a)
+		}
+		switch key {
+		case "name":
+			p.theme.Name = value
+		case "author":
+			p.theme.Author = value
+		case "mode":
+			found := false
+			for m, name := range themeModes {
+				if name == value {
+					p.theme.Mode, found = ThemeMode(m), true
+				}
+			}
+			if !found {
+				return p.errorf(line, "in %q: unknown mode %q (expected any, dark or light)", a, value)
+			}
+		default:
+			return p.errorf(line, "in %q: unknown theme attribute %q", a, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTheme(t *testing.T) {
+	const doc = `// A sample theme.
+@theme { name: "Purple Rain"; author: prince; mode: dark; }
+@palette { purple: #bd93f9; }
+title { foreground: purple; bold: true; }
+`
+	th, err := LoadTheme(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Name != "Purple Rain" || th.Author != "prince" || th.Mode != ThemeDark {
+		t.Errorf("unexpected metadata: %+v", th)
+	}
+	if th.Palette()["purple"] != "#bd93f9" {
+		t.Errorf("unexpected palette: %v", th.Palette())
+	}
+	s, _ := th.Sheet.Get("title")
+	checkOutput(t, `bold: true; foreground: #bd93f9;`, Export(s))
+
+	var buf strings.Builder
+	if err := SaveTheme(&buf, th); err != nil {
+		t.Fatal(err)
+	}
+	const exp = `@theme { name: "Purple Rain"; author: "prince"; mode: dark; }
+
+@palette { purple: #bd93f9; }
+
+title { bold: true; foreground: purple; }
+`
+	checkOutput(t, exp, buf.String())
+
+	// The saved theme can be loaded back.
+	th2, err := LoadTheme(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := SaveTheme(&buf, th2); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, exp, buf.String())
+
+	buf.Reset()
+	if err := SaveTheme(&buf, Theme{}); err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "@theme { mode: any; }\n", buf.String())
+
+	for _, tc := range []struct {
+		in     string
+		expErr string
+	}{
+		{`@theme { mode: dim; }`, `line 1: in "mode: dim": unknown mode "dim" (expected any, dark or light)`},
+		{`@theme { name: Purple Rain; }`, `line 1: in "name: Purple Rain": expected a double-quoted string or a single word`},
+		{`@theme { version: 2; }`, `line 1: in "version: 2": unknown theme attribute "version"`},
+		{`@theme { name: "a; }`, `line 1: unterminated block for "@theme"`},
+		{`@dark { @theme { name: x; } }`, `line 1: @theme is only supported at the top level of documents read by LoadTheme`},
+	} {
+		if _, err := LoadTheme(strings.NewReader(tc.in)); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+	if _, err := ImportSheet(StyleSheet{}, `@theme { name: x; }`); err == nil {
+		t.Error("expected error for @theme in ImportSheet")
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.16
+// +build go1.16
+
+package lipglossc
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// LoadThemeFS is like LoadTheme, but reads the document from the named
+// file in fsys, typically an embed.FS:
+//
+//	//go:embed themes
+//	var themes embed.FS
+//
+//	t, err := lipglossc.LoadThemeFS(themes, "themes/dracula.gloss")
+//
+// As with ImportSheetFS, the document can include other documents with
+// @import, and errors are reported with the name of the file where
+// they occur.
+func LoadThemeFS(fsys fs.FS, path string, opts ...ImportOption) (Theme, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return Theme{}, err
+	}
+	var t Theme
+	p := sheetParser{
+		input: string(data),
+		line:  1,
+		file:  path,
+		open: func(name string) (string, error) {
+			data, err := fs.ReadFile(fsys, name)
+			return string(data), err
+		},
+		stack: []string{path},
+		theme: &t,
+	}
+	if err := p.parseDocument(&t.Sheet, opts); err != nil {
+		return Theme{}, err
+	}
+	return t, nil
+}
+
+// LoadAllThemes loads the themes in the files of fsys matching the
+// glob pattern, as per fs.Glob and LoadThemeFS, keyed by file name,
+// e.g. "themes/dracula.gloss". It is an error if no file matches.
+// The first error aborts the loading.
+func LoadAllThemes(fsys fs.FS, glob string, opts ...ImportOption) (map[string]Theme, error) {
+	paths, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no theme file matches %q", glob)
+	}
+	themes := make(map[string]Theme, len(paths))
+	for _, path := range paths {
+		t, err := LoadThemeFS(fsys, path, opts...)
+		if err != nil {
+			return nil, err
+		}
+		themes[path] = t
+	}
+	return themes, nil
+}
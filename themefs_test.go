@@ -0,0 +1,59 @@
+//go:build go1.16
+// +build go1.16
+
+package lipglossc
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadThemeFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"themes/dark.gloss": {Data: []byte(`@theme { name: "Dark"; mode: dark; }
+@import "../common/palette.gloss";
+title { foreground: accent; }
+`)},
+		"themes/light.gloss":   {Data: []byte(`@theme { name: "Light"; mode: light; } title { bold: true; }`)},
+		"themes/README.md":     {Data: []byte(`not a theme`)},
+		"common/palette.gloss": {Data: []byte(`@palette { accent: #7D56F4; }`)},
+		"broken/bad.gloss":     {Data: []byte("@theme { name: \"Bad\"; }\n@import \"../common/bad.gloss\";\n")},
+		"common/bad.gloss":     {Data: []byte("\ntitle { bold: maybe; }")},
+	}
+
+	th, err := LoadThemeFS(fsys, "themes/dark.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, "Dark dark", th.Name+" "+th.Mode.String())
+	s, _ := th.Sheet.Get("title")
+	checkOutput(t, `foreground: #7D56F4;`, Export(s))
+
+	themes, err := LoadAllThemes(fsys, "themes/*.gloss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for path, th := range themes {
+		names = append(names, path+"="+th.Name)
+	}
+	if len(names) != 2 || themes["themes/light.gloss"].Name != "Light" || themes["themes/dark.gloss"].Name != "Dark" {
+		t.Errorf("unexpected themes: %v", names)
+	}
+
+	for _, tc := range []struct {
+		glob string
+		err  string
+	}{
+		{"broken/*.gloss", `common/bad.gloss:2: style "title": in "bold: maybe": no value found; bold expects true or false`},
+		{"nope/*.gloss", `no theme file matches "nope/*.gloss"`},
+		{"[", `syntax error in pattern`},
+	} {
+		if _, err := LoadAllThemes(fsys, tc.glob); err == nil || err.Error() != tc.err {
+			t.Errorf("%s: expected %q, got %v", tc.glob, tc.err, err)
+		}
+	}
+	if _, err := LoadThemeFS(fsys, "themes/missing.gloss"); err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,18 @@
+package lipglossc
+
+import "github.com/charmbracelet/lipgloss"
+
+// Validate checks the syntax of the style specifications in the
+// input and the validity of all the properties and values. It accepts
+// the same options and returns the same errors as Import, since it
+// imports the input into a throwaway style; the directives of all the
+// @dark, @light and @profile blocks are checked, regardless of the
+// background mode and the color profile, and the WithDirectiveCallback
+// function, if any, is not called.
+func Validate(spec string, opts ...ImportOption) error {
+	opt := makeImportOptions(opts)
+	opt.allConditions = true
+	opt.onDirective = nil
+	_, err := importStyle(lipgloss.NewStyle(), spec, &opt)
+	return err
+}
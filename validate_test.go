@@ -0,0 +1,68 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestValidate(t *testing.T) {
+	td := []struct {
+		in     string
+		expErr string
+	}{
+		{``, ``},
+		{`bold: true; clear; clear-colors; foreground: unset; margin: 1 2`, ``},
+		{`bold: true !important; padding: 1 2 !important`, ``},
+		{`invalid`, `invalid syntax: "invalid"`},
+		{`unsupported: foo`, `in "unsupported: foo": property not supported: "unsupported"`},
+		{`bold: true extra`, `in "bold: true extra": excess values at end: ...extra; bold expects true or false`},
+		{`foreground: adaptive(1,b)`, `in "foreground: adaptive(1,b)": color not recognized: "b"; foreground expects a color (#rgb, #rrggbb, ANSI number, name, adaptive(light,dark) or complete(truecolor,ansi256,ansi))`},
+		{`border: rounded true xx`, `in "border: rounded true xx": no value found; border expects a preset name or border("t","b","l","r","tl","tr","br","bl"), then true or false (repeatable)`},
+	}
+	for _, tc := range td {
+		t.Run(tc.in, func(t *testing.T) {
+			err := Validate(tc.in)
+			if tc.expErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expErr {
+				t.Fatalf("expected error %q, got %v", tc.expErr, err)
+			}
+			// Validate reports the same error as Import.
+			if _, ierr := Import(lipgloss.NewStyle(), tc.in); ierr == nil || ierr.Error() != err.Error() {
+				t.Errorf("Import error mismatch: %v vs %v", ierr, err)
+			}
+		})
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	// Validate honors the same options as Import.
+	styles := map[string]S{"base": lipgloss.NewStyle().Bold(true)}
+	spec := `$pad: 2; padding-left: $pad; apply: base`
+	if err := Validate(spec, WithStyles(styles)); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(spec); err == nil {
+		t.Errorf("expected error for unknown style")
+	}
+	if err := Validate(`bold|true`, WithImportSeparator("|")); err == nil {
+		t.Errorf("expected error")
+	}
+
+	// The directive callback is not called.
+	called := false
+	if err := Validate(`bold: true`, WithDirectiveCallback(func(prop, value string, s S) error {
+		called = true
+		return nil
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Errorf("expected the callback not to be called")
+	}
+}
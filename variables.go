@@ -0,0 +1,124 @@
+package lipglossc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// reVarName matches variable names, including the leading "$".
+var reVarName = regexp.MustCompile(`^\$[a-zA-Z_][-a-zA-Z0-9_]*$`)
+
+// WithImportVariables predefines variables for Import, for example
+// {"accent": "#7D56F4"} makes "foreground: $accent" valid. The
+// variables defined in the input do not modify the map.
+func WithImportVariables(vars map[string]string) ImportOption {
+	return func(o *importOptions) {
+		for name, value := range vars {
+			o.vars[name] = value
+		}
+	}
+}
+
+// withSharedVariables makes Import define the variables in the given
+// map, so that they carry over to the next calls with the same map.
+func withSharedVariables(vars map[string]string) ImportOption {
+	return func(o *importOptions) {
+		o.vars = vars
+	}
+}
+
+// defineVariable processes a "$name: value" directive. Like property
+// values, a value quoted as a whole is stored without the quotes.
+func (opt *importOptions) defineVariable(name, value string) error {
+	if !reVarName.MatchString(name) {
+		return fmt.Errorf("invalid variable name: %q", name)
+	}
+	value, err := expandVariables(value, opt.vars)
+	if err != nil {
+		return err
+	}
+	opt.vars[name[1:]] = unquoteValue(value)
+	return nil
+}
+
+// expandVariables replaces the references to variables in the given
+// property value. Quoted strings are left unchanged.
+func expandVariables(args string, vars map[string]string) (string, error) {
+	if !strings.Contains(args, "$") {
+		return args, nil
+	}
+	var buf strings.Builder
+	inQuote := false
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case inQuote:
+			if c == '\\' && i+1 < len(args) {
+				buf.WriteByte(c)
+				i++
+				c = args[i]
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == '"':
+			inQuote = true
+		case c == '$':
+			j := i + 1
+			for j < len(args) && (isIdentStart(args[j]) || args[j] == '-' || (args[j] >= '0' && args[j] <= '9')) {
+				j++
+			}
+			v, ok := vars[args[i+1:j]]
+			if !ok {
+				return "", fmt.Errorf("undefined variable: %s", args[i:j])
+			}
+			buf.WriteString(v)
+			i = j - 1
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String(), nil
+}
+
+// WithVariables makes Export preserve the structure of themes that use
+// variables: the output starts with the definitions of the variables,
+// and every value identical to the value of a variable is replaced by
+// a reference to it. For example, with {"accent": "#7D56F4"}:
+//
+//	$accent: #7D56F4; foreground: $accent;
+//
+// Values are compared with the variables as printed by Export. When
+// multiple variables have the same value, the first in alphabetical
+// order is used.
+func WithVariables(vars map[string]string) ExportOption {
+	return func(e *options) {
+		e.vars = vars
+	}
+}
+
+// exportVariables returns the variable definitions for WithVariables.
+func exportVariables(opt *options) []propValue {
+	names := make([]string, 0, len(opt.vars))
+	for name := range opt.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	defs := make([]propValue, len(names))
+	for i, name := range names {
+		defs[i] = propValue{name: "$" + name, value: opt.vars[name]}
+	}
+	return defs
+}
+
+// variableRef returns the reference to the variable with the given
+// value, if any.
+func variableRef(defs []propValue, value string) (string, bool) {
+	for _, d := range defs {
+		if d.value == value {
+			return d.name, true
+		}
+	}
+	return "", false
+}
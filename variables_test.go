@@ -0,0 +1,48 @@
+package lipglossc
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestVariables(t *testing.T) {
+	vars := map[string]string{"gutter": "2"}
+	s, err := Import(lipgloss.NewStyle(), `$accent: #7D56F4; $border-fg: $accent;
+foreground: $accent; border-top-foreground: $border-fg; padding: 0 $gutter;
+border-style: border("$","$","|","|","+","+","+","+")`, WithImportVariables(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOutput(t, `border-style: border("$","$","|","|","+","+","+","+"); border-top-foreground: #7D56F4; foreground: #7D56F4; padding-left: 2; padding-right: 2;`, Export(s))
+	if len(vars) != 1 {
+		t.Errorf("variables leaked to the caller: %v", vars)
+	}
+
+	// Export can preserve the variables.
+	checkOutput(t, `$accent: #7D56F4;
+$gutter: 2;
+border-style: border("$","$","|","|","+","+","+","+");
+border-top-foreground: $accent;
+foreground: $accent;
+padding-left: $gutter;
+padding-right: $gutter;`, Export(s, WithSeparator("\n"), WithVariables(map[string]string{"accent": "#7D56F4", "gutter": "2"})))
+
+	for _, tc := range []struct {
+		in, expErr string
+	}{
+		{`foreground: $nope`, `in "foreground: $nope": undefined variable: $nope`},
+		{`$1x: 2`, `in "$1x: 2": invalid variable name: "$1x"`},
+		{`$a: $b`, `in "$a: $b": undefined variable: $b`},
+	} {
+		if _, err := Import(lipgloss.NewStyle(), tc.in); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+		if err := Validate(tc.in); err == nil || err.Error() != tc.expErr {
+			t.Errorf("%s: expected %q, got %v", tc.in, tc.expErr, err)
+		}
+	}
+	if err := Validate(`$a: 1; padding: $a`); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package lipglossc
+
+import "fmt"
+
+// Warning is a problem in an input that does not prevent Import from
+// applying it, e.g. the use of a deprecated property.
+type Warning struct {
+	// Pos is the position of the directive, when known. It is only set
+	// by ImportWithSourceMap.
+	Pos SourcePos
+	// Prop is the property concerned, e.g. "color-whitespace".
+	Prop string
+	// Message describes the problem.
+	Message string
+	// Hint, if not empty, suggests a replacement.
+	Hint string
+}
+
+// String implements fmt.Stringer.
+func (w Warning) String() string {
+	msg := fmt.Sprintf("%s: %s", w.Prop, w.Message)
+	if w.Hint != "" {
+		msg += " (" + w.Hint + ")"
+	}
+	if w.Pos.File != "" {
+		msg = w.Pos.String() + ": " + msg
+	}
+	return msg
+}
+
+// WithWarnings calls fn for every warning during Import. By default,
+// warnings are ignored.
+func WithWarnings(fn func(Warning)) ImportOption {
+	return func(o *importOptions) {
+		o.warn = fn
+	}
+}
+
+// deprecatedProps lists the properties deprecated upstream in
+// lipgloss, with a hint about their replacement. They remain
+// supported for as long as the lipgloss version in use provides them.
+var deprecatedProps = map[string]string{
+	// Deprecated in lipgloss after v0.6.
+	"color-whitespace": "use margins and padding instead",
+}
+
+// checkDeprecated reports a warning if the property is deprecated.
+func (opt *importOptions) checkDeprecated(prop string) {
+	if opt.warn == nil {
+		return
+	}
+	if hint, ok := deprecatedProps[prop]; ok {
+		opt.warn(Warning{Prop: prop, Message: "deprecated property", Hint: hint})
+	}
+}
@@ -0,0 +1,36 @@
+package lipglossc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	var warnings []string
+	collect := WithWarnings(func(w Warning) { warnings = append(warnings, w.String()) })
+
+	s, err := Import(lipgloss.NewStyle(), `bold: true; color-whitespace: false; italic: true`, collect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The deprecated property is still applied.
+	if s.GetColorWhitespace() {
+		t.Errorf("expected color-whitespace to be disabled")
+	}
+	exp := []string{`color-whitespace: deprecated property (use margins and padding instead)`}
+	if fmt.Sprint(warnings) != fmt.Sprint(exp) {
+		t.Errorf("expected %q, got %q", exp, warnings)
+	}
+
+	warnings = nil
+	_, _, err = ImportWithSourceMap(lipgloss.NewStyle(), "bold: true;\ncolor-whitespace: false;", "theme.gloss", collect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp = []string{`theme.gloss:2: color-whitespace: deprecated property (use margins and padding instead)`}
+	if fmt.Sprint(warnings) != fmt.Sprint(exp) {
+		t.Errorf("expected %q, got %q", exp, warnings)
+	}
+}
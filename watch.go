@@ -0,0 +1,181 @@
+package lipglossc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// WatchOption configures Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	interval    time.Duration
+	onError     func(error)
+	importOpts  []ImportOption
+	incremental bool
+}
+
+// WithWatchInterval sets how often Watch checks the file for changes.
+// The default is one second.
+func WithWatchInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+// WithWatchErrors sets a function called when the file cannot be read
+// or imported after a change, e.g. while the user is editing it. The
+// previous styles remain in effect until the next successful import.
+// By default, the errors are ignored.
+func WithWatchErrors(fn func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// WithWatchImportOptions sets the options used to import the file, as
+// per ImportSheet.
+func WithWatchImportOptions(opts ...ImportOption) WatchOption {
+	return func(o *watchOptions) {
+		o.importOpts = opts
+	}
+}
+
+// WithWatchIncremental makes Watch re-apply only the style blocks that
+// changed in the file, and those of the styles that depend on them,
+// instead of importing the whole file again. This keeps the reload
+// latency low for very large themes. The whole file is still imported
+// again when the changes are not limited to style blocks, or when it
+// uses @import, @remove, conditional or version sections, or wildcard
+// selectors.
+// As the unchanged styles are reused, fn must not modify the sheets
+// with Set.
+func WithWatchIncremental() WatchOption {
+	return func(o *watchOptions) {
+		o.incremental = true
+	}
+}
+
+// Watcher monitors a stylesheet document for changes. See Watch.
+type Watcher struct {
+	path string
+	opt  watchOptions
+	fn   func(StyleSheet)
+	// data is the contents of the file at the last successful import,
+	// and sheet the result, and modTime and size the attributes of the
+	// file when last read, to detect changes.
+	data    []byte
+	sheet   StyleSheet
+	modTime time.Time
+	size    int64
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Watch imports the stylesheet document at the given path, then
+// monitors the file and imports it again every time it changes,
+// calling fn with the resulting sheet. This makes it possible to
+// edit the styles of an application while it is running. For example,
+// in a Bubble Tea program:
+//
+//	w, err := lipglossc.Watch("theme.gloss", func(ss lipglossc.StyleSheet) {
+//	    p.Send(themeChangedMsg{ss})
+//	})
+//	defer w.Close()
+//
+// fn is called once with the initial sheet before Watch returns, then
+// from a separate goroutine upon every change. Applications that
+// prefer a channel can send the sheet to it from fn.
+//
+// The file is polled, see WithWatchInterval. An error is returned if
+// the initial import fails; later errors are reported with
+// WithWatchErrors.
+func Watch(path string, fn func(StyleSheet), opts ...WatchOption) (*Watcher, error) {
+	w := &Watcher{
+		path: path,
+		opt:  watchOptions{interval: time.Second},
+		fn:   fn,
+		size: -1,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(&w.opt)
+	}
+	if err := w.check(); err != nil {
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+// Close stops monitoring the file. After Close returns, fn is not
+// called anymore.
+func (w *Watcher) Close() {
+	w.once.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+	t := time.NewTicker(w.opt.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+		}
+		if err := w.check(); err != nil && w.opt.onError != nil {
+			w.opt.onError(err)
+		}
+	}
+}
+
+// check imports the file if it changed since the last import, and
+// calls fn with the result.
+func (w *Watcher) check() error {
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	if fi.ModTime().Equal(w.modTime) && fi.Size() == w.size {
+		return nil
+	}
+	data, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	// Remember the attributes even if the import fails, so that the
+	// error is only reported once per change.
+	w.modTime, w.size = fi.ModTime(), fi.Size()
+	if w.data != nil && bytes.Equal(data, w.data) {
+		return nil
+	}
+	ss, err := w.importSheet(string(data))
+	if err != nil {
+		w.data = nil
+		return err
+	}
+	w.data, w.sheet = data, ss
+	w.fn(ss)
+	return nil
+}
+
+// importSheet imports the new contents of the file, incrementally if
+// possible and requested.
+func (w *Watcher) importSheet(input string) (StyleSheet, error) {
+	if w.opt.incremental && w.data != nil {
+		ss, _, ok, err := importIncremental(w.sheet, string(w.data), input, w.opt.importOpts)
+		if ok {
+			return ss, err
+		}
+	}
+	return ImportSheet(StyleSheet{}, input, w.opt.importOpts...)
+}
@@ -0,0 +1,124 @@
+package lipglossc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "theme.gloss")
+	write := func(contents string, age time.Duration) {
+		t.Helper()
+		if err := ioutil.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		// Ensure that every write changes the modification time, even
+		// with coarse file system timestamps.
+		mt := time.Now().Add(-age)
+		if err := os.Chtimes(path, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := Watch(path, func(StyleSheet) {}); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+
+	write("title { bold: true; }", time.Hour)
+	sheets := make(chan StyleSheet, 10)
+	errs := make(chan error, 10)
+	w, err := Watch(path, func(ss StyleSheet) { sheets <- ss },
+		WithWatchInterval(5*time.Millisecond),
+		WithWatchErrors(func(err error) { errs <- err }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	next := func() StyleSheet {
+		t.Helper()
+		select {
+		case ss := <-sheets:
+			return ss
+		case err := <-errs:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+		return StyleSheet{}
+	}
+	s, _ := next().Get("title")
+	checkOutput(t, `bold: true;`, Export(s))
+
+	write("title { italic: true; }", 2*time.Minute)
+	s, _ = next().Get("title")
+	checkOutput(t, `italic: true;`, Export(s))
+
+	// Errors are reported once, and the next valid version is imported.
+	write("title { italic: maybe; }", time.Minute)
+	select {
+	case err := <-errs:
+		checkOutput(t, `line 1: style "title": in "italic: maybe": no value found; italic expects true or false`, err.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+	write("title { faint: true; }", 0)
+	s, _ = next().Get("title")
+	checkOutput(t, `faint: true;`, Export(s))
+
+	w.Close()
+	write("title { bold: true; }", 0)
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-sheets:
+		t.Errorf("unexpected reload after Close")
+	case err := <-errs:
+		t.Errorf("unexpected error: %v", err)
+	default:
+	}
+}
+
+func TestWatchIncremental(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "theme.gloss")
+	write := func(contents string, age time.Duration) {
+		t.Helper()
+		if err := ioutil.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mt := time.Now().Add(-age)
+		if err := os.Chtimes(path, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("title { bold: true; }\nfooter { faint: true; }", time.Hour)
+	sheets := make(chan StyleSheet, 10)
+	w, err := Watch(path, func(ss StyleSheet) { sheets <- ss },
+		WithWatchInterval(5*time.Millisecond), WithWatchIncremental())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	<-sheets
+
+	write("title { italic: true; }\nfooter { faint: true; }", 0)
+	select {
+	case ss := <-sheets:
+		checkOutput(t, "footer { faint: true; }\ntitle { italic: true; }\n", ss.Export())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
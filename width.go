@@ -0,0 +1,69 @@
+package lipglossc
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CheckWidth reports when the width or max-width of the style is too
+// small for the given sample content. The content is measured by its
+// display width, so that e.g. emoji and CJK characters count as two
+// columns. For multi-line samples, the widest line is considered.
+//
+// A width that is too small causes the content to wrap; a max-width
+// that is too small causes the rendered block to be truncated.
+func CheckWidth(s S, samples ...string) []string {
+	var problems []string
+	if w := s.GetWidth(); w > 0 {
+		avail := w - s.GetHorizontalPadding()
+		for _, sample := range samples {
+			if sw := lipgloss.Width(sample); sw > avail {
+				problems = append(problems, fmt.Sprintf("width %d leaves %d columns for content, but %q is %d columns wide",
+					w, avail, sample, sw))
+			}
+		}
+	}
+	if mw := s.GetMaxWidth(); mw > 0 {
+		frame := s.GetHorizontalPadding() + s.GetHorizontalBorderSize() + s.GetHorizontalMargins()
+		for _, sample := range samples {
+			needed := lipgloss.Width(sample) + frame
+			if s.GetWidth() > 0 {
+				// Content wider than the width wraps, narrower content
+				// is padded.
+				needed = s.GetWidth() + s.GetHorizontalBorderSize() + s.GetHorizontalMargins()
+			}
+			if needed > mw {
+				problems = append(problems, fmt.Sprintf("max-width %d truncates %q, which needs %d columns with padding, borders and margins",
+					mw, sample, needed))
+			}
+		}
+	}
+	return problems
+}
+
+// FitWidth returns a copy of the style with its width increased, if
+// necessary, so that the given sample content fits without wrapping.
+// The width is measured as in CheckWidth. Styles without a width are
+// returned unchanged, since their content does not wrap.
+func FitWidth(s S, samples ...string) S {
+	s = s.Copy()
+	w := s.GetWidth()
+	if w == 0 {
+		return s
+	}
+	if needed := maxSampleWidth(samples...) + s.GetHorizontalPadding(); needed > w {
+		s = s.Width(needed)
+	}
+	return s
+}
+
+func maxSampleWidth(samples ...string) int {
+	res := 0
+	for _, sample := range samples {
+		if w := lipgloss.Width(sample); w > res {
+			res = w
+		}
+	}
+	return res
+}
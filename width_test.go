@@ -0,0 +1,35 @@
+package lipglossc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCheckWidth(t *testing.T) {
+	s := lipgloss.NewStyle().Width(10).PaddingLeft(2).PaddingRight(2)
+	checkOutput(t, `width 10 leaves 6 columns for content, but "こんにちは" is 10 columns wide`,
+		strings.Join(CheckWidth(s, "hello", "こんにちは"), "\n"))
+
+	s = lipgloss.NewStyle().MaxWidth(8).Padding(0, 1).Border(lipgloss.NormalBorder())
+	checkOutput(t, `max-width 8 truncates "🎉🎉🎉", which needs 10 columns with padding, borders and margins`,
+		strings.Join(CheckWidth(s, "abc", "🎉🎉🎉"), "\n"))
+
+	if p := CheckWidth(lipgloss.NewStyle(), "こんにちは"); len(p) != 0 {
+		t.Errorf("unexpected problems: %v", p)
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	s := lipgloss.NewStyle().Width(6).PaddingLeft(1)
+	fitted := FitWidth(s, "ok", "世界世界")
+	checkOutput(t, `padding-left: 1; width: 9;`, Export(fitted))
+	checkOutput(t, `padding-left: 1; width: 6;`, Export(s))
+	if p := CheckWidth(fitted, "ok", "世界世界"); len(p) != 0 {
+		t.Errorf("unexpected problems: %v", p)
+	}
+
+	checkOutput(t, `padding-left: 1; width: 6;`, Export(FitWidth(s, "ok")))
+	checkOutput(t, ``, Export(FitWidth(lipgloss.NewStyle(), "世界世界")))
+}